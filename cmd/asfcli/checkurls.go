@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func newCheckURLsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "check-urls",
+		Usage: "Probe the download URLs in a saved results file and report dead links, redirects and sizes",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from",
+				Usage:    "Saved results file (JSON array or GeoJSON)",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of URLs probed in parallel",
+				Value: 8,
+			},
+		},
+		Action: executeCheckURLs,
+	}
+}
+
+func executeCheckURLs(ctx context.Context, cmd *cli.Command) error {
+	products, err := readProductsFile(strings.TrimSpace(cmd.String("from")))
+	if err != nil {
+		return err
+	}
+	client := buildClient(cmd)
+
+	type check struct {
+		result asf.ProbeResult
+		err    error
+	}
+	checks := make([]check, len(products))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(1, cmd.Int("concurrency")))
+	for i, product := range products {
+		g.Go(func() error {
+			result, err := client.Probe(gctx, product.Properties.URL)
+			checks[i] = check{result: result, err: err}
+			return nil
+		})
+	}
+	g.Wait()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tSIZE\tURL\tFINAL URL")
+	dead := 0
+	for _, c := range checks {
+		status, size, final := "ERROR", "-", "-"
+		if c.err == nil {
+			status = strconv.Itoa(c.result.StatusCode)
+			if c.result.Size >= 0 {
+				size = strconv.FormatInt(c.result.Size, 10)
+			}
+			if c.result.FinalURL != c.result.URL {
+				final = c.result.FinalURL
+			}
+		}
+		if c.err != nil || !c.result.OK() {
+			dead++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", status, size, c.result.URL, final)
+	}
+	tw.Flush()
+	for _, c := range checks {
+		if c.err != nil {
+			fmt.Fprintln(os.Stderr, c.err)
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "%d of %d URL(s) OK\n", len(checks)-dead, len(checks))
+	if dead > 0 {
+		return fmt.Errorf("check-urls: %d dead link(s)", dead)
+	}
+	return nil
+}
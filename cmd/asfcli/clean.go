@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func newCleanCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "clean",
+		Usage: "Remove, or resume, partial downloads left behind in a download directory",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "dir",
+				Usage:   "Download directory to scan (recursively)",
+				Aliases: []string{"d"},
+				Value:   ".",
+			},
+			&cli.DurationFlag{
+				Name:  "older-than",
+				Usage: "Only consider .part files not written to for this long",
+				Value: 7 * 24 * time.Hour,
+			},
+			&cli.StringFlag{
+				Name:  "resume-from",
+				Usage: "Saved results file; stale .part files of products it lists are resumed instead of removed",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List what would be removed or resumed without changing anything",
+			},
+		},
+		Action: executeClean,
+	}
+}
+
+func executeClean(ctx context.Context, cmd *cli.Command) error {
+	dir := strings.TrimSpace(cmd.String("dir"))
+	parts, err := asf.StalePartFiles(dir, time.Now().Add(-cmd.Duration("older-than")))
+	if err != nil {
+		return fmt.Errorf("clean: %w", err)
+	}
+
+	known := make(map[string]asf.Product)
+	if from := strings.TrimSpace(cmd.String("resume-from")); from != "" {
+		products, err := readProductsFile(from)
+		if err != nil {
+			return err
+		}
+		for _, product := range products {
+			if product.Properties.FileName != "" {
+				known[filepath.Join(dir, product.Properties.FileName)] = product
+			}
+		}
+	}
+
+	var resume []asf.Product
+	dryRun := cmd.Bool("dry-run")
+	for _, part := range parts {
+		if product, ok := known[part.DestPath]; ok {
+			action := "resume"
+			if part.Segmented {
+				action = "restart"
+			}
+			fmt.Fprintf(os.Stdout, "%s\t%s\n", action, part.Path)
+			resume = append(resume, product)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "remove\t%s\n", part.Path)
+		if dryRun {
+			continue
+		}
+		if err := os.Remove(part.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("clean: %w", err)
+		}
+	}
+	if len(parts) == 0 {
+		fmt.Fprintln(os.Stdout, "No stale partial downloads.")
+	}
+	if dryRun || len(resume) == 0 {
+		return nil
+	}
+	if err := buildClient(cmd).Download(ctx, dir, resume...); err != nil {
+		return fmt.Errorf("clean: resume: %w", err)
+	}
+	return nil
+}
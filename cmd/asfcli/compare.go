@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func newCompareCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "compare",
+		Usage:     "Compare two saved result files and report added, removed and changed granules",
+		ArgsUsage: "<results_a> <results_b>",
+		Action:    executeCompare,
+	}
+}
+
+func executeCompare(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 2 {
+		return fmt.Errorf("compare: expected exactly two result files")
+	}
+	a, err := readProductsFile(cmd.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	b, err := readProductsFile(cmd.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	printComparison(os.Stdout, a, b)
+	return nil
+}
+
+// readProductsFile loads products saved by asfcli or the ASF API.
+func readProductsFile(path string) ([]asf.Product, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open results: %w", err)
+	}
+	defer file.Close()
+
+	products, err := asf.ReadProducts(file)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return products, nil
+}
+
+func printComparison(w io.Writer, a, b []asf.Product) {
+	inA := indexProducts(a)
+	inB := indexProducts(b)
+
+	var onlyA, onlyB, changed []string
+	for key := range inA {
+		if _, ok := inB[key]; !ok {
+			onlyA = append(onlyA, key)
+		}
+	}
+	for key := range inB {
+		if _, ok := inA[key]; !ok {
+			onlyB = append(onlyB, key)
+		}
+	}
+	diffs := make(map[string][]string)
+	for key, pa := range inA {
+		if pb, ok := inB[key]; ok {
+			if d := diffProducts(pa.Properties, pb.Properties); len(d) > 0 {
+				changed = append(changed, key)
+				diffs[key] = d
+			}
+		}
+	}
+	slices.Sort(onlyA)
+	slices.Sort(onlyB)
+	slices.Sort(changed)
+
+	fmt.Fprintf(w, "Only in A (%d):\n", len(onlyA))
+	for _, key := range onlyA {
+		fmt.Fprintf(w, "  %s\n", key)
+	}
+	fmt.Fprintf(w, "Only in B (%d):\n", len(onlyB))
+	for _, key := range onlyB {
+		fmt.Fprintf(w, "  %s\n", key)
+	}
+	fmt.Fprintf(w, "Changed (%d):\n", len(changed))
+	for _, key := range changed {
+		fmt.Fprintf(w, "  %s\n", key)
+		for _, d := range diffs[key] {
+			fmt.Fprintf(w, "    %s\n", d)
+		}
+	}
+}
+
+func indexProducts(products []asf.Product) map[string]asf.Product {
+	index := make(map[string]asf.Product, len(products))
+	for _, p := range products {
+		index[p.ID()] = p
+	}
+	return index
+}
+
+// diffProducts describes metadata differences relevant to reprocessing.
+func diffProducts(a, b asf.Properties) []string {
+	var diffs []string
+	if a.Bytes != b.Bytes {
+		diffs = append(diffs, fmt.Sprintf("bytes: %d -> %d", a.Bytes, b.Bytes))
+	}
+	if a.Md5sum != b.Md5sum {
+		diffs = append(diffs, fmt.Sprintf("md5sum: %s -> %s", a.Md5sum, b.Md5sum))
+	}
+	if !a.ProcessingDate.Equal(b.ProcessingDate) {
+		diffs = append(diffs, fmt.Sprintf("processingDate: %s -> %s", formatTime(a.ProcessingDate), formatTime(b.ProcessingDate)))
+	}
+	return diffs
+}
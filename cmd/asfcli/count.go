@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+)
+
+func newCountCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "count",
+		Usage:  "Print the number of products matching a search without fetching them",
+		Flags:  searchFilterFlags(),
+		Action: executeCount,
+	}
+}
+
+func executeCount(ctx context.Context, cmd *cli.Command) error {
+	opts, err := searchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	count, err := buildClient(cmd).Count(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("count: %w", err)
+	}
+	fmt.Fprintln(os.Stdout, count)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/digest"
+)
+
+func newDigestCommand() *cli.Command {
+	flags := append(searchFilterFlags(),
+		&cli.IntFlag{
+			Name:  "days",
+			Usage: "Number of days, ending now, to summarize",
+			Value: 1,
+		},
+		&cli.IntFlag{
+			Name:  "baseline-days",
+			Usage: "Days before the window checked for tracks already acquired (default: same as --days)",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Output format: markdown or html",
+			Value: "markdown",
+		},
+	)
+	return &cli.Command{
+		Name:   "digest",
+		Usage:  "Summarize recent acquisitions matching a search as Markdown or HTML",
+		Flags:  flags,
+		Action: executeDigest,
+	}
+}
+
+func executeDigest(ctx context.Context, cmd *cli.Command) error {
+	opts, err := searchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != "markdown" && format != "html" {
+		return fmt.Errorf("digest: unsupported --format %q", cmd.String("format"))
+	}
+	d, err := digest.Generate(ctx, buildClient(cmd), opts, digest.Options{
+		Days:         cmd.Int("days"),
+		BaselineDays: cmd.Int("baseline-days"),
+	})
+	if err != nil {
+		return err
+	}
+	if format == "html" {
+		return d.WriteHTML(os.Stdout)
+	}
+	return d.WriteMarkdown(os.Stdout)
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func newDownloadCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "download",
+		Usage:     "Download granules, saved search results, URL lists or Vertex bulk-download manifests",
+		ArgsUsage: "[granule ...]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "from",
+				Usage: "Saved results file (JSON array or GeoJSON) (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "urls",
+				Usage: "Text file with one download URL per line (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "manifest",
+				Usage: "Bulk-download manifest exported from Vertex (.metalink, .meta4 or .csv; repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "product-type",
+				Usage: "Product types to fetch for granule arguments (repeatable; default all non-metadata products)",
+			},
+			&cli.StringSliceFlag{
+				Name:    "include",
+				Aliases: []string{"include-pattern"},
+				Usage:   "Only download products whose file names match this glob, e.g. '*.zip' (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:    "exclude",
+				Aliases: []string{"exclude-pattern"},
+				Usage:   "Skip products whose file names match this glob, e.g. '*.iso.xml' (repeatable)",
+			},
+			&cli.StringFlag{
+				Name:    "dir",
+				Usage:   "Destination directory",
+				Aliases: []string{"d"},
+				Value:   ".",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of files downloaded in parallel (default number of CPUs)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "host-concurrency",
+				Usage: "Cap parallel downloads from a host and its subdomains separately, e.g. amazonaws.com=32 (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "adaptive",
+				Usage: "Tune the number of parallel downloads to throughput and server throttling, up to --concurrency (default 32)",
+			},
+			&cli.IntFlag{
+				Name:  "segments",
+				Usage: "Fetch each file of 64 MiB or more as this many parallel byte ranges",
+			},
+			&cli.BoolFlag{
+				Name:  "resume",
+				Usage: "Resume partially downloaded files",
+				Value: true,
+			},
+			&cli.BoolFlag{
+				Name:  "preserve-timestamps",
+				Usage: "Set each file's modification time from the server's Last-Modified header",
+			},
+			&cli.StringFlag{
+				Name:  "temp-dir",
+				Usage: "Keep partial downloads in this directory instead of next to their destination",
+			},
+			&cli.IntFlag{
+				Name:  "retries",
+				Usage: "Retry each file up to this many times after a transient failure, resuming where it stopped",
+			},
+			&cli.DurationFlag{
+				Name:  "retry-backoff",
+				Usage: "Wait before the first file retry, doubled on each further retry",
+				Value: time.Second,
+			},
+			&cli.BoolFlag{
+				Name:  "fail-fast",
+				Usage: "Abort the remaining downloads at the first failure",
+			},
+			&cli.BoolFlag{
+				Name:  "warm-up",
+				Usage: "Log in once before starting parallel downloads, failing early on bad credentials",
+			},
+			&cli.BoolFlag{
+				Name:  "verify",
+				Usage: "Verify downloads against their published MD5 checksums",
+			},
+			&cli.BoolFlag{
+				Name:  "progress",
+				Usage: "Show per-file progress with size, speed and ETA",
+				Value: true,
+			},
+		},
+		Action: executeDownload,
+	}
+}
+
+func executeDownload(ctx context.Context, cmd *cli.Command) error {
+	opts := []asf.Option{asf.WithDownloadConcurrency(cmd.Int("concurrency"))}
+	if !cmd.Bool("resume") {
+		opts = append(opts, asf.WithNoResume())
+	}
+	include, exclude := cmd.StringSlice("include"), cmd.StringSlice("exclude")
+	for _, pattern := range slices.Concat(include, exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("download: invalid pattern %q: %w", pattern, err)
+		}
+	}
+	if len(include) > 0 || len(exclude) > 0 {
+		opts = append(opts, asf.WithFilePatterns(include, exclude))
+	}
+	if values := cmd.StringSlice("host-concurrency"); len(values) > 0 {
+		limits := make(map[string]int, len(values))
+		for _, value := range values {
+			host, n, ok := strings.Cut(value, "=")
+			limit, err := strconv.Atoi(strings.TrimSpace(n))
+			if !ok || strings.TrimSpace(host) == "" || err != nil || limit <= 0 {
+				return fmt.Errorf("download: invalid --host-concurrency %q; want host=n", value)
+			}
+			limits[strings.TrimSpace(host)] = limit
+		}
+		opts = append(opts, asf.WithHostConcurrency(limits))
+	}
+	if cmd.Bool("adaptive") {
+		opts = append(opts, asf.WithAdaptiveConcurrency())
+	}
+	if segments := cmd.Int("segments"); segments > 1 {
+		opts = append(opts, asf.WithSegmentedDownloads(segments, 64<<20))
+	}
+	if cmd.Bool("preserve-timestamps") {
+		opts = append(opts, asf.WithPreserveTimestamps())
+	}
+	if dir := strings.TrimSpace(cmd.String("temp-dir")); dir != "" {
+		opts = append(opts, asf.WithTempDir(dir))
+	}
+	if retries := cmd.Int("retries"); retries > 0 {
+		opts = append(opts, asf.WithDownloadRetries(retries, cmd.Duration("retry-backoff")))
+	}
+	if cmd.Bool("fail-fast") {
+		opts = append(opts, asf.WithBatchMode(asf.FailFast))
+	}
+	if cmd.Bool("warm-up") {
+		opts = append(opts, asf.WithAuthWarmup())
+	}
+	if cmd.Bool("verify") {
+		opts = append(opts, asf.WithChecksumVerification())
+	}
+	if cmd.Bool("progress") {
+		bars := newProgressBars()
+		defer bars.Finish()
+		opts = append(opts, asf.WithProgress(bars.Update))
+	}
+	client := buildClient(cmd, opts...)
+
+	var products []asf.Product
+	if granules := convertSlice[string](cmd.Args().Slice()); len(granules) > 0 {
+		found, err := client.SearchAll(ctx, asf.SearchOptions{
+			GranuleIDs:   granules,
+			ProductTypes: convertSlice[asf.ProductType](cmd.StringSlice("product-type")),
+		})
+		if err != nil {
+			return fmt.Errorf("look up granules: %w", err)
+		}
+		for _, product := range found {
+			if !isMetadataProduct(product.Properties) {
+				products = append(products, product)
+			}
+		}
+	}
+	for _, path := range cmd.StringSlice("from") {
+		loaded, err := readProductsFile(strings.TrimSpace(path))
+		if err != nil {
+			return err
+		}
+		products = append(products, loaded...)
+	}
+	for _, path := range cmd.StringSlice("manifest") {
+		loaded, err := readManifestFile(strings.TrimSpace(path))
+		if err != nil {
+			return err
+		}
+		products = append(products, loaded...)
+	}
+	var urls []string
+	for _, path := range cmd.StringSlice("urls") {
+		loaded, err := readURLList(strings.TrimSpace(path))
+		if err != nil {
+			return err
+		}
+		urls = append(urls, loaded...)
+	}
+	if len(products) == 0 && len(urls) == 0 {
+		return fmt.Errorf("download: nothing to download; pass granule IDs, --from, --urls or --manifest")
+	}
+
+	dir := strings.TrimSpace(cmd.String("dir"))
+	fmt.Fprintf(os.Stderr, "Downloading %d file(s) to %s...\n", len(products)+len(urls), dir)
+	productsErr := client.Download(ctx, dir, products...)
+	var urlsErr error
+	if productsErr == nil || !cmd.Bool("fail-fast") {
+		// A failed product does not stop the URL list unless failing fast.
+		urlsErr = client.DownloadURLs(ctx, dir, urls...)
+	}
+	failed := 0
+	for _, err := range []error{productsErr, urlsErr} {
+		var batchErr *asf.BatchError
+		if err != nil && !errors.As(err, &batchErr) {
+			return fmt.Errorf("download: %w", err)
+		}
+		if batchErr != nil {
+			for _, failure := range batchErr.Errors {
+				fmt.Fprintf(os.Stderr, "FAILED %s: %v\n", failure.Product.Properties.FileName, failure.Err)
+			}
+			failed += len(batchErr.Errors)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("download: %d of %d file(s) failed", failed, len(products)+len(urls))
+	}
+	return nil
+}
+
+// readURLList reads one URL per line, ignoring blank lines and # comments.
+func readURLList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open URL list: %w", err)
+	}
+	var urls []string
+	for line := range strings.Lines(string(data)) {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			urls = append(urls, line)
+		}
+	}
+	return urls, nil
+}
+
+// readManifestFile loads a Vertex bulk-download manifest, choosing the parser
+// from the file extension.
+func readManifestFile(path string) ([]asf.Product, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer file.Close()
+
+	var products []asf.Product
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".metalink", ".meta4":
+		products, err = asf.ReadMetalink(file)
+	case ".csv":
+		products, err = asf.ReadCSVManifest(file)
+	default:
+		return nil, fmt.Errorf("unsupported manifest type %q (want .metalink, .meta4 or .csv)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return products, nil
+}
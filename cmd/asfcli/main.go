@@ -7,6 +7,9 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -14,6 +17,8 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/robert-malhotra/go-asf/pkg/asf"
+	"github.com/robert-malhotra/go-asf/pkg/coverage"
+	"github.com/robert-malhotra/go-asf/pkg/stac"
 )
 
 func main() {
@@ -30,6 +35,18 @@ func main() {
 		},
 		Commands: []*cli.Command{
 			newSearchCommand(),
+			newCountCommand(),
+			newMissionsCommand(),
+			newStackCommand(),
+			newCompareCommand(),
+			newDownloadCommand(),
+			newCheckURLsCommand(),
+			newVerifyCommand(),
+			newCleanCommand(),
+			newWatchCommand(),
+			newDigestCommand(),
+			newSchemaCommand(),
+			newRunCommand(),
 		},
 	}
 
@@ -42,135 +59,405 @@ func newSearchCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "search",
 		Usage: "Execute a search against the ASF API",
-		Flags: []cli.Flag{
-			&cli.StringSliceFlag{
-				Name:    "platform",
-				Usage:   "Filter by platform (repeatable)",
-				Aliases: []string{"p"},
-			},
-			&cli.StringSliceFlag{
-				Name:    "beam-mode",
-				Usage:   "Filter by beam mode (repeatable)",
-				Aliases: []string{"b"},
-			},
-			&cli.StringSliceFlag{
-				Name:  "polarization",
-				Usage: "Filter by polarization (repeatable)",
-			},
-			&cli.StringSliceFlag{
-				Name:  "product-type",
-				Usage: "Filter by product type (repeatable)",
-			},
-			&cli.StringSliceFlag{
-				Name:  "collection",
-				Usage: "Filter by collection name (repeatable)",
-			},
-			&cli.StringSliceFlag{
-				Name:  "processing-level",
-				Usage: "Filter by processing level (repeatable)",
-			},
-			&cli.StringSliceFlag{
-				Name:  "look-direction",
-				Usage: "Filter by look direction (repeatable)",
+		Flags: append(searchFilterFlags(),
+			&cli.IntFlag{
+				Name:  "max-results",
+				Usage: "Maximum number of results to return",
 			},
-			&cli.StringFlag{
-				Name:  "relative-orbit",
-				Usage: "Filter by relative orbit",
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Page through all results (--max-results caps the total)",
 			},
 			&cli.StringFlag{
-				Name:  "flight-direction",
-				Usage: "Filter by flight direction (ASCENDING or DESCENDING)",
+				Name:  "sort-by",
+				Usage: "Order results by startTime, stopTime, processingDate, sceneName, pathNumber or frameNumber",
 			},
 			&cli.StringFlag{
-				Name:  "intersects",
-				Usage: "WKT or GeoJSON geometry for intersectsWith filter",
-			},
-			&cli.StringSliceFlag{
-				Name:    "granule",
-				Usage:   "Filter by specific granule IDs (repeatable)",
-				Aliases: []string{"g"},
+				Name:  "sort-order",
+				Usage: "Sort direction (asc or desc)",
+				Value: "asc",
 			},
 			&cli.StringFlag{
-				Name:  "start",
-				Usage: "Start time (RFC3339)",
+				Name:  "output",
+				Usage: "Output format (text, json, csv, kml, kmz, stac or ndjson; ndjson streams all pages)",
+				Value: "text",
 			},
 			&cli.StringFlag{
-				Name:  "end",
-				Usage: "End time (RFC3339)",
-			},
-			&cli.IntFlag{
-				Name:  "max-results",
-				Usage: "Maximum number of results to return",
+				Name:  "map",
+				Usage: "Draw the result footprints to this PNG or SVG file (chosen by extension)",
 			},
 			&cli.StringFlag{
-				Name:  "output",
-				Usage: "Output format (text or json)",
-				Value: "text",
+				Name:  "map-background",
+				Usage: "GeoJSON file of lines, such as a coastline, to draw beneath the --map footprints",
 			},
 			&cli.StringFlag{
 				Name:  "download-dir",
 				Usage: "Download all matching products to the specified directory",
 			},
-		},
+		),
 		Action: executeSearch,
 	}
 }
 
-func executeSearch(ctx context.Context, cmd *cli.Command) error {
-	client := buildClient(cmd)
+// searchFilterFlags returns the flags shared by commands that build a search
+// query; searchOptionsFromFlags reads them back.
+func searchFilterFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    "platform",
+			Usage:   "Filter by platform (repeatable)",
+			Aliases: []string{"p"},
+		},
+		&cli.StringSliceFlag{
+			Name:    "beam-mode",
+			Usage:   "Filter by beam mode (repeatable)",
+			Aliases: []string{"b"},
+		},
+		&cli.StringSliceFlag{
+			Name:  "polarization",
+			Usage: "Filter by polarization (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "product-type",
+			Usage: "Filter by product type (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "collection",
+			Usage: "Filter by collection name (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "processing-level",
+			Usage: "Filter by processing level (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "look-direction",
+			Usage: "Filter by look direction (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "relative-orbit",
+			Usage: "Filter by relative orbit, accepting ranges like 15-17 (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "absolute-orbit",
+			Usage: "Filter by absolute orbit, accepting ranges like 4750-4760 (repeatable)",
+		},
+		&cli.StringFlag{
+			Name:  "frame",
+			Usage: "Filter by ESA frame number or inclusive frame range, e.g. 100-120",
+		},
+		&cli.StringFlag{
+			Name:  "asf-frame",
+			Usage: "Filter by ASF frame number or inclusive frame range, which differ from ESA frames",
+		},
+		&cli.StringFlag{
+			Name:  "flight-direction",
+			Usage: "Filter by flight direction (ASCENDING or DESCENDING)",
+		},
+		&cli.StringFlag{
+			Name:  "intersects",
+			Usage: "WKT or GeoJSON geometry for intersectsWith filter",
+		},
+		&cli.StringFlag{
+			Name:  "season",
+			Usage: "Only acquisitions between two days of the year, as start,end (e.g. 152,243 for summer)",
+		},
+		&cli.StringFlag{
+			Name:  "bbox",
+			Usage: "Bounding box filter as west,south,east,north in degrees",
+		},
+		&cli.StringFlag{
+			Name:  "point",
+			Usage: "Point filter as lat,lon; matches footprints containing the point",
+		},
+		&cli.StringSliceFlag{
+			Name:  "dataset",
+			Usage: "Filter by dataset, e.g. SLC-BURST (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "full-burst-id",
+			Usage: "Filter bursts by full burst ID, e.g. 064_136213_IW2 (repeatable)",
+		},
+		&cli.IntSliceFlag{
+			Name:  "relative-burst-id",
+			Usage: "Filter bursts by relative burst ID (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "subswath",
+			Usage: "Filter bursts by subswath, e.g. IW2 (repeatable, applied client-side)",
+		},
+		&cli.IntSliceFlag{
+			Name:  "burst-index",
+			Usage: "Filter bursts by burst index (repeatable, applied client-side)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "granule",
+			Usage:   "Filter by specific granule IDs (repeatable)",
+			Aliases: []string{"g"},
+		},
+		&cli.StringFlag{
+			Name:  "start",
+			Usage: "Start time (RFC3339)",
+		},
+		&cli.StringFlag{
+			Name:  "end",
+			Usage: "End time (RFC3339)",
+		},
+		&cli.BoolFlag{
+			Name:  "omit-geometry",
+			Usage: "Drop footprints from results to save memory when only metadata is needed",
+		},
+		&cli.StringFlag{
+			Name:  "processed-after",
+			Usage: "Only products processed by ASF after this time (RFC3339)",
+		},
+		&cli.StringFlag{
+			Name:  "processed-before",
+			Usage: "Only products processed by ASF before this time (RFC3339, applied client-side)",
+		},
+	}
+}
 
+// searchOptionsFromFlags builds search options from the flags declared by
+// searchFilterFlags.
+func searchOptionsFromFlags(cmd *cli.Command) (asf.SearchOptions, error) {
 	start, err := parseTimeFlag(cmd, "start")
 	if err != nil {
-		return err
+		return asf.SearchOptions{}, err
 	}
 	end, err := parseTimeFlag(cmd, "end")
 	if err != nil {
-		return err
+		return asf.SearchOptions{}, err
+	}
+	processedAfter, err := parseTimeFlag(cmd, "processed-after")
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+	processedBefore, err := parseTimeFlag(cmd, "processed-before")
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+	relativeOrbits, err := parseRangesFlag(cmd, "relative-orbit")
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+	absoluteOrbits, err := parseRangesFlag(cmd, "absolute-orbit")
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+	frameStart, frameEnd, err := parseFrameFlag(cmd, "frame")
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+	asfFrameStart, asfFrameEnd, err := parseFrameFlag(cmd, "asf-frame")
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+
+	season, err := parseFloatsFlag(cmd, "season", 2)
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+	bbox, err := parseFloatsFlag(cmd, "bbox", 4)
+	if err != nil {
+		return asf.SearchOptions{}, err
+	}
+	intersects := strings.TrimSpace(cmd.String("intersects"))
+	if point, err := parseFloatsFlag(cmd, "point", 2); err != nil {
+		return asf.SearchOptions{}, err
+	} else if point != nil {
+		if intersects != "" || bbox != nil {
+			return asf.SearchOptions{}, fmt.Errorf("--point cannot be combined with --intersects or --bbox")
+		}
+		intersects = fmt.Sprintf("POINT (%g %g)", point[1], point[0])
 	}
 
 	opts := asf.SearchOptions{
-		Platforms:       convertSlice[asf.Platform](cmd.StringSlice("platform")),
-		BeamModes:       convertSlice[asf.BeamMode](cmd.StringSlice("beam-mode")),
-		Polarizations:   convertSlice[asf.Polarization](cmd.StringSlice("polarization")),
-		ProductTypes:    convertSlice[asf.ProductType](cmd.StringSlice("product-type")),
-		Collections:     convertSlice[asf.CollectionName](cmd.StringSlice("collection")),
-		ProcessingLevel: convertSlice[asf.ProcessingLevel](cmd.StringSlice("processing-level")),
-		LookDirections:  convertSlice[asf.LookDirection](cmd.StringSlice("look-direction")),
-		RelativeOrbit:   strings.TrimSpace(cmd.String("relative-orbit")),
-		FlightDirection: asf.FlightDirection(strings.TrimSpace(cmd.String("flight-direction"))),
-		IntersectsWith:  strings.TrimSpace(cmd.String("intersects")),
-		GranuleIDs:      convertSlice[string](cmd.StringSlice("granule")),
-		Start:           start,
-		End:             end,
-		MaxResults:      cmd.Int("max-results"),
-	}
-
-	products, err := client.Search(ctx, opts)
+		Platforms:        convertSlice[asf.Platform](cmd.StringSlice("platform")),
+		BeamModes:        convertSlice[asf.BeamMode](cmd.StringSlice("beam-mode")),
+		Polarizations:    convertSlice[asf.Polarization](cmd.StringSlice("polarization")),
+		ProductTypes:     convertSlice[asf.ProductType](cmd.StringSlice("product-type")),
+		Collections:      convertSlice[asf.CollectionName](cmd.StringSlice("collection")),
+		ProcessingLevel:  convertSlice[asf.ProcessingLevel](cmd.StringSlice("processing-level")),
+		LookDirections:   convertSlice[asf.LookDirection](cmd.StringSlice("look-direction")),
+		RelativeOrbits:   relativeOrbits,
+		AbsoluteOrbits:   absoluteOrbits,
+		FrameStart:       frameStart,
+		FrameEnd:         frameEnd,
+		ASFFrameStart:    asfFrameStart,
+		ASFFrameEnd:      asfFrameEnd,
+		FlightDirection:  asf.FlightDirection(strings.TrimSpace(cmd.String("flight-direction"))),
+		IntersectsWith:   intersects,
+		GranuleIDs:       convertSlice[string](cmd.StringSlice("granule")),
+		Datasets:         convertSlice[asf.Dataset](cmd.StringSlice("dataset")),
+		FullBurstIDs:     convertSlice[string](cmd.StringSlice("full-burst-id")),
+		RelativeBurstIDs: cmd.IntSlice("relative-burst-id"),
+		Subswaths:        convertSlice[string](cmd.StringSlice("subswath")),
+		BurstIndexes:     cmd.IntSlice("burst-index"),
+		Start:            start,
+		End:              end,
+		ProcessedAfter:   processedAfter,
+		ProcessedBefore:  processedBefore,
+		OmitGeometry:     cmd.Bool("omit-geometry"),
+	}
+	if bbox != nil {
+		opts.BBox = [4]float64(bbox)
+	}
+	if season != nil {
+		opts.Season = asf.Season{Start: int(season[0]), End: int(season[1])}
+	}
+	return opts, nil
+}
+
+// parseRangesFlag expands every value of a repeatable range flag such as
+// "15-17" into the individual integers it denotes.
+func parseRangesFlag(cmd *cli.Command, name string) ([]int, error) {
+	var values []int
+	for _, value := range cmd.StringSlice(name) {
+		parsed, err := asf.ParseIntRanges(value)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		values = append(values, parsed...)
+	}
+	return values, nil
+}
+
+// parseFrameFlag parses a frame flag such as --frame as a single frame or an
+// inclusive start-end range.
+func parseFrameFlag(cmd *cli.Command, name string) (int, int, error) {
+	value := strings.TrimSpace(cmd.String(name))
+	if value == "" {
+		return 0, 0, nil
+	}
+	lo, hi, isRange := strings.Cut(value, "-")
+	start, err := strconv.Atoi(strings.TrimSpace(lo))
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse %s: invalid frame %q", name, value)
+	}
+	if !isRange {
+		return start, start, nil
+	}
+	end, err := strconv.Atoi(strings.TrimSpace(hi))
+	if err != nil || end < start {
+		return 0, 0, fmt.Errorf("parse %s: invalid frame range %q", name, value)
+	}
+	return start, end, nil
+}
+
+// parseFloatsFlag parses a comma-separated list of exactly n numbers, or
+// returns nil if the flag is empty.
+func parseFloatsFlag(cmd *cli.Command, name string, n int) ([]float64, error) {
+	value := strings.TrimSpace(cmd.String(name))
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("parse %s: expected %d comma-separated numbers, got %q", name, n, value)
+	}
+	values := make([]float64, n)
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// sortKeys lists the values accepted by --sort-by.
+var sortKeys = []asf.SortKey{
+	asf.SortByStartTime, asf.SortByStopTime, asf.SortByProcessingDate,
+	asf.SortBySceneName, asf.SortByPathNumber, asf.SortByFrameNumber,
+}
+
+func executeSearch(ctx context.Context, cmd *cli.Command) error {
+	client := buildClient(cmd)
+
+	opts, err := searchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	opts.MaxResults = cmd.Int("max-results")
+	opts.SortBy = asf.SortKey(strings.TrimSpace(cmd.String("sort-by")))
+	opts.SortOrder = asf.SortOrder(strings.ToLower(strings.TrimSpace(cmd.String("sort-order"))))
+	if opts.SortBy != "" && !slices.Contains(sortKeys, opts.SortBy) {
+		return fmt.Errorf("parse sort-by: unknown sort key %q", opts.SortBy)
+	}
+	if opts.SortOrder != asf.SortAscending && opts.SortOrder != asf.SortDescending {
+		return fmt.Errorf("parse sort-order: expected asc or desc, got %q", opts.SortOrder)
+	}
+
+	output := strings.ToLower(strings.TrimSpace(cmd.String("output")))
+	var products []asf.Product
+	if output == "ndjson" {
+		products, err = streamNDJSON(ctx, client, opts, os.Stdout)
+	} else if cmd.Bool("all") {
+		products, err = client.SearchAll(ctx, opts)
+	} else {
+		var result asf.SearchResult
+		result, err = client.SearchDetailed(ctx, opts)
+		products = result.Products
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("search: %w", err)
 	}
 
 	if len(products) == 0 {
-		fmt.Fprintln(os.Stdout, "No products found.")
+		if output != "ndjson" {
+			fmt.Fprintln(os.Stdout, "No products found.")
+		}
 		return nil
 	}
 
-	switch output := strings.ToLower(strings.TrimSpace(cmd.String("output"))); output {
+	switch output {
+	case "ndjson":
+		// Already written while streaming.
 	case "json":
 		if err := writeJSON(os.Stdout, products); err != nil {
 			return err
 		}
+	case "csv":
+		if err := asf.FormatCSV(os.Stdout, products); err != nil {
+			return err
+		}
+	case "stac":
+		if err := writeSTAC(os.Stdout, products); err != nil {
+			return err
+		}
+	case "kml":
+		if err := asf.FormatKML(os.Stdout, products); err != nil {
+			return err
+		}
+	case "kmz":
+		if err := asf.FormatKMZ(os.Stdout, products); err != nil {
+			return err
+		}
 	case "text":
 		printProductsTable(os.Stdout, products)
 	default:
 		return fmt.Errorf("unsupported output format %q", output)
 	}
 
+	if mapPath := strings.TrimSpace(cmd.String("map")); mapPath != "" {
+		if err := writeFootprintMap(mapPath, strings.TrimSpace(cmd.String("map-background")), products); err != nil {
+			return err
+		}
+	}
+
 	downloadDir := strings.TrimSpace(cmd.String("download-dir"))
 	if downloadDir == "" {
 		return nil
 	}
 
+	bars := newProgressBars()
+	defer bars.Finish()
+	client = buildClient(cmd, asf.WithProgress(bars.Update))
+
 	fmt.Fprintf(os.Stderr, "Downloading %d product(s) to %s...\n", len(products), downloadDir)
 	if err := client.Download(ctx, downloadDir, products...); err != nil {
 		return fmt.Errorf("download: %w", err)
@@ -178,7 +465,92 @@ func executeSearch(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-func buildClient(cmd *cli.Command) *asf.Client {
+// writeFootprintMap draws the footprints of products to path, as SVG when
+// the path ends in .svg and as PNG otherwise, over the GeoJSON lines in
+// backgroundPath when it is set.
+func writeFootprintMap(path, backgroundPath string, products []asf.Product) error {
+	var opts coverage.Options
+	if backgroundPath != "" {
+		background, err := os.Open(backgroundPath)
+		if err != nil {
+			return fmt.Errorf("open map background: %w", err)
+		}
+		opts.Background, err = coverage.LoadBackground(background)
+		background.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create map: %w", err)
+	}
+	defer file.Close()
+	format := coverage.PNG
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		format = coverage.SVG
+	}
+	if err := format(file, products, opts); err != nil {
+		return fmt.Errorf("write map: %w", err)
+	}
+	return file.Close()
+}
+
+// streamNDJSON pages through the search, writing each product to w as one
+// JSON line as soon as its page arrives, and returns the products written.
+func streamNDJSON(ctx context.Context, client *asf.Client, opts asf.SearchOptions, w io.Writer) ([]asf.Product, error) {
+	sink := asf.NewJSONLSink(w)
+	var products []asf.Product
+	for product, err := range client.SearchIter(ctx, opts) {
+		if err != nil {
+			return products, err
+		}
+		if err := sink.Write(product); err != nil {
+			return products, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+func newMissionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "missions",
+		Usage: "List valid campaign names for a platform (e.g. UAVSAR, AIRSAR)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "platform",
+				Usage:    "Platform to list campaigns for",
+				Aliases:  []string{"p"},
+				Required: true,
+			},
+		},
+		Action: executeMissions,
+	}
+}
+
+func executeMissions(ctx context.Context, cmd *cli.Command) error {
+	client := buildClient(cmd)
+	platform := asf.Platform(strings.TrimSpace(cmd.String("platform")))
+
+	missions, err := client.Missions(ctx, platform)
+	if err != nil {
+		return fmt.Errorf("missions: %w", err)
+	}
+	if len(missions) == 0 {
+		fmt.Fprintf(os.Stdout, "No campaigns found for %s.\n", platform)
+		return nil
+	}
+	for _, mission := range missions {
+		fmt.Fprintln(os.Stdout, mission)
+	}
+	return nil
+}
+
+// buildClient creates a client from the global flags, followed by any
+// command-specific options.
+func buildClient(cmd *cli.Command, extra ...asf.Option) *asf.Client {
 	var opts []asf.Option
 	root := cmd.Root()
 	if baseURL := strings.TrimSpace(root.String("base-url")); baseURL != "" {
@@ -187,7 +559,7 @@ func buildClient(cmd *cli.Command) *asf.Client {
 	if token := strings.TrimSpace(root.String("token")); token != "" {
 		opts = append(opts, asf.WithAuthToken(token))
 	}
-	return asf.NewClient(opts...)
+	return asf.NewClient(append(opts, extra...)...)
 }
 
 func parseTimeFlag(cmd *cli.Command, name string) (time.Time, error) {
@@ -208,6 +580,17 @@ func writeJSON(w io.Writer, products []asf.Product) error {
 	return encoder.Encode(products)
 }
 
+// writeSTAC writes products as a STAC ItemCollection.
+func writeSTAC(w io.Writer, products []asf.Product) error {
+	items, err := stac.NewItems(products, "")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stac.NewItemCollection(items))
+}
+
 func printProductsTable(w io.Writer, products []asf.Product) {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 	fmt.Fprintln(tw, "SCENE\tPLATFORM\tSTART\tSTOP\tPATH\tURL")
@@ -256,3 +639,14 @@ func isMetadataProduct(props asf.Properties) bool {
 	return strings.EqualFold(props.ProcessingLevel, "METADATA") ||
 		strings.HasSuffix(strings.ToLower(props.URL), ".iso.xml")
 }
+
+func newSchemaCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "schema",
+		Usage: "Print the JSON Schema of saved search results, for code generators",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			_, err := fmt.Fprintln(os.Stdout, string(asf.ProductSchema()))
+			return err
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// progressRedrawInterval throttles terminal redraws.
+const progressRedrawInterval = 100 * time.Millisecond
+
+// progressBars renders one line per active download on a terminal, and a line
+// per completed file otherwise.
+type progressBars struct {
+	out      io.Writer
+	terminal bool
+
+	mu       sync.Mutex
+	files    map[string]*fileProgress
+	active   []string // file names in display order
+	drawn    int      // lines drawn by the last redraw
+	lastDraw time.Time
+}
+
+type fileProgress struct {
+	progress asf.Progress
+	started  time.Time
+}
+
+// newProgressBars renders to stderr, drawing bars only when it is a terminal.
+func newProgressBars() *progressBars {
+	terminal := false
+	if info, err := os.Stderr.Stat(); err == nil {
+		terminal = info.Mode()&os.ModeCharDevice != 0
+	}
+	return &progressBars{out: os.Stderr, terminal: terminal, files: make(map[string]*fileProgress)}
+}
+
+// Update is an asf.ProgressFunc.
+func (b *progressBars) Update(p asf.Progress) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	file, ok := b.files[p.FileName]
+	if !ok {
+		file = &fileProgress{started: time.Now()}
+		b.files[p.FileName] = file
+		b.active = append(b.active, p.FileName)
+	}
+	file.progress = p
+
+	if !b.terminal {
+		if p.Done {
+			fmt.Fprintf(b.out, "%s  %s\n", p.FileName, formatBytes(p.Bytes))
+			b.remove(p.FileName)
+		}
+		return
+	}
+	if p.Done || time.Since(b.lastDraw) >= progressRedrawInterval {
+		b.redraw(p)
+	}
+}
+
+// Finish clears the bars of downloads that never completed.
+func (b *progressBars) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.terminal {
+		b.clear()
+	}
+}
+
+// redraw replaces the previously drawn bars, printing a completed file as a
+// permanent line above them.
+func (b *progressBars) redraw(p asf.Progress) {
+	b.clear()
+	if p.Done {
+		fmt.Fprintln(b.out, b.line(p.FileName))
+		b.remove(p.FileName)
+	}
+	for _, name := range b.active {
+		fmt.Fprintln(b.out, b.line(name))
+	}
+	b.drawn = len(b.active)
+	b.lastDraw = time.Now()
+}
+
+// clear erases the lines drawn by the last redraw.
+func (b *progressBars) clear() {
+	for ; b.drawn > 0; b.drawn-- {
+		fmt.Fprint(b.out, "\x1b[1A\x1b[2K")
+	}
+}
+
+func (b *progressBars) remove(name string) {
+	delete(b.files, name)
+	b.active = slices.DeleteFunc(b.active, func(n string) bool { return n == name })
+}
+
+// line formats one file's bar, size, speed and ETA.
+func (b *progressBars) line(name string) string {
+	file := b.files[name]
+	p := file.progress
+
+	elapsed := time.Since(file.started).Seconds()
+	var speed float64
+	if elapsed > 0 {
+		speed = float64(p.Bytes-p.Resumed) / elapsed
+	}
+
+	const width = 20
+	if p.Total <= 0 {
+		return fmt.Sprintf("%-40s %s  %s/s", truncateName(name, 40), formatBytes(p.Bytes), formatBytes(int64(speed)))
+	}
+	fraction := min(float64(p.Bytes)/float64(p.Total), 1)
+	filled := int(fraction * width)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", width-filled)
+
+	total := formatBytes(p.Total)
+	if p.Estimated {
+		total = "~" + total
+	}
+
+	eta := "-"
+	if p.Done {
+		eta = "done"
+	} else if speed > 0 {
+		eta = time.Duration(float64(p.Total-p.Bytes) / speed * float64(time.Second)).Round(time.Second).String()
+	}
+	return fmt.Sprintf("%-40s [%s] %3.0f%% %s/%s  %s/s  ETA %s",
+		truncateName(name, 40), bar, fraction*100,
+		formatBytes(p.Bytes), total, formatBytes(int64(speed)), eta)
+}
+
+func truncateName(name string, n int) string {
+	if len(name) <= n {
+		return name
+	}
+	return "..." + name[len(name)-n+3:]
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
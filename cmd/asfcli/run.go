@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+	"github.com/robert-malhotra/go-asf/pkg/job"
+	"github.com/robert-malhotra/go-asf/pkg/pipeline"
+)
+
+func newRunCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "run",
+		Usage:     "Run a declarative ingest job file, repeating it on its schedule",
+		ArgsUsage: "job.yaml",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "once",
+				Usage: "Run the job a single time even if it has a schedule",
+			},
+		},
+		Action: executeRun,
+	}
+}
+
+func executeRun(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return fmt.Errorf("run: expected exactly one job file")
+	}
+	j, err := job.Load(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("once") {
+		j.Schedule = 0
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runner := &job.Runner{
+		Client: buildClient(cmd),
+		OnResult: func(result asf.DownloadResult) {
+			if result.Err != nil {
+				fmt.Fprintf(os.Stderr, "FAILED %s: %v\n", result.Product.Properties.FileName, result.Err)
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Downloaded %s\n", result.Path)
+		},
+	}
+	return runner.RunScheduled(ctx, j, func(report *pipeline.Report, err error) {
+		if report != nil {
+			fmt.Fprintf(os.Stderr, "%s: %d matched, %d skipped, %d downloaded, %d failed\n", j.Name,
+				report.Matched, report.Filtered, len(report.Results)-len(report.Failed()), len(report.Failed()))
+		}
+		if err != nil && j.Schedule > 0 {
+			fmt.Fprintf(os.Stderr, "%s: run failed: %v\n", j.Name, err)
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func newStackCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "stack",
+		Usage: "List the InSAR baseline stack for a reference granule",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "reference",
+				Usage:    "Reference granule (scene name)",
+				Aliases:  []string{"r"},
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output format (text or json)",
+				Value: "text",
+			},
+			&cli.StringFlag{
+				Name:  "plot",
+				Usage: "Write a temporal vs perpendicular baseline scatter plot to this SVG file",
+			},
+		},
+		Action: executeStack,
+	}
+}
+
+func executeStack(ctx context.Context, cmd *cli.Command) error {
+	client := buildClient(cmd)
+	reference := strings.TrimSpace(cmd.String("reference"))
+
+	products, err := client.Stack(ctx, reference)
+	if err != nil {
+		return fmt.Errorf("stack: %w", err)
+	}
+	if len(products) == 0 {
+		fmt.Fprintln(os.Stdout, "No stack products found.")
+		return nil
+	}
+
+	switch output := strings.ToLower(strings.TrimSpace(cmd.String("output"))); output {
+	case "json":
+		if err := writeJSON(os.Stdout, products); err != nil {
+			return err
+		}
+	case "text":
+		printStackTable(os.Stdout, products)
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+
+	plotPath := strings.TrimSpace(cmd.String("plot"))
+	if plotPath == "" {
+		return nil
+	}
+	file, err := os.Create(plotPath)
+	if err != nil {
+		return fmt.Errorf("create plot: %w", err)
+	}
+	defer file.Close()
+	if err := writeBaselinePlot(file, reference, products); err != nil {
+		return fmt.Errorf("write plot: %w", err)
+	}
+	return file.Close()
+}
+
+func printStackTable(w io.Writer, products []asf.Product) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCENE\tSTART\tTEMPORAL(d)\tPERPENDICULAR(m)")
+	for _, product := range products {
+		props := product.Properties
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\n",
+			props.SceneName,
+			formatTime(props.StartTime),
+			formatOptional(props.TemporalBaseline, "%d"),
+			formatOptional(props.PerpendicularBaseline, "%.1f"),
+		)
+	}
+	tw.Flush()
+}
+
+func formatOptional[T int | float64](value *T, format string) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf(format, *value)
+}
+
+// Plot geometry in SVG user units.
+const (
+	plotWidth   = 800.0
+	plotHeight  = 500.0
+	plotMargin  = 60.0
+	plotTickLen = 5.0
+)
+
+// writeBaselinePlot renders the standard InSAR baseline plot: temporal
+// baseline on the x axis, perpendicular baseline on the y axis, with the
+// reference scene highlighted. Products without both baselines are skipped.
+func writeBaselinePlot(w io.Writer, reference string, products []asf.Product) error {
+	type point struct {
+		x, y  float64
+		label string
+		ref   bool
+	}
+	var points []point
+	for _, product := range products {
+		props := product.Properties
+		if props.TemporalBaseline == nil || props.PerpendicularBaseline == nil {
+			continue
+		}
+		points = append(points, point{
+			x:     float64(*props.TemporalBaseline),
+			y:     *props.PerpendicularBaseline,
+			label: props.SceneName,
+			ref:   props.SceneName == reference,
+		})
+	}
+	if len(points) == 0 {
+		return fmt.Errorf("no products with baseline values")
+	}
+
+	minX, maxX, minY, maxY := points[0].x, points[0].x, points[0].y, points[0].y
+	for _, p := range points[1:] {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	minX, maxX = padRange(minX, maxX)
+	minY, maxY = padRange(minY, maxY)
+
+	scaleX := func(v float64) float64 {
+		return plotMargin + (v-minX)/(maxX-minX)*(plotWidth-2*plotMargin)
+	}
+	scaleY := func(v float64) float64 {
+		return plotHeight - plotMargin - (v-minY)/(maxY-minY)*(plotHeight-2*plotMargin)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="sans-serif" font-size="12">`+"\n",
+		plotWidth, plotHeight, plotWidth, plotHeight)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%.0f" y="25" text-anchor="middle" font-size="16">Baseline plot: %s</text>`+"\n",
+		plotWidth/2, html.EscapeString(reference))
+
+	// Axes, ticks and labels.
+	left, right := plotMargin, plotWidth-plotMargin
+	top, bottom := plotMargin, plotHeight-plotMargin
+	fmt.Fprintf(&b, `<path d="M%.1f %.1f V%.1f H%.1f" fill="none" stroke="black"/>`+"\n", left, top, bottom, right)
+	for _, tick := range niceTicks(minX, maxX) {
+		x := scaleX(tick)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"/>`+"\n", x, bottom, x, bottom+plotTickLen)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%g</text>`+"\n", x, bottom+18, tick)
+	}
+	for _, tick := range niceTicks(minY, maxY) {
+		y := scaleY(tick)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"/>`+"\n", left-plotTickLen, y, left, y)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle">%g</text>`+"\n", left-8, y, tick)
+	}
+	fmt.Fprintf(&b, `<text x="%.0f" y="%.0f" text-anchor="middle">Temporal baseline (days)</text>`+"\n", plotWidth/2, plotHeight-15)
+	fmt.Fprintf(&b, `<text x="15" y="%.0f" text-anchor="middle" transform="rotate(-90 15 %.0f)">Perpendicular baseline (m)</text>`+"\n",
+		plotHeight/2, plotHeight/2)
+
+	for _, p := range points {
+		fill, radius := "steelblue", 4.0
+		if p.ref {
+			fill, radius = "crimson", 6.0
+		}
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="%.0f" fill="%s"><title>%s (%g d, %g m)</title></circle>`+"\n",
+			scaleX(p.x), scaleY(p.y), radius, fill, html.EscapeString(p.label), p.x, p.y)
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// padRange widens a value range by 5% on both sides, and to a unit range
+// when all values are equal, so points never sit on the plot border.
+func padRange(min, max float64) (float64, float64) {
+	if min == max {
+		return min - 1, max + 1
+	}
+	pad := (max - min) * 0.05
+	return min - pad, max + pad
+}
+
+// niceTicks returns roughly five evenly spaced, rounded tick values in
+// [min, max].
+func niceTicks(min, max float64) []float64 {
+	raw := (max - min) / 5
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := magnitude
+	for _, m := range []float64{2, 5, 10} {
+		if raw/magnitude > m*0.75 {
+			step = m * magnitude
+		}
+	}
+	var ticks []float64
+	for tick := math.Ceil(min/step) * step; tick <= max; tick += step {
+		ticks = append(ticks, math.Round(tick/step)*step)
+	}
+	return ticks
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func newVerifyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Check downloaded files against published MD5 checksums and, with --deep, zip CRCs",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "dir",
+				Usage:   "Directory holding the downloaded files",
+				Aliases: []string{"d"},
+				Value:   ".",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "Saved results file (JSON array or GeoJSON) listing the expected files and checksums",
+			},
+			&cli.BoolFlag{
+				Name:  "deep",
+				Usage: "Open .zip archives and validate the CRC of every entry without extracting",
+			},
+		},
+		Action: executeVerify,
+	}
+}
+
+// verifyTarget is a file to verify and, when known, its expected MD5 sum.
+type verifyTarget struct {
+	path   string
+	md5sum string
+}
+
+func executeVerify(ctx context.Context, cmd *cli.Command) error {
+	dir := strings.TrimSpace(cmd.String("dir"))
+	deep := cmd.Bool("deep")
+
+	var targets []verifyTarget
+	if from := strings.TrimSpace(cmd.String("from")); from != "" {
+		products, err := readProductsFile(from)
+		if err != nil {
+			return err
+		}
+		for _, product := range products {
+			if product.Properties.FileName == "" || isMetadataProduct(product.Properties) {
+				continue
+			}
+			targets = append(targets, verifyTarget{
+				path:   filepath.Join(dir, product.Properties.FileName),
+				md5sum: product.Properties.Md5sum,
+			})
+		}
+	} else {
+		if !deep {
+			return fmt.Errorf("verify: nothing to check; pass --from for checksums or --deep for zip CRCs")
+		}
+		archives, err := filepath.Glob(filepath.Join(dir, "*.zip"))
+		if err != nil {
+			return fmt.Errorf("verify: %w", err)
+		}
+		for _, path := range archives {
+			targets = append(targets, verifyTarget{path: path})
+		}
+	}
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stdout, "No files to verify.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tFILE\tDETAIL")
+	failed := 0
+	for _, target := range targets {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		status, detail := "OK", "-"
+		if err := verifyFile(target, deep); err != nil {
+			failed++
+			status, detail = "FAILED", err.Error()
+			if errors.Is(err, fs.ErrNotExist) {
+				status, detail = "MISSING", "-"
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", status, target.path, detail)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(os.Stdout, "%d of %d file(s) OK\n", len(targets)-failed, len(targets))
+	if failed > 0 {
+		return fmt.Errorf("verify: %d file(s) failed", failed)
+	}
+	return nil
+}
+
+// verifyFile checks a file's MD5 sum, if one is expected, and with deep set
+// also the CRCs of a zip archive.
+func verifyFile(target verifyTarget, deep bool) error {
+	if _, err := os.Stat(target.path); err != nil {
+		return err
+	}
+	if err := asf.VerifyChecksum(target.path, target.md5sum); err != nil {
+		return err
+	}
+	if deep && strings.EqualFold(filepath.Ext(target.path), ".zip") {
+		return asf.VerifyZip(target.path)
+	}
+	return nil
+}
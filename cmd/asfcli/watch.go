@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+	"github.com/robert-malhotra/go-asf/pkg/watch"
+)
+
+func newWatchCommand() *cli.Command {
+	flags := append(searchFilterFlags(),
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "Time between polls",
+			Value: 15 * time.Minute,
+		},
+		&cli.StringFlag{
+			Name:  "state",
+			Usage: "File remembering which products were already handled",
+			Value: "asfcli-watch.json",
+		},
+		&cli.BoolFlag{
+			Name:  "once",
+			Usage: "Poll a single time and exit, for use from cron",
+		},
+		&cli.StringFlag{
+			Name:  "download-dir",
+			Usage: "Download new products into this directory",
+		},
+		&cli.StringFlag{
+			Name: "exec",
+			Usage: "Shell command run once per new product, with ASF_SCENE_NAME, ASF_FILE_ID, ASF_URL " +
+				"and, when downloading, ASF_PATH set in its environment",
+		},
+		&cli.StringFlag{
+			Name:  "notify-url",
+			Usage: "Webhook URL receiving a JSON POST whenever new products appear",
+		},
+		&cli.StringFlag{
+			Name:    "notify-secret",
+			Usage:   "Secret used to sign webhook bodies with HMAC-SHA256 (X-ASF-Signature header)",
+			Sources: cli.EnvVars("ASF_WEBHOOK_SECRET"),
+		},
+	)
+	return &cli.Command{
+		Name:   "watch",
+		Usage:  "Poll a search on an interval and report, download or process only new products",
+		Flags:  flags,
+		Action: executeWatch,
+	}
+}
+
+func executeWatch(ctx context.Context, cmd *cli.Command) error {
+	opts, err := searchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	interval := cmd.Duration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("watch: --interval must be positive")
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := &watch.Watcher{
+		Client:  buildClient(cmd),
+		Options: opts,
+		Store:   watch.FileStore{Path: strings.TrimSpace(cmd.String("state"))},
+	}
+	var hook *watch.Webhook
+	if notifyURL := strings.TrimSpace(cmd.String("notify-url")); notifyURL != "" {
+		hook = &watch.Webhook{URL: notifyURL, Secret: cmd.String("notify-secret")}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := pollOnce(ctx, cmd, watcher, hook)
+		if cmd.Bool("once") {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollOnce runs one poll, handles the new products it reports and
+// acknowledges those that were handled successfully; the rest are reported
+// again by the next poll. A nil hook sends no notifications.
+func pollOnce(ctx context.Context, cmd *cli.Command, watcher *watch.Watcher, hook *watch.Webhook) error {
+	found, err := watcher.Poll(ctx)
+	if err != nil {
+		return err
+	}
+	var fresh, handled []asf.Product
+	for _, product := range found {
+		if isMetadataProduct(product.Properties) {
+			handled = append(handled, product)
+		} else {
+			fresh = append(fresh, product)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d new product(s)\n", time.Now().UTC().Format(time.RFC3339), len(fresh))
+	if len(fresh) == 0 {
+		return watcher.Ack(ctx, handled...)
+	}
+	printProductsTable(os.Stdout, fresh)
+	if hook != nil {
+		if err := hook.Notify(ctx, fresh); err != nil {
+			fmt.Fprintf(os.Stderr, "watch: notify: %v\n", err)
+		}
+	}
+
+	paths := make([]string, len(fresh))
+	failed := make([]bool, len(fresh))
+	if dir := strings.TrimSpace(cmd.String("download-dir")); dir != "" {
+		results, err := watcher.Client.DownloadReport(ctx, dir, fresh...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "watch: download: %v\n", err)
+		}
+		for i, result := range results {
+			paths[i] = result.Path
+			failed[i] = result.Err != nil || result.Path == ""
+		}
+	}
+	command := strings.TrimSpace(cmd.String("exec"))
+	for i, product := range fresh {
+		if failed[i] {
+			continue
+		}
+		if command != "" {
+			if err := runExec(ctx, command, product, paths[i]); err != nil {
+				fmt.Fprintf(os.Stderr, "watch: exec for %s: %v\n", product.Properties.SceneName, err)
+				continue
+			}
+		}
+		handled = append(handled, product)
+	}
+	return watcher.Ack(ctx, handled...)
+}
+
+// runExec runs command through the shell with the product, and the path it
+// was downloaded to if any, described in its environment.
+func runExec(ctx context.Context, command string, product asf.Product, path string) error {
+	c := exec.CommandContext(ctx, "sh", "-c", command)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"ASF_SCENE_NAME="+product.Properties.SceneName,
+		"ASF_FILE_ID="+product.Properties.FileID,
+		"ASF_URL="+product.Properties.URL,
+	)
+	if path != "" {
+		c.Env = append(c.Env, "ASF_PATH="+path)
+	}
+	return c.Run()
+}
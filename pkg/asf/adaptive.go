@@ -0,0 +1,152 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// maxAdaptiveConcurrency caps adaptive download concurrency unless
+// WithDownloadConcurrency sets another ceiling.
+const maxAdaptiveConcurrency = 32
+
+// WithAdaptiveConcurrency tunes the number of parallel downloads while they
+// run instead of fixing it, since the CPU count says little about how much
+// parallelism the network and server can take. Starting from two, one more
+// download is allowed after each round that raised the aggregate
+// throughput, and the limit is halved whenever the server answers 429 Too
+// Many Requests or 503 Service Unavailable (additive increase,
+// multiplicative decrease). WithDownloadConcurrency sets the ceiling,
+// otherwise 32. The learned limit is shared by all of the client's
+// downloads.
+func WithAdaptiveConcurrency() Option {
+	return func(c *Client) {
+		c.adaptive = &adaptiveLimiter{c: c, limit: 2, wake: make(chan struct{})}
+	}
+}
+
+// downloadWorkers returns the most files the client downloads at once.
+func (c *Client) downloadWorkers() int {
+	return c.overallWorkers() + c.hostLimits.total()
+}
+
+// overallWorkers returns the download limit shared by hosts without a cap
+// of their own.
+func (c *Client) overallWorkers() int {
+	switch {
+	case c.adaptive != nil:
+		return c.adaptive.max()
+	case c.downloadConcurrency > 0:
+		return c.downloadConcurrency
+	default:
+		return runtime.NumCPU()
+	}
+}
+
+// adaptiveLimiter is the AIMD download limit behind WithAdaptiveConcurrency.
+// It judges each round of limit completed downloads by the client's
+// byte counter and throttling counter.
+type adaptiveLimiter struct {
+	c *Client
+
+	mu       sync.Mutex
+	limit    int
+	inflight int
+	// wake is closed and replaced whenever a slot frees up or the limit
+	// changes.
+	wake chan struct{}
+
+	// The current round: when it started, the byte and throttle counters
+	// at that time, and the downloads completed since.
+	start     time.Time
+	bytes     int64
+	throttled int64
+	completed int
+	// lastRate is the throughput of the previous round in bytes/second.
+	lastRate float64
+}
+
+func (l *adaptiveLimiter) max() int {
+	if l.c.downloadConcurrency > 0 {
+		return l.c.downloadConcurrency
+	}
+	return maxAdaptiveConcurrency
+}
+
+// acquire waits for a download slot.
+func (l *adaptiveLimiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.start.IsZero() {
+			l.startRound()
+		}
+		if l.inflight < min(l.limit, l.max()) {
+			l.inflight++
+			l.mu.Unlock()
+			return nil
+		}
+		wake := l.wake
+		l.mu.Unlock()
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// release frees a download slot and adjusts the limit.
+func (l *adaptiveLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+	l.adjust()
+	close(l.wake)
+	l.wake = make(chan struct{})
+}
+
+// adjust halves the limit if the server has throttled the client since the
+// round started, and otherwise, once a full round has completed, raises it
+// by one if throughput grew or lowers it by one if throughput fell.
+func (l *adaptiveLimiter) adjust() {
+	if l.c.usage.throttled.Load() > l.throttled {
+		l.limit = max(l.limit/2, 1)
+		l.c.log().Debug("asf: download concurrency decreased after throttling", "limit", l.limit)
+		l.startRound()
+		l.lastRate = 0
+		return
+	}
+	l.completed++
+	if l.completed < l.limit {
+		return
+	}
+	elapsed := l.c.now().Sub(l.start).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	rate := float64(l.c.usage.bytesDownloaded.Load()-l.bytes) / elapsed
+	switch {
+	case rate > l.lastRate*1.05 && l.limit < l.max():
+		l.limit++
+		l.c.log().Debug("asf: download concurrency increased", "limit", l.limit, "bytes_per_second", int64(rate))
+	case rate < l.lastRate*0.9 && l.limit > 1:
+		l.limit--
+		l.c.log().Debug("asf: download concurrency decreased", "limit", l.limit, "bytes_per_second", int64(rate))
+	}
+	l.lastRate = rate
+	l.startRound()
+}
+
+func (l *adaptiveLimiter) startRound() {
+	l.start = l.c.now()
+	l.bytes = l.c.usage.bytesDownloaded.Load()
+	l.throttled = l.c.usage.throttled.Load()
+	l.completed = 0
+}
+
+// isThrottled reports whether a response asks the client to slow down.
+func isThrottled(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
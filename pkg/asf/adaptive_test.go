@@ -0,0 +1,107 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveLimiterAIMD(t *testing.T) {
+	clock := newFakeClock()
+	c := NewClient(WithAdaptiveConcurrency(), WithClock(clock), WithDownloadConcurrency(8))
+	l := c.adaptive
+	ctx := context.Background()
+
+	// round runs a full round of downloads at the current limit,
+	// transferring bytes over one second.
+	round := func(bytes int64) {
+		t.Helper()
+		n := l.limit
+		for range n {
+			if err := l.acquire(ctx); err != nil {
+				t.Fatalf("acquire returned error: %v", err)
+			}
+		}
+		c.usage.bytesDownloaded.Add(bytes)
+		clock.Advance(time.Second)
+		for range n {
+			l.release()
+		}
+	}
+
+	round(1000)
+	if l.limit != 3 {
+		t.Fatalf("expected the limit to grow to 3, got %d", l.limit)
+	}
+	round(2000)
+	if l.limit != 4 {
+		t.Fatalf("expected the limit to grow to 4 while throughput rises, got %d", l.limit)
+	}
+	round(2000)
+	if l.limit != 4 {
+		t.Fatalf("expected the limit to hold at 4 when throughput is flat, got %d", l.limit)
+	}
+
+	c.usage.throttled.Add(1)
+	if err := l.acquire(ctx); err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+	l.release()
+	if l.limit != 2 {
+		t.Fatalf("expected throttling to halve the limit to 2, got %d", l.limit)
+	}
+}
+
+func TestAdaptiveLimiterAcquireHonorsContext(t *testing.T) {
+	l := NewClient(WithAdaptiveConcurrency()).adaptive
+	for range 2 {
+		if err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire returned error: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the full limiter to wait until the deadline, got %v", err)
+	}
+}
+
+func TestDownloadAdaptiveConcurrency(t *testing.T) {
+	var concurrent, peak, requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 3 {
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		n := concurrent.Add(1)
+		defer concurrent.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAdaptiveConcurrency(), WithDownloadConcurrency(3), WithDownloadRetries(1, time.Millisecond))
+	var urls []string
+	for i := range 12 {
+		urls = append(urls, fmt.Sprintf("%s/f%d.zip", server.URL, i))
+	}
+	if err := client.DownloadURLs(context.Background(), t.TempDir(), urls...); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	if got := peak.Load(); got > 3 {
+		t.Fatalf("expected at most 3 concurrent downloads, got %d", got)
+	}
+	if got := client.Stats().Throttled; got != 1 {
+		t.Fatalf("expected 1 throttled response, got %d", got)
+	}
+}
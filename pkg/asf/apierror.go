@@ -0,0 +1,81 @@
+package asf
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError reports an unexpected HTTP status from the search API or a
+// download server. Use errors.As to branch on StatusCode, for example to
+// tell rejected credentials (401) from throttling (429) and server faults
+// (5xx). Returned errors also carry the status's ErrorClass for Classify.
+type APIError struct {
+	StatusCode int
+	// Body is the start of the response body, typically an error message.
+	Body string
+	// RequestURL is the URL that returned the status.
+	RequestURL string
+	// RetryAfter is the wait requested by the server's Retry-After header,
+	// or zero if it sent none.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("asf: unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("asf: unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// maxAPIErrorBody caps how much of an error response body APIError keeps.
+const maxAPIErrorBody = 4 << 10
+
+// StatusError reads the start of resp's body and returns the classified
+// APIError for its unexpected status, for callers of Do that want the same
+// errors as the client's own requests. It does not close the body.
+func (c *Client) StatusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxAPIErrorBody))
+	return c.statusError(resp, body)
+}
+
+// statusError builds the classified APIError for resp, whose body has been
+// read into body.
+func (c *Client) statusError(resp *http.Response, body []byte) error {
+	if len(body) > maxAPIErrorBody {
+		body = body[:maxAPIErrorBody]
+	}
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), c.now()),
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.RequestURL = resp.Request.URL.String()
+	}
+	return classifyStatus(resp.StatusCode, apiErr)
+}
+
+// parseRetryAfter converts a Retry-After header, either delay seconds or an
+// HTTP date, into a wait from now. Missing, malformed and past values yield
+// zero.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	when, err := http.ParseTime(header)
+	if err != nil || !when.After(now) {
+		return 0
+	}
+	return when.Sub(now)
+}
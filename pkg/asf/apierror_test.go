@@ -0,0 +1,50 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(WithBaseURL(server.URL)).Search(context.Background(), SearchOptions{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Body != "slow down" || apiErr.RetryAfter != 7*time.Second {
+		t.Fatalf("unexpected APIError %+v", apiErr)
+	}
+	if !strings.HasPrefix(apiErr.RequestURL, server.URL) {
+		t.Fatalf("unexpected RequestURL %q", apiErr.RequestURL)
+	}
+	if !IsTransient(err) {
+		t.Fatalf("expected a 429 to be transient")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for header, want := range map[string]time.Duration{
+		"":                              0,
+		"30":                            30 * time.Second,
+		"-5":                            0,
+		"soon":                          0,
+		"Wed, 01 Jan 2025 12:01:30 GMT": 90 * time.Second,
+		"Wed, 01 Jan 2025 11:59:00 GMT": 0,
+	} {
+		if got := parseRetryAfter(header, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
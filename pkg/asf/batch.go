@@ -0,0 +1,119 @@
+package asf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BatchMode selects what a batch operation does when one of its items fails.
+type BatchMode int
+
+const (
+	// ContinueOnError finishes the remaining items and reports every
+	// failure at the end. It is the default.
+	ContinueOnError BatchMode = iota
+	// FailFast cancels the remaining items at the first failure.
+	FailFast
+)
+
+func (m BatchMode) String() string {
+	switch m {
+	case ContinueOnError:
+		return "continue-on-error"
+	case FailFast:
+		return "fail-fast"
+	default:
+		return fmt.Sprintf("BatchMode(%d)", int(m))
+	}
+}
+
+// WithBatchMode sets whether one failed download aborts the rest of a
+// Download batch. By default the other products are still downloaded.
+func WithBatchMode(mode BatchMode) Option {
+	return func(c *Client) {
+		c.batchMode = mode
+	}
+}
+
+// ProductError is the failure to download one product of a batch.
+type ProductError struct {
+	Product Product
+	Err     error
+}
+
+func (e *ProductError) Error() string { return e.Err.Error() }
+
+func (e *ProductError) Unwrap() error { return e.Err }
+
+// DownloadError is the failure to download one file, recording where it was
+// fetched from and where it was being saved. Download and DownloadURLs wrap
+// each file's failure in one once its destination is known, so callers can
+// recover the URL with errors.As.
+type DownloadError struct {
+	// URL is the URL fetched, after any WithURLRewriter rewrite.
+	URL string
+	// Dest is the path the file was being saved to.
+	Dest string
+	Err  error
+}
+
+func (e *DownloadError) Error() string { return e.Err.Error() }
+
+func (e *DownloadError) Unwrap() error { return e.Err }
+
+// BatchError reports every product of a download batch that failed, so
+// that one bad granule does not hide the others. Products left unstarted
+// because the caller's context was cancelled are included with the
+// cancellation cause; those abandoned by FailFast are not. errors.Is and
+// errors.As look through all of the failures.
+type BatchError struct {
+	// Total is the number of products in the batch.
+	Total  int
+	Errors []*ProductError
+}
+
+func (e *BatchError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	names := make([]string, 0, 3)
+	for _, failure := range e.Errors[:min(3, len(e.Errors))] {
+		names = append(names, failure.Product.Properties.FileName)
+	}
+	if len(e.Errors) > 3 {
+		names = append(names, "...")
+	}
+	return fmt.Sprintf("asf: %d of %d downloads failed (%s); first: %v",
+		len(e.Errors), e.Total, strings.Join(names, ", "), e.Errors[0].Err)
+}
+
+// Unwrap returns the individual product errors.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, failure := range e.Errors {
+		errs[i] = failure
+	}
+	return errs
+}
+
+// Failed returns the products that failed, in batch order.
+func (e *BatchError) Failed() []Product {
+	products := make([]Product, len(e.Errors))
+	for i, failure := range e.Errors {
+		products[i] = failure.Product
+	}
+	return products
+}
+
+// Transient returns the products whose failure Classify reports as
+// Transient, in batch order, so a job runner can requeue only those. Classify
+// on the BatchError itself only sees the first classified failure.
+func (e *BatchError) Transient() []Product {
+	var products []Product
+	for _, failure := range e.Errors {
+		if IsTransient(failure.Err) {
+			products = append(products, failure.Product)
+		}
+	}
+	return products
+}
@@ -0,0 +1,116 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDownloadCollectsAllFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	var products []Product
+	for _, name := range []string{"missing-a.zip", "ok.zip", "missing-b.zip"} {
+		products = append(products, Product{Properties: Properties{URL: server.URL + "/" + name, FileName: name}})
+	}
+	dir := t.TempDir()
+	err := NewClient(WithDownloadConcurrency(1)).Download(context.Background(), dir, products...)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected BatchError, got %v", err)
+	}
+	failed := batchErr.Failed()
+	if batchErr.Total != 3 || len(failed) != 2 || failed[0].Properties.FileName != "missing-a.zip" || failed[1].Properties.FileName != "missing-b.zip" {
+		t.Fatalf("unexpected batch error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ok.zip")); err != nil {
+		t.Fatalf("expected the healthy product to be downloaded: %v", err)
+	}
+	var productErr *ProductError
+	if !errors.As(err, &productErr) || productErr.Product.Properties.FileName != "missing-a.zip" {
+		t.Fatalf("expected errors.As to find the first product error, got %v", productErr)
+	}
+}
+
+func TestDownloadCancelledBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	products := []Product{
+		{Properties: Properties{URL: "http://127.0.0.1:1/a.zip", FileName: "a.zip"}},
+		{Properties: Properties{URL: "http://127.0.0.1:1/b.zip", FileName: "b.zip"}},
+	}
+	err := NewClient().Download(ctx, t.TempDir(), products...)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected every product to report cancellation, got %v", err)
+	}
+}
+
+func TestDownloadFailFast(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	var products []Product
+	for _, name := range []string{"a.zip", "b.zip", "c.zip"} {
+		products = append(products, Product{Properties: Properties{URL: server.URL + "/" + name, FileName: name}})
+	}
+	client := NewClient(WithDownloadConcurrency(1), WithBatchMode(FailFast))
+	err := client.Download(context.Background(), t.TempDir(), products...)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || batchErr.Errors[0].Product.Properties.FileName != "a.zip" {
+		t.Fatalf("expected only the first failure to be reported, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected fail-fast batch to stop after one request, got %d", got)
+	}
+}
+
+func TestDownloadURLsReportsFailedURLs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	missing := server.URL + "/missing.zip"
+	err := NewClient().DownloadURLs(context.Background(), dir, server.URL+"/ok.zip", missing)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Unwrap()) != 1 {
+		t.Fatalf("expected one failure in a *BatchError, got %v", err)
+	}
+	var downloadErr *DownloadError
+	if !errors.As(err, &downloadErr) {
+		t.Fatalf("expected a *DownloadError in %v", err)
+	}
+	if downloadErr.URL != missing || downloadErr.Dest != filepath.Join(dir, "missing.zip") {
+		t.Fatalf("unexpected DownloadError %+v", downloadErr)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected the 404 to be reachable with errors.As, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// BBoxWKT renders a [west, south, east, north] bounding box as a WKT
+// POLYGON. A box whose west edge lies east of its east edge crosses the
+// antimeridian and is rendered as a MULTIPOLYGON of its two halves.
+func BBoxWKT(bbox [4]float64) string {
+	west, south, east, north := bbox[0], bbox[1], bbox[2], bbox[3]
+	if west > east {
+		return "MULTIPOLYGON (" + bboxRings(west, south, 180, north) + ", " + bboxRings(-180, south, east, north) + ")"
+	}
+	return "POLYGON " + bboxRings(west, south, east, north)
+}
+
+func bboxRings(west, south, east, north float64) string {
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	return fmt.Sprintf("((%[1]s %[2]s, %[3]s %[2]s, %[3]s %[4]s, %[1]s %[4]s, %[1]s %[2]s))", f(west), f(south), f(east), f(north))
+}
+
+// intersectsWith returns the area filter of a search: IntersectsWith if set,
+// otherwise the bounding box, if any.
+func (opts SearchOptions) intersectsWith() string {
+	if opts.IntersectsWith != "" || opts.BBox == ([4]float64{}) {
+		return opts.IntersectsWith
+	}
+	return BBoxWKT(opts.BBox)
+}
+
+// PointSearch returns a single page of products whose footprint contains the
+// point at lat, lon, with the remaining filters taken from opts. Any area
+// filter in opts is replaced.
+func (c *Client) PointSearch(ctx context.Context, lat, lon float64, opts SearchOptions) ([]Product, error) {
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return nil, fmt.Errorf("asf: point (%g, %g) out of range", lat, lon)
+	}
+	opts.BBox = [4]float64{}
+	opts.IntersectsWith = fmt.Sprintf("POINT (%s %s)",
+		strconv.FormatFloat(lon, 'f', -1, 64), strconv.FormatFloat(lat, 'f', -1, 64))
+	return c.Search(ctx, opts)
+}
@@ -0,0 +1,49 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBBoxWKT(t *testing.T) {
+	if got, want := BBoxWKT([4]float64{-10, 40.5, 5, 50}), "POLYGON ((-10 40.5, 5 40.5, 5 50, -10 50, -10 40.5))"; got != want {
+		t.Fatalf("unexpected WKT:\n got %s\nwant %s", got, want)
+	}
+	want := "MULTIPOLYGON (((170 -10, 180 -10, 180 10, 170 10, 170 -10)), ((-180 -10, -170 -10, -170 10, -180 10, -180 -10)))"
+	if got := BBoxWKT([4]float64{170, -10, -170, 10}); got != want {
+		t.Fatalf("unexpected antimeridian WKT:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestBBoxAndPointSearch(t *testing.T) {
+	var intersects []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		intersects = append(intersects, r.URL.Query().Get("intersectsWith"))
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if _, err := client.Search(ctx, SearchOptions{BBox: [4]float64{0, 0, 1, 1}}); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if _, err := client.Search(ctx, SearchOptions{BBox: [4]float64{0, 0, 1, 1}, IntersectsWith: "POINT (3 4)"}); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if _, err := client.PointSearch(ctx, 64.8, -147.7, SearchOptions{BBox: [4]float64{0, 0, 1, 1}}); err != nil {
+		t.Fatalf("PointSearch returned error: %v", err)
+	}
+	want := []string{"POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", "POINT (3 4)", "POINT (-147.7 64.8)"}
+	for i := range want {
+		if intersects[i] != want[i] {
+			t.Fatalf("request %d: expected intersectsWith %q, got %q", i, want[i], intersects[i])
+		}
+	}
+
+	if _, err := client.PointSearch(ctx, 95, 0, SearchOptions{}); err == nil {
+		t.Fatalf("expected error for out-of-range latitude")
+	}
+}
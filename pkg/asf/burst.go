@@ -0,0 +1,96 @@
+package asf
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BurstInfo holds the Sentinel-1 burst metadata of SLC-BURST products.
+type BurstInfo struct {
+	AbsoluteBurstID int       `json:"absoluteBurstID"`
+	RelativeBurstID int       `json:"relativeBurstID"`
+	FullBurstID     string    `json:"fullBurstID"`
+	BurstIndex      int       `json:"burstIndex"`
+	Subswath        string    `json:"subswath"`
+	SamplesPerBurst int       `json:"samplesPerBurst"`
+	AzimuthTime     time.Time `json:"azimuthTime"`
+	// AzimuthAnxTime is the burst's azimuth time in seconds since the
+	// ascending node crossing.
+	AzimuthAnxTime float64 `json:"azimuthAnxTime"`
+}
+
+// UnmarshalJSON accepts the API's representation, in which azimuthAnxTime is
+// a string and azimuthTime lacks a time zone.
+func (b *BurstInfo) UnmarshalJSON(data []byte) error {
+	type plain BurstInfo
+	var raw struct {
+		plain
+		AzimuthTime    string          `json:"azimuthTime"`
+		AzimuthAnxTime json.RawMessage `json:"azimuthAnxTime"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*b = BurstInfo(raw.plain)
+
+	if raw.AzimuthTime != "" {
+		t, err := parseFlexibleTime(raw.AzimuthTime)
+		if err != nil {
+			return fmt.Errorf("azimuthTime: %w", err)
+		}
+		b.AzimuthTime = t
+	}
+	if anx := strings.Trim(string(raw.AzimuthAnxTime), `"`); anx != "" && anx != "null" {
+		v, err := strconv.ParseFloat(anx, 64)
+		if err != nil {
+			return fmt.Errorf("azimuthAnxTime: %w", err)
+		}
+		b.AzimuthAnxTime = v
+	}
+	return nil
+}
+
+// timeLayouts lists the timestamp layouts seen in ASF responses, most
+// common first. Layouts without a zone are interpreted as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// parseFlexibleTime parses a timestamp in any of the layouts used by the API.
+func parseFlexibleTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
+
+// matchesBurstFilters applies the burst filters the search API cannot
+// evaluate server-side.
+func matchesBurstFilters(p Product, opts SearchOptions) bool {
+	if len(opts.Subswaths) == 0 && len(opts.BurstIndexes) == 0 {
+		return true
+	}
+	burst := p.Properties.Burst
+	if burst == nil {
+		return false
+	}
+	if len(opts.Subswaths) > 0 && !slices.ContainsFunc(opts.Subswaths, func(s string) bool {
+		return strings.EqualFold(s, burst.Subswath)
+	}) {
+		return false
+	}
+	if len(opts.BurstIndexes) > 0 && !slices.Contains(opts.BurstIndexes, burst.BurstIndex) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package asf
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBurstSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("dataset"); got != "SLC-BURST" {
+			t.Errorf("expected dataset SLC-BURST, got %q", got)
+		}
+		if got := q.Get("fullBurstID"); got != "064_136213_IW2" {
+			t.Errorf("unexpected fullBurstID: %q", got)
+		}
+		if got := q["relativeBurstID"]; len(got) != 2 || got[0] != "136213" || got[1] != "136214" {
+			t.Errorf("unexpected relativeBurstID: %v", got)
+		}
+		if q.Has("subswath") || q.Has("burstIndex") {
+			t.Errorf("client-side burst filters must not be sent: %v", q)
+		}
+		w.Write([]byte(`{"features":[
+			{"properties":{"sceneName":"B1","burst":{"absoluteBurstID":290,"relativeBurstID":136213,"fullBurstID":"064_136213_IW2","burstIndex":4,"subswath":"IW2","samplesPerBurst":21648,"azimuthTime":"2023-03-14T01:49:32.937146","azimuthAnxTime":"2186.7265477"}}},
+			{"properties":{"sceneName":"B2","burst":{"relativeBurstID":136214,"fullBurstID":"064_136214_IW3","burstIndex":5,"subswath":"IW3"}}},
+			{"properties":{"sceneName":"NOBURST"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	opts := SearchOptions{
+		Datasets:         []Dataset{DatasetSentinel1Bursts},
+		FullBurstIDs:     []string{"064_136213_IW2"},
+		RelativeBurstIDs: []int{136213, 136214},
+		Subswaths:        []string{"iw2"},
+		BurstIndexes:     []int{4},
+	}
+	products, err := NewClient(WithBaseURL(server.URL)).Search(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(products) != 1 || products[0].Properties.SceneName != "B1" {
+		t.Fatalf("expected only B1 to pass client-side filters, got %+v", products)
+	}
+
+	burst := products[0].Properties.Burst
+	if burst.AbsoluteBurstID != 290 || burst.SamplesPerBurst != 21648 {
+		t.Fatalf("unexpected burst ids: %+v", burst)
+	}
+	if burst.AzimuthAnxTime != 2186.7265477 {
+		t.Fatalf("unexpected azimuth anx time: %v", burst.AzimuthAnxTime)
+	}
+	want := time.Date(2023, 3, 14, 1, 49, 32, 937146000, time.UTC)
+	if !burst.AzimuthTime.Equal(want) {
+		t.Fatalf("unexpected azimuth time: %s", burst.AzimuthTime)
+	}
+}
+
+func TestBurstInfoRoundTrip(t *testing.T) {
+	in := BurstInfo{FullBurstID: "064_136213_IW2", AzimuthAnxTime: 12.5, AzimuthTime: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var out BurstInfo
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out != in {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", out, in)
+	}
+}
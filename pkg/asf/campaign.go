@@ -0,0 +1,83 @@
+package asf
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SMAPProperties holds the SMAP-specific fields of a product's properties.
+type SMAPProperties struct {
+	// HalfOrbit is "A" or "D" for the ascending or descending half orbit.
+	HalfOrbit string `json:"halfOrbit"`
+	// RelativeOrbitPhase is the position of the orbit in SMAP's 8-day
+	// repeat cycle.
+	RelativeOrbitPhase int    `json:"relativeOrbitPhase"`
+	CompositeReleaseID string `json:"compositeReleaseID"`
+}
+
+// AIRSARProperties holds the AIRSAR-specific fields of a product's
+// properties.
+type AIRSARProperties struct {
+	Campaign   string `json:"campaign"`
+	Site       string `json:"site"`
+	FlightLine string `json:"flightLine"`
+}
+
+// SMAP decodes the SMAP-specific properties of p. It reports false for
+// products of other platforms or products not decoded from an API response.
+func (p Product) SMAP() (SMAPProperties, bool) {
+	var props SMAPProperties
+	return props, p.Properties.decodeCampaign(PlatformSMAP, &props)
+}
+
+// AIRSAR decodes the AIRSAR-specific properties of p. It reports false for
+// products of other platforms or products not decoded from an API response.
+func (p Product) AIRSAR() (AIRSARProperties, bool) {
+	var props AIRSARProperties
+	return props, p.Properties.decodeCampaign(PlatformAIRSAR, &props)
+}
+
+// campaignPlatforms lists the platforms whose raw properties are kept for the
+// typed accessors above.
+var campaignPlatforms = []Platform{PlatformSMAP, PlatformAIRSAR}
+
+// isCampaignPlatform reports whether the raw properties of platform should be
+// kept.
+func isCampaignPlatform(platform string) bool {
+	for _, p := range campaignPlatforms {
+		if strings.EqualFold(platform, string(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeCampaign decodes the retained raw properties into out if the product
+// belongs to platform.
+func (p Properties) decodeCampaign(platform Platform, out any) bool {
+	if len(p.campaign) == 0 || !strings.EqualFold(p.Platform, string(platform)) {
+		return false
+	}
+	return json.Unmarshal(p.campaign, out) == nil
+}
+
+// MarshalJSON writes the properties, including any platform-specific fields
+// retained for the typed accessors, so that saved results round-trip.
+func (p Properties) MarshalJSON() ([]byte, error) {
+	type plain Properties
+	data, err := json.Marshal(plain(p))
+	if err != nil || len(p.campaign) == 0 {
+		return data, err
+	}
+	var merged, known map[string]json.RawMessage
+	if err := json.Unmarshal(p.campaign, &merged); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &known); err != nil {
+		return nil, err
+	}
+	for key, value := range known {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
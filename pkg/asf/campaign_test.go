@@ -0,0 +1,50 @@
+package asf
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestCampaignProperties(t *testing.T) {
+	data := []byte(`[
+		{"properties":{"platform":"SMAP","sceneName":"SP_L1A","halfOrbit":"D","relativeOrbitPhase":57,"compositeReleaseID":"R18290"}},
+		{"properties":{"platform":"AIRSAR","sceneName":"ts1899","campaign":"PACRIM2","site":"Tahiti","flightLine":"CM6132"}},
+		{"properties":{"platform":"Sentinel-1A","sceneName":"S1A","halfOrbit":"A"}}
+	]`)
+	products, err := ReadProducts(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadProducts returned error: %v", err)
+	}
+
+	smap, ok := products[0].SMAP()
+	if !ok || smap.HalfOrbit != "D" || smap.RelativeOrbitPhase != 57 || smap.CompositeReleaseID != "R18290" {
+		t.Fatalf("unexpected SMAP properties %+v (ok=%v)", smap, ok)
+	}
+	if _, ok := products[0].AIRSAR(); ok {
+		t.Fatalf("expected no AIRSAR properties for a SMAP product")
+	}
+	airsar, ok := products[1].AIRSAR()
+	if !ok || airsar.Campaign != "PACRIM2" || airsar.Site != "Tahiti" || airsar.FlightLine != "CM6132" {
+		t.Fatalf("unexpected AIRSAR properties %+v (ok=%v)", airsar, ok)
+	}
+	if _, ok := products[2].SMAP(); ok {
+		t.Fatalf("expected no SMAP properties for a Sentinel-1 product")
+	}
+
+	// Saved results keep the platform-specific fields.
+	saved, err := json.Marshal(products)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	reloaded, err := ReadProducts(bytes.NewReader(saved))
+	if err != nil {
+		t.Fatalf("ReadProducts returned error: %v", err)
+	}
+	if got, ok := reloaded[0].SMAP(); !ok || got != smap {
+		t.Fatalf("SMAP properties did not round-trip: %+v", got)
+	}
+	if reloaded[0].Properties.SceneName != "SP_L1A" {
+		t.Fatalf("unexpected scene name %q", reloaded[0].Properties.SceneName)
+	}
+}
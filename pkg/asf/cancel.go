@@ -0,0 +1,34 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// contextReader stops reading once ctx is done, so decoding a large search
+// response aborts as soon as the search is cancelled or its time limit
+// passes, even when the body is already buffered and the transport would
+// not notice.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// readError describes err from reading or decoding a response body, wrapping
+// the context's error rather than the decoder's when ctx ended the read, so
+// callers enforcing a latency budget can match context.DeadlineExceeded or
+// context.Canceled with errors.Is.
+func readError(ctx context.Context, op string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("asf: %s aborted: %w", op, ctxErr)
+	}
+	return fmt.Errorf("asf: %s: %w", op, err)
+}
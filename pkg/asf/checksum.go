@@ -0,0 +1,122 @@
+package asf
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumVerifier checks a completed download, before it is moved into
+// place, against the checksum published for its product. Implementations
+// can use hardware-accelerated or parallel hashing for fast transfer nodes.
+type ChecksumVerifier interface {
+	// Verify returns an error wrapping ErrChecksumMismatch if the file at
+	// path does not match the product. ctx bounds any lookups it makes.
+	Verify(ctx context.Context, path string, product Product) error
+}
+
+// ChecksumVerifierFunc adapts a function to the ChecksumVerifier interface.
+type ChecksumVerifierFunc func(ctx context.Context, path string, product Product) error
+
+func (f ChecksumVerifierFunc) Verify(ctx context.Context, path string, product Product) error {
+	return f(ctx, path, product)
+}
+
+// HashVerifier verifies files by hashing them with New and comparing the hex
+// digest with the one Expected returns for the product. Products without an
+// expected digest pass.
+type HashVerifier struct {
+	New      func() hash.Hash
+	Expected func(Product) string
+}
+
+func (v HashVerifier) Verify(ctx context.Context, path string, product Product) error {
+	return verifyDigest(path, v.New, v.Expected(product))
+}
+
+// MD5Verifier checks files against Properties.Md5sum. It is the verifier
+// used by WithChecksumVerification.
+var MD5Verifier = HashVerifier{
+	New:      md5.New,
+	Expected: func(p Product) string { return p.Properties.Md5sum },
+}
+
+// WithChecksumVerification verifies each downloaded file against the
+// product's Md5sum before moving it into place. Mismatching files are deleted
+// and reported with ErrChecksumMismatch; products without a checksum are not
+// verified.
+func WithChecksumVerification() Option {
+	return WithChecksumVerifier(MD5Verifier)
+}
+
+// WithChecksumVerifier verifies each downloaded file with v before moving it
+// into place, as WithChecksumVerification does with MD5.
+func WithChecksumVerifier(v ChecksumVerifier) Option {
+	return func(c *Client) {
+		c.checksumVerifier = v
+	}
+}
+
+// VerifyChecksum compares the MD5 digest of the file at path with the
+// expected hex digest. An empty expected digest always passes.
+func VerifyChecksum(path, expected string) error {
+	return verifyDigest(path, md5.New, expected)
+}
+
+// verifyDigest hashes the file at path with newHash and compares the result
+// with the expected hex digest, which passes when empty.
+func verifyDigest(path string, newHash func() hash.Hash, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	h := newHash()
+	if _, err := io.Copy(h, file); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, expected) {
+		return fmt.Errorf("%w: got %s, want %s", ErrChecksumMismatch, got, expected)
+	}
+	return nil
+}
+
+// WithCMRChecksumVerification verifies each download against the SHA-256
+// checksum NASA CMR publishes for it, looked up with FetchUMM, since some
+// archives publish no MD5. Files without a SHA-256 in CMR fall back to the
+// product's Md5sum; files with neither are not verified. If CMR cannot be
+// reached, the download fails but the completed partial file is kept, to be
+// verified on the next attempt.
+func WithCMRChecksumVerification() Option {
+	return func(c *Client) {
+		c.checksumVerifier = cmrVerifier{c}
+	}
+}
+
+// cmrVerifier implements WithCMRChecksumVerification.
+type cmrVerifier struct {
+	c *Client
+}
+
+func (v cmrVerifier) Verify(ctx context.Context, path string, product Product) error {
+	granule, err := v.c.productUMM(ctx, product)
+	if err != nil && !errors.Is(err, ErrGranuleNotFound) {
+		return fmt.Errorf("look up checksum in CMR: %w", err)
+	}
+	if sum := granule.Checksum(filepath.Base(product.Properties.FileName), "SHA-256"); sum != "" {
+		return verifyDigest(path, sha256.New, sum)
+	}
+	return MD5Verifier.Verify(ctx, path, product)
+}
@@ -0,0 +1,128 @@
+package asf
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownloadCustomChecksumVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	// sha256("payload")
+	sums := map[string]string{"f.zip": "239f59ed55e737c77147cf55ad0c1b030b6d7ee748a7426952f9b852d5a935e5"}
+	verifier := HashVerifier{
+		New:      sha256.New,
+		Expected: func(p Product) string { return sums[p.Properties.FileName] },
+	}
+	client := NewClient(WithChecksumVerifier(verifier))
+
+	targetDir := t.TempDir()
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL}}
+	if err := client.Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "f.zip")); err != nil {
+		t.Fatalf("expected verified file to be kept: %v", err)
+	}
+
+	sums["f.zip"] = "0000"
+	err := client.Download(context.Background(), t.TempDir(), product)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	var calls int
+	counting := ChecksumVerifierFunc(func(ctx context.Context, path string, p Product) error {
+		calls++
+		return nil
+	})
+	if err := NewClient(WithChecksumVerifier(counting)).Download(context.Background(), t.TempDir(), product); err != nil || calls != 1 {
+		t.Fatalf("expected the verifier to be called once, got %d calls and %v", calls, err)
+	}
+}
+
+func TestDownloadCMRChecksumVerification(t *testing.T) {
+	data := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("payload"))
+	}))
+	defer data.Close()
+
+	// sha256("payload")
+	sum := "239f59ed55e737c77147cf55ad0c1b030b6d7ee748a7426952f9b852d5a935e5"
+	cmr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("granule_ur") != "f-SLC" {
+			w.Write([]byte(`{"items":[]}`))
+			return
+		}
+		w.Write([]byte(`{"items":[{"meta":{"concept-id":"G1-ASF"},"umm":{"DataGranule":{"ArchiveAndDistributionInformation":[
+			{"Name":"f.zip","Checksum":{"Value":"` + sum + `","Algorithm":"SHA-256"}}]}}}]}`))
+	}))
+	defer cmr.Close()
+
+	client := NewClient(WithCMRURL(cmr.URL), WithCMRChecksumVerification())
+	product := Product{Properties: Properties{SceneName: "f", FileID: "f-SLC", FileName: "f.zip", URL: data.URL}}
+	if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+
+	sum = "0000"
+	if err := client.Download(context.Background(), t.TempDir(), product); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	// Without a CMR record the product's MD5 is used instead.
+	product.Properties.FileID = "other"
+	product.Properties.Md5sum = "0000"
+	if err := client.Download(context.Background(), t.TempDir(), product); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected MD5 fallback mismatch, got %v", err)
+	}
+}
+
+func TestDownloadCMRLookupFailureKeepsFile(t *testing.T) {
+	data := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "f.zip", time.Time{}, strings.NewReader("payload"))
+	}))
+	defer data.Close()
+	var cmrDown atomic.Bool
+	cmrDown.Store(true)
+	cmr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cmrDown.Load() {
+			http.Error(w, "unavailable", http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"items":[{"meta":{"concept-id":"G1-ASF"},"umm":{"DataGranule":{"ArchiveAndDistributionInformation":[
+			{"Name":"f.zip","Checksum":{"Value":"239f59ed55e737c77147cf55ad0c1b030b6d7ee748a7426952f9b852d5a935e5","Algorithm":"SHA-256"}}]}}}]}`))
+	}))
+	defer cmr.Close()
+
+	targetDir := t.TempDir()
+	client := NewClient(WithCMRURL(cmr.URL), WithCMRChecksumVerification())
+	product := Product{Properties: Properties{SceneName: "f", FileID: "f-SLC", FileName: "f.zip", URL: data.URL + "/f.zip"}}
+	err := client.Download(context.Background(), targetDir, product)
+	if err == nil || errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected a lookup error, got %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(targetDir, "f.zip.part")); err != nil || string(content) != "payload" {
+		t.Fatalf("expected the completed partial file to be kept, got %q (err %v)", content, err)
+	}
+
+	cmrDown.Store(false)
+	if err := client.Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(targetDir, "f.zip")); err != nil || string(content) != "payload" {
+		t.Fatalf("unexpected content %q (err %v)", content, err)
+	}
+}
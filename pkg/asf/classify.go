@@ -0,0 +1,94 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+)
+
+// ErrorClass says whether a failed search or download is worth retrying
+// later.
+type ErrorClass int
+
+const (
+	// Permanent failures, such as a 404 for a decommissioned product or a
+	// rejected credential, fail the same way when repeated.
+	Permanent ErrorClass = iota + 1
+	// Transient failures, such as timeouts, connection resets, throttling
+	// and 5xx responses, may succeed when the operation is requeued.
+	Transient
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case Permanent:
+		return "permanent"
+	case Transient:
+		return "transient"
+	default:
+		return "unclassified"
+	}
+}
+
+// ClassifiedError attaches an ErrorClass to an error. Errors returned for
+// unexpected HTTP statuses carry one.
+type ClassifiedError struct {
+	Class ErrorClass
+	Err   error
+}
+
+func (e *ClassifiedError) Error() string { return e.Err.Error() }
+
+func (e *ClassifiedError) Unwrap() error { return e.Err }
+
+// Classify returns the class of err, so orchestration layers can requeue only
+// transient failures. The outermost ClassifiedError in the chain decides;
+// otherwise network errors, timeouts, truncated transfers and failed
+// integrity checks, which a fresh attempt may fix, are transient, and
+// everything else, including cancellation by the caller and redirect loops,
+// is permanent. Classify(nil) returns zero.
+func Classify(err error) ErrorClass {
+	if err == nil {
+		return 0
+	}
+	var classified *ClassifiedError
+	if errors.As(err, &classified) && classified.Class != 0 {
+		return classified.Class
+	}
+	var redirectErr *RedirectError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, ErrClientClosed), errors.As(err, &redirectErr):
+		return Permanent
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return Transient
+	case errors.Is(err, ErrChecksumMismatch), errors.Is(err, ErrInvalidDownload), errors.Is(err, ErrCorruptArchive):
+		return Transient
+	default:
+		return Permanent
+	}
+}
+
+// IsTransient reports whether err is classified as Transient.
+func IsTransient(err error) bool {
+	return Classify(err) == Transient
+}
+
+// statusClass classifies an unexpected HTTP status code.
+func statusClass(code int) ErrorClass {
+	switch {
+	case code == http.StatusRequestTimeout, code == http.StatusTooEarly,
+		code == http.StatusTooManyRequests, code >= 500:
+		return Transient
+	default:
+		return Permanent
+	}
+}
+
+// classifyStatus wraps err, which reports an unexpected HTTP status, with the
+// status code's class.
+func classifyStatus(code int, err error) error {
+	return &ClassifiedError{Class: statusClass(code), Err: err}
+}
@@ -0,0 +1,85 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"nil", nil, 0},
+		{"not found", classifyStatus(http.StatusNotFound, fmt.Errorf("404")), Permanent},
+		{"throttled", classifyStatus(http.StatusTooManyRequests, fmt.Errorf("429")), Transient},
+		{"server error", classifyStatus(http.StatusBadGateway, fmt.Errorf("502")), Transient},
+		{"wrapped", fmt.Errorf("outer: %w", classifyStatus(http.StatusServiceUnavailable, fmt.Errorf("503"))), Transient},
+		{"deadline", fmt.Errorf("send: %w", context.DeadlineExceeded), Transient},
+		{"cancelled", context.Canceled, Permanent},
+		{"checksum", fmt.Errorf("verify: %w", ErrChecksumMismatch), Transient},
+		{"auth", ErrAuthRedirect, Permanent},
+		{"other", fmt.Errorf("boom"), Permanent},
+	} {
+		if got := Classify(tc.err); got != tc.want {
+			t.Errorf("%s: Classify = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDownloadStatusClassified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	dir := t.TempDir()
+	for name, want := range map[string]ErrorClass{"gone.zip": Permanent, "busy.zip": Transient} {
+		err := client.Download(context.Background(), dir, Product{Properties: Properties{
+			URL:      server.URL + "/" + name,
+			FileName: name,
+		}})
+		if got := Classify(err); got != want {
+			t.Errorf("%s: Classify(%v) = %v, want %v", name, err, got, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gone.zip")); err == nil {
+		t.Fatalf("expected no file for failed download")
+	}
+}
+
+func TestBatchErrorTransient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var products []Product
+	for _, name := range []string{"gone.zip", "busy.zip"} {
+		products = append(products, Product{Properties: Properties{URL: server.URL + "/" + name, FileName: name}})
+	}
+	err := NewClient().Download(context.Background(), t.TempDir(), products...)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 {
+		t.Fatalf("expected both downloads to fail, got %v", err)
+	}
+	if transient := batchErr.Transient(); len(transient) != 1 || transient[0].Properties.FileName != "busy.zip" {
+		t.Fatalf("expected only busy.zip to be transient, got %+v", transient)
+	}
+}
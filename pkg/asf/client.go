@@ -1,20 +1,22 @@
 package asf
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
-	"os"
-	"path/filepath"
-	"runtime"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 
-	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -22,10 +24,70 @@ const (
 )
 
 // Client provides access to ASF Search endpoints.
+//
+// A Client is safe for concurrent use by multiple goroutines. All
+// configuration is applied by NewClient and never mutated afterwards, and the
+// default cookie jar synchronizes its own writes, so a single Client should be
+// shared rather than created per request.
 type Client struct {
 	baseURL       string
+	cmrURL        string
 	httpClient    *http.Client
 	authenticator Authenticator
+
+	// cookieJar replaces the default jar when customJar is set; a nil jar
+	// disables cookie persistence entirely.
+	cookieJar    http.CookieJar
+	customJar    bool
+	dial         dialConfig
+	maxRedirects int
+
+	clock         Clock
+	hedgeDelay    time.Duration
+	searchGroup   *singleflight.Group
+	searchTimeout time.Duration
+	pageSize      int
+	retry         RetryPolicy
+
+	logger         *slog.Logger
+	validateSchema bool
+
+	outputFormat OutputFormat
+	transformers []Transformer
+
+	usage            usage
+	statsLogInterval time.Duration
+
+	lifecycle lifecycle
+
+	invalidFileRetries  int
+	downloadRetries     int
+	downloadBackoff     time.Duration
+	checksumVerifier    ChecksumVerifier
+	downloadHeaders     http.Header
+	downloadConcurrency int
+	downloadQueueSize   int
+	adaptive            *adaptiveLimiter
+	hostLimits          *hostLimits
+	batchMode           BatchMode
+	authWarmup          bool
+	downloadOrder       DownloadOrder
+	noResume            bool
+	segmentParts        int
+	segmentMinSize      int64
+	preserveTimestamps  bool
+	tempDir             string
+	destResolver        DestResolver
+	includePatterns     []string
+	excludePatterns     []string
+	urlRewriter         URLRewriter
+
+	preferS3        bool
+	s3Signer        S3Signer
+	s3Endpoint      string
+	s3RequesterPays bool
+	s3Regions       sync.Map
+	progress        ProgressFunc
 }
 
 // Option mutates the client when constructing it.
@@ -42,24 +104,79 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 	if c == nil {
 		return nil, fmt.Errorf("asf: client is nil")
 	}
-	if c.authenticator != nil {
+	if region, ok := req.Context().Value(s3RegionKey{}).(string); ok {
+		if c.s3Signer != nil {
+			if err := c.s3Signer(req, region); err != nil {
+				return nil, fmt.Errorf("asf: sign S3 request: %w", err)
+			}
+		}
+	} else if c.authenticator != nil {
 		if err := c.authenticator(req); err != nil {
 			return nil, fmt.Errorf("asf: authenticate request: %w", err)
 		}
 	}
-	return c.httpClient.Do(req)
+	defer c.maybeLogStats()
+
+	hc := c.httpClient
+	if req.Context().Value(timeoutOverrideKey{}) != nil {
+		// The request carries its own deadline; lift the client-wide limit.
+		override := *hc
+		override.Timeout = 0
+		hc = &override
+	}
+	resp, err := c.sendWithRetry(req, func(req *http.Request) (*http.Response, error) {
+		c.usage.requests.Add(1)
+		resp, err := hc.Do(req)
+		if err == nil && isThrottled(resp) {
+			c.usage.throttled.Add(1)
+		}
+		return resp, err
+	})
+	var redirectErr *RedirectError
+	if errors.As(err, &redirectErr) {
+		// The wrapping *url.Error would repeat the full last URL, which may
+		// carry login tokens.
+		return nil, redirectErr
+	}
+	return resp, err
+}
+
+// timeoutOverrideKey marks request contexts whose deadline replaces the HTTP
+// client's Timeout.
+type timeoutOverrideKey struct{}
+
+// WithSearchTimeout sets the default time limit for search requests,
+// replacing the HTTP client's own timeout for them. Complex geometry queries
+// can take the API well beyond the default 30 seconds to answer.
+func WithSearchTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.searchTimeout = timeout
+	}
 }
 
-// WithHTTPClient configures a custom HTTP client instance.
+// WithHTTPClient configures a custom HTTP client instance. Passing nil keeps
+// the default client.
 func WithHTTPClient(hc *http.Client) Option {
 	return func(c *Client) {
-		if hc == nil {
-			hc = newDefaultHTTPClient()
-		}
 		c.httpClient = hc
 	}
 }
 
+// WithCookieJar sets the cookie jar used by the default HTTP client. It has no
+// effect when a custom client is supplied through WithHTTPClient.
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(c *Client) {
+		c.cookieJar = jar
+		c.customJar = true
+	}
+}
+
+// WithNoCookieJar disables cookie persistence on the default HTTP client,
+// which suits stateless workers that authenticate with tokens only.
+func WithNoCookieJar() Option {
+	return WithCookieJar(nil)
+}
+
 // WithAuthToken configures the bearer token used for authenticated requests.
 func WithAuthToken(token string) Option {
 	return WithAuthenticator(BearerToken(token))
@@ -75,14 +192,17 @@ func WithAuthenticator(auth Authenticator) Option {
 // NewClient creates a Client with sensible defaults.
 func NewClient(opts ...Option) *Client {
 	c := &Client{
-		baseURL:    defaultBaseURL,
-		httpClient: newDefaultHTTPClient(),
+		baseURL: defaultBaseURL,
+		cmrURL:  defaultCMRURL,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
 	if c.httpClient == nil {
-		c.httpClient = newDefaultHTTPClient()
+		c.httpClient = c.newDefaultHTTPClient()
+	}
+	if c.hostLimits != nil {
+		c.hostLimits.other = make(chan struct{}, c.overallWorkers())
 	}
 	return c
 }
@@ -98,16 +218,131 @@ type SearchOptions struct {
 	LookDirections  []LookDirection
 	Start           time.Time
 	End             time.Time
-	RelativeOrbit   string
+	// RelativeOrbits selects tracks; consecutive values are sent as ranges.
+	RelativeOrbits  []int
+	AbsoluteOrbits  []int
 	FlightDirection FlightDirection
 	IntersectsWith  string
 	GranuleIDs      []string
-	MaxResults      int
+	Datasets        []Dataset
+	// FrameStart and FrameEnd select an inclusive range of ESA frame
+	// numbers, the API's frame parameter. Setting only FrameStart selects a
+	// single frame.
+	FrameStart, FrameEnd int
+	// ASFFrameStart and ASFFrameEnd select an inclusive range of ASF frame
+	// numbers, ASF's own (and JAXA's) numbering, which differs from the ESA
+	// frames for the same scene.
+	ASFFrameStart, ASFFrameEnd int
+	// Season limits results to a range of days of the year across all years
+	// between Start and End, e.g. only summer acquisitions.
+	Season Season
+	// BBox is a [west, south, east, north] area filter in degrees, used when
+	// IntersectsWith is empty. The zero value disables it.
+	BBox [4]float64
+	// ProcessedAfter and ProcessedBefore select products by when ASF
+	// processed them rather than when they were acquired, so that ingest
+	// pipelines can fetch what is new since their last run. The API only
+	// accepts a lower bound; ProcessedBefore is applied client-side.
+	ProcessedAfter  time.Time
+	ProcessedBefore time.Time
+	// MaxResults caps the number of products returned. For Search it is the
+	// size of the single page requested; for SearchAll it caps the total
+	// across pages, with zero meaning no cap.
+	MaxResults int
+	// PageSize sets the page size used by SearchAll; zero uses the client's
+	// default (see WithPageSize).
+	PageSize int
+	// Burst filters select Sentinel-1 SLC-BURST products. Subswaths and
+	// BurstIndexes are not supported by the API and are applied client-side.
+	FullBurstIDs     []string
+	RelativeBurstIDs []int
+	Subswaths        []string
+	BurstIndexes     []int
+	// SortBy and SortOrder ask the API to order results, so that "newest N"
+	// queries need not over-fetch. Single pages and SearchAll results are
+	// also sorted client-side in case the endpoint ignores them; SearchIter
+	// yields products in the order the API returns them. The client-side
+	// sort only reorders the products fetched, so with MaxResults set an
+	// endpoint that ignores the sort returns the first N in its own order,
+	// not the top N.
+	SortBy    SortKey
+	SortOrder SortOrder
+	// Output overrides the client's wire format for this search.
+	Output OutputFormat
+	// OmitGeometry discards footprints while decoding, for metadata-only
+	// inventories where they would dominate memory use. The API has no way
+	// to leave them out, so they are still transferred.
+	OmitGeometry bool
+	// Timeout overrides the client's search time limit for this search.
+	Timeout time.Duration
+	// Extra carries additional raw query parameters, such as server-side
+	// tuning options without a dedicated field. They are added after, and
+	// alongside, the parameters generated from the typed fields.
+	Extra url.Values
 }
 
-// Search queries the ASF search API and returns a list of products.
+// Search queries the ASF search API and returns a single page of products,
+// at most MaxResults of them. Use SearchAll to page through larger result
+// sets, or SearchDetailed to learn whether the page was truncated.
 func (c *Client) Search(ctx context.Context, opts SearchOptions) ([]Product, error) {
-	endpoint, err := url.JoinPath(c.baseURL, "services", "search", "param")
+	result, err := c.SearchDetailed(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result.Products, nil
+}
+
+// searchPage fetches one page of raw search results. Page numbers start at 1;
+// zero omits the page parameter.
+func (c *Client) searchPage(ctx context.Context, opts SearchOptions, page int) ([]Product, error) {
+	ctx, cancel := c.searchContext(ctx, opts)
+	defer cancel()
+	opts.Output = c.searchFormat(opts)
+	q := encodeSearchOptions(opts)
+	setPositiveInt(q, "page", page)
+	return c.fetchProducts(ctx, q, opts.Output, opts.OmitGeometry, "services", "search", "param")
+}
+
+// filterProducts drops products that fail client-side filters.
+func filterProducts(products []Product, opts SearchOptions) []Product {
+	return slices.DeleteFunc(products, func(p Product) bool {
+		return !matchesBurstFilters(p, opts) || !matchesProcessingDate(p, opts)
+	})
+}
+
+// matchesProcessingDate applies ProcessedBefore, which the search API cannot
+// evaluate server-side.
+func matchesProcessingDate(p Product, opts SearchOptions) bool {
+	return opts.ProcessedBefore.IsZero() || p.Properties.ProcessingDate.Before(opts.ProcessedBefore)
+}
+
+// searchContext applies the effective search time limit to ctx.
+func (c *Client) searchContext(ctx context.Context, opts SearchOptions) (context.Context, context.CancelFunc) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = c.searchTimeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	ctx = context.WithValue(ctx, timeoutOverrideKey{}, true)
+	return context.WithTimeout(ctx, timeout)
+}
+
+// fetchProducts runs a product-returning query against an API path below the
+// base URL and decodes the response in the given format, dropping footprints
+// if omitGeometry is set. Identical concurrent queries share one request when
+// WithSearchDeduplication is set.
+func (c *Client) fetchProducts(ctx context.Context, query url.Values, format OutputFormat, omitGeometry bool, path ...string) ([]Product, error) {
+	if c.searchGroup == nil {
+		return c.fetchProductsOnce(ctx, query, format, omitGeometry, path...)
+	}
+	return c.fetchProductsShared(ctx, query, format, omitGeometry, path...)
+}
+
+// fetchProductsOnce performs the request for fetchProducts.
+func (c *Client) fetchProductsOnce(ctx context.Context, query url.Values, format OutputFormat, omitGeometry bool, path ...string) ([]Product, error) {
+	endpoint, err := url.JoinPath(c.baseURL, path...)
 	if err != nil {
 		return nil, fmt.Errorf("asf: invalid base URL: %w", err)
 	}
@@ -116,9 +351,9 @@ func (c *Client) Search(ctx context.Context, opts SearchOptions) ([]Product, err
 	if err != nil {
 		return nil, fmt.Errorf("asf: create request: %w", err)
 	}
-	req.URL.RawQuery = encodeSearchOptions(opts).Encode()
+	req.URL.RawQuery = query.Encode()
 
-	resp, err := c.do(req)
+	resp, err := c.doSearch(req)
 	if err != nil {
 		return nil, fmt.Errorf("asf: send request: %w", err)
 	}
@@ -126,16 +361,63 @@ func (c *Client) Search(ctx context.Context, opts SearchOptions) ([]Product, err
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("asf: unexpected status %d: %s", resp.StatusCode, string(body))
+		return nil, c.statusError(resp, body)
+	}
+
+	body := io.Reader(contextReader{ctx: ctx, r: resp.Body})
+	if c.validateSchema && format == OutputGeoJSON {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, readError(ctx, "read response", err)
+		}
+		c.reportSchemaDrift(data)
+		body = contextReader{ctx: ctx, r: bytes.NewReader(data)}
+	}
+
+	products, err := decodeProducts(body, format, omitGeometry)
+	if err != nil {
+		return nil, readError(ctx, "decode response", err)
+	}
+	if err := c.transform(products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// getJSON issues a GET request against an API path below the base URL and
+// decodes the JSON response into out.
+func (c *Client) getJSON(ctx context.Context, query url.Values, out any, path ...string) error {
+	return c.getJSONFrom(ctx, c.baseURL, query, out, path...)
+}
+
+// getJSONFrom is getJSON against another service's base URL, such as CMR.
+func (c *Client) getJSONFrom(ctx context.Context, baseURL string, query url.Values, out any, path ...string) error {
+	endpoint, err := url.JoinPath(baseURL, path...)
+	if err != nil {
+		return fmt.Errorf("asf: invalid base URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("asf: create request: %w", err)
 	}
+	req.URL.RawQuery = query.Encode()
 
-	var payload FeatureCollection
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&payload); err != nil {
-		return nil, fmt.Errorf("asf: decode response: %w", err)
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("asf: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return c.statusError(resp, body)
 	}
 
-	return payload.Features, nil
+	if err := json.NewDecoder(contextReader{ctx: ctx, r: resp.Body}).Decode(out); err != nil {
+		return readError(ctx, "decode response", err)
+	}
+	return nil
 }
 
 // encodeSearchOptions flattens search options into URL query parameters.
@@ -149,13 +431,28 @@ func encodeSearchOptions(opts SearchOptions) url.Values {
 	addQueryValues(q, "processingLevel", opts.ProcessingLevel)
 	addQueryValues(q, "lookDirection", opts.LookDirections)
 	addStringQueryValues(q, "granule_list", opts.GranuleIDs)
-	setQueryIfNonEmpty(q, "intersectsWith", opts.IntersectsWith)
-	setQueryIfNonEmpty(q, "relativeOrbit", opts.RelativeOrbit)
+	addQueryValues(q, "dataset", opts.Datasets)
+	addStringQueryValues(q, "fullBurstID", opts.FullBurstIDs)
+	addIntQueryValues(q, "relativeBurstID", opts.RelativeBurstIDs)
+	setQueryIfNonEmpty(q, "intersectsWith", opts.intersectsWith())
+	addStringQueryValues(q, "relativeOrbit", formatIntRanges(opts.RelativeOrbits))
+	addStringQueryValues(q, "absoluteOrbit", formatIntRanges(opts.AbsoluteOrbits))
+	setQueryIfNonEmpty(q, "frame", formatFrameRange(opts.FrameStart, opts.FrameEnd))
+	setQueryIfNonEmpty(q, "asfframe", formatFrameRange(opts.ASFFrameStart, opts.ASFFrameEnd))
 	setQueryIfNonEmpty(q, "flightDirection", opts.FlightDirection)
 	setQueryTime(q, "start", opts.Start)
 	setQueryTime(q, "end", opts.End)
+	setQueryTime(q, "processingDate", opts.ProcessedAfter)
+	setQueryIfNonEmpty(q, "season", opts.Season.param())
 	setPositiveInt(q, "maxResults", opts.MaxResults)
-	q.Set("output", "geojson")
+	setSortQuery(q, opts)
+	if opts.Output == "" {
+		opts.Output = OutputGeoJSON
+	}
+	q.Set("output", string(opts.Output))
+	for key, values := range opts.Extra {
+		addStringQueryValues(q, key, values)
+	}
 	return q
 }
 
@@ -177,6 +474,13 @@ func addStringQueryValues(q url.Values, key string, values []string) {
 	}
 }
 
+// addIntQueryValues appends integer values.
+func addIntQueryValues(q url.Values, key string, values []int) {
+	for _, value := range values {
+		q.Add(key, strconv.Itoa(value))
+	}
+}
+
 // setQueryIfNonEmpty sets a query parameter if the string-based value is not empty.
 func setQueryIfNonEmpty[T ~string](q url.Values, key string, value T) {
 	if s := string(value); s != "" {
@@ -197,73 +501,6 @@ func setPositiveInt(q url.Values, key string, value int) {
 	}
 }
 
-// Download fetches all products in the list and saves them to the targetFolder.
-// It downloads files concurrently, limiting concurrency to runtime.NumCPU().
-func (c *Client) Download(ctx context.Context, targetFolder string, products ...Product) error {
-	if len(products) == 0 {
-		return nil
-	}
-
-	if err := os.MkdirAll(targetFolder, 0755); err != nil {
-		return fmt.Errorf("asf: create target folder %q: %w", targetFolder, err)
-	}
-
-	g, gctx := errgroup.WithContext(ctx)
-	// Limit concurrency to avoid overwhelming the network or server.
-	g.SetLimit(runtime.NumCPU())
-
-	for _, p := range products {
-		product := p // Capture loop variable for goroutine.
-		g.Go(func() error {
-			return c.downloadProduct(gctx, targetFolder, product)
-		})
-	}
-
-	return g.Wait()
-}
-
-// downloadProduct handles the download of a single product.
-func (c *Client) downloadProduct(ctx context.Context, targetFolder string, product Product) error {
-	if product.Properties.URL == "" {
-		return fmt.Errorf("asf: product %q has no URL", product.Properties.SceneName)
-	}
-	if product.Properties.FileName == "" {
-		return fmt.Errorf("asf: product %q has no FileName", product.Properties.SceneName)
-	}
-
-	destPath := filepath.Join(targetFolder, product.Properties.FileName)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, product.Properties.URL, nil)
-	if err != nil {
-		return fmt.Errorf("asf: create download request for %q: %w", product.Properties.FileName, err)
-	}
-
-	resp, err := c.do(req)
-	if err != nil {
-		return fmt.Errorf("asf: send download request for %q: %w", product.Properties.FileName, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("asf: unexpected download status for %q: %d: %s", product.Properties.FileName, resp.StatusCode, string(body))
-	}
-
-	// Create the destination file.
-	file, err := os.Create(destPath)
-	if err != nil {
-		return fmt.Errorf("asf: create file %q: %w", destPath, err)
-	}
-	defer file.Close()
-
-	// Stream the response body to the file.
-	if _, err := io.Copy(file, resp.Body); err != nil {
-		return fmt.Errorf("asf: save file %q: %w", destPath, err)
-	}
-
-	return nil
-}
-
 // Authenticator applies authentication information to a request.
 type Authenticator = func(*http.Request) error
 
@@ -299,23 +536,17 @@ func HeaderAuth(headers map[string]string) Authenticator {
 	}
 }
 
-func newDefaultHTTPClient() *http.Client {
-	jar, _ := cookiejar.New(nil)
+// newDefaultHTTPClient builds the HTTP client used when none is supplied.
+func (c *Client) newDefaultHTTPClient() *http.Client {
 	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-		Jar:     jar,
+		Timeout:   30 * time.Second,
+		Transport: c.dial.transport(),
 	}
-	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-		if len(via) == 0 {
-			return nil
-		}
-		prev := via[len(via)-1]
-
-		// Only re-apply auth header on redirect
-		if authHeader := prev.Header.Get("Authorization"); authHeader != "" {
-			req.Header.Set("Authorization", authHeader)
-		}
-		return nil
+	if c.customJar {
+		httpClient.Jar = c.cookieJar
+	} else {
+		httpClient.Jar, _ = cookiejar.New(nil)
 	}
+	httpClient.CheckRedirect = c.checkRedirect
 	return httpClient
 }
@@ -2,10 +2,14 @@ package asf
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest" // Import the httptest package
-	"os"                // Import the os package to read the file
+	"net/url"
+	"os" // Import the os package to read the file
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -369,3 +373,223 @@ func TestDownloadErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestDownloadHTMLLoginPage(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "ContentType", contentType: "text/html; charset=utf-8", body: "please log in"},
+		{name: "Preview", contentType: "application/octet-stream", body: "\n<!DOCTYPE html><html><body>Earthdata Login</body></html>"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			targetDir := t.TempDir()
+			products := []Product{{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}}
+			err := NewClient().Download(context.Background(), targetDir, products...)
+			if !errors.Is(err, ErrAuthRedirect) {
+				t.Fatalf("expected ErrAuthRedirect, got: %v", err)
+			}
+			if _, statErr := os.Stat(filepath.Join(targetDir, "f.zip")); !os.IsNotExist(statErr) {
+				t.Fatalf("expected no file to be written, stat returned: %v", statErr)
+			}
+		})
+	}
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	payloadBytes, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("failed to read asf_response.json: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Exercise concurrent cookie jar writes on every response.
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: r.URL.Path, Path: "/"})
+		if r.URL.Path == "/services/search/param" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(payloadBytes)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithAuthToken("token"))
+	targetDir := t.TempDir()
+
+	const workers = 64
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				_, err := client.Search(context.Background(), SearchOptions{MaxResults: 2})
+				errs <- err
+				return
+			}
+			name := "file" + strconv.Itoa(i) + ".zip"
+			product := Product{Properties: Properties{SceneName: name, FileName: name, URL: server.URL + "/" + name}}
+			errs <- client.Download(context.Background(), targetDir, product)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent request failed: %v", err)
+		}
+	}
+}
+
+func TestCookieJarOptions(t *testing.T) {
+	var sawCookie bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("session"); err == nil {
+			sawCookie = true
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	download := func(t *testing.T, client *Client) {
+		t.Helper()
+		for _, name := range []string{"a.zip", "b.zip"} {
+			product := Product{Properties: Properties{SceneName: name, FileName: name, URL: server.URL + "/" + name}}
+			if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+				t.Fatalf("Download failed: %v", err)
+			}
+		}
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		sawCookie = false
+		download(t, NewClient())
+		if !sawCookie {
+			t.Fatalf("expected default client to persist cookies")
+		}
+	})
+
+	t.Run("NoCookieJar", func(t *testing.T) {
+		sawCookie = false
+		download(t, NewClient(WithNoCookieJar()))
+		if sawCookie {
+			t.Fatalf("expected cookies not to be sent without a jar")
+		}
+	})
+
+	t.Run("CustomJar", func(t *testing.T) {
+		sawCookie = false
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			t.Fatalf("create jar: %v", err)
+		}
+		download(t, NewClient(WithCookieJar(jar)))
+		if !sawCookie {
+			t.Fatalf("expected custom jar to persist cookies")
+		}
+		u, _ := url.Parse(server.URL)
+		if len(jar.Cookies(u)) != 1 {
+			t.Fatalf("expected custom jar to hold the session cookie, got %v", jar.Cookies(u))
+		}
+	})
+}
+
+func TestSearchTimeoutOverridesClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer server.Close()
+
+	hc := &http.Client{Timeout: 20 * time.Millisecond}
+	client := NewClient(WithBaseURL(server.URL), WithHTTPClient(hc))
+	if _, err := client.Search(context.Background(), SearchOptions{}); err == nil {
+		t.Fatalf("expected the HTTP client timeout to apply by default")
+	}
+	if _, err := client.Search(context.Background(), SearchOptions{Timeout: time.Second}); err != nil {
+		t.Fatalf("expected per-search timeout to extend the limit, got: %v", err)
+	}
+
+	client = NewClient(WithBaseURL(server.URL), WithHTTPClient(hc), WithSearchTimeout(10*time.Millisecond))
+	_, err := client.Search(context.Background(), SearchOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected search deadline error, got: %v", err)
+	}
+}
+
+func TestSearchExtraParameters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("asfframe"); got != "true" {
+			t.Errorf("expected extra asfframe=true, got %q", got)
+		}
+		if got := q["platform"]; len(got) != 2 {
+			t.Errorf("expected extra values to be added alongside typed ones, got %v", got)
+		}
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer server.Close()
+
+	opts := SearchOptions{
+		Platforms: []Platform{PlatformSentinel1A},
+		Extra:     url.Values{"asfframe": {"true"}, "platform": {"Sentinel-1B"}},
+	}
+	if _, err := NewClient(WithBaseURL(server.URL)).Search(context.Background(), opts); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+}
+
+func TestSearchRelativeOrbitRanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query()["relativeOrbit"]; strings.Join(got, ",") != "15-17,20" {
+			t.Errorf("expected relativeOrbit terms [15-17 20], got %v", got)
+		}
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer server.Close()
+
+	opts := SearchOptions{RelativeOrbits: []int{17, 20, 15, 16}}
+	if _, err := NewClient(WithBaseURL(server.URL)).Search(context.Background(), opts); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+}
+
+func TestSearchProcessingDateWindow(t *testing.T) {
+	fixture, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("processingDate"); got != "2025-10-01T00:00:00Z" {
+			t.Errorf("expected processingDate=2025-10-01T00:00:00Z, got %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	opts := SearchOptions{
+		ProcessedAfter:  time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		ProcessedBefore: time.Date(2025, 10, 28, 2, 10, 0, 0, time.UTC),
+	}
+	products, err := NewClient(WithBaseURL(server.URL)).Search(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(products) != 1 || !products[0].Properties.ProcessingDate.Equal(time.Date(2025, 10, 28, 2, 9, 50, 0, time.UTC)) {
+		t.Fatalf("expected only the product processed before the bound, got %d products", len(products))
+	}
+}
@@ -0,0 +1,43 @@
+package asf
+
+import "time"
+
+// Clock abstracts the wall clock for time-dependent client behavior such as
+// hedging delays and periodic stats logging, so that it can be simulated in
+// tests or by embedding applications.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the current time once d has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by package time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// WithClock replaces the wall clock used by the client. Passing nil keeps the
+// system clock.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// now returns the current time according to the client's clock.
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
+
+// after waits for d on the client's clock.
+func (c *Client) after(d time.Duration) <-chan time.Time {
+	if c.clock == nil {
+		return systemClock{}.After(d)
+	}
+	return c.clock.After(d)
+}
@@ -0,0 +1,140 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	f.waiters = append(f.waiters, fakeWaiter{at: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward, firing any waiters that become due.
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	pending := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.at.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- f.now
+	}
+	f.waiters = pending
+}
+
+func TestWithClockDrivesStatsLogInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	var logs bytes.Buffer
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithClock(clock),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithStatsLogInterval(time.Minute),
+	)
+	search := func() {
+		if _, err := client.Search(context.Background(), SearchOptions{}); err != nil {
+			t.Fatalf("Search returned error: %v", err)
+		}
+	}
+
+	search()
+	search()
+	if n := strings.Count(logs.String(), "asf: client usage"); n != 1 {
+		t.Fatalf("expected 1 usage log line before the interval elapses, got %d", n)
+	}
+
+	clock.Advance(time.Minute)
+	search()
+	if n := strings.Count(logs.String(), "asf: client usage"); n != 2 {
+		t.Fatalf("expected 2 usage log lines after advancing the clock, got %d", n)
+	}
+}
+
+func TestWithClockDrivesHedgeDelay(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+		if first {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.Write([]byte(`{"features":[{"properties":{"sceneName":"hedged"}}]}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	clock := newFakeClock()
+	client := NewClient(WithBaseURL(server.URL), WithClock(clock), WithHedging(time.Hour))
+
+	done := make(chan []Product, 1)
+	go func() {
+		products, _ := client.Search(context.Background(), SearchOptions{})
+		done <- products
+	}()
+
+	// Wait for the hedge timer to be registered, then fire it.
+	for {
+		clock.mu.Lock()
+		registered := len(clock.waiters) > 0
+		clock.mu.Unlock()
+		if registered {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(time.Hour)
+
+	products := <-done
+	if len(products) != 1 || products[0].Properties.SceneName != "hedged" {
+		t.Fatalf("expected the hedged request to win, got %+v", products)
+	}
+}
@@ -0,0 +1,54 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Count returns the number of products matching opts without fetching them,
+// using the API's count output mode. MaxResults, paging and output settings
+// are ignored, as are the client-side filters (Subswaths, BurstIndexes and
+// ProcessedBefore), so the count may exceed what a search returns.
+func (c *Client) Count(ctx context.Context, opts SearchOptions) (int, error) {
+	ctx, cancel := c.searchContext(ctx, opts)
+	defer cancel()
+
+	opts.MaxResults = 0
+	q := encodeSearchOptions(opts)
+	q.Set("output", "count")
+
+	endpoint, err := url.JoinPath(c.baseURL, "services", "search", "param")
+	if err != nil {
+		return 0, fmt.Errorf("asf: invalid base URL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("asf: create request: %w", err)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.doSearch(req)
+	if err != nil {
+		return 0, fmt.Errorf("asf: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("asf: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, c.statusError(resp, body)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(body)))
+	if err != nil {
+		return 0, fmt.Errorf("asf: decode count: %w", err)
+	}
+	return count, nil
+}
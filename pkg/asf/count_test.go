@@ -0,0 +1,47 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("output"); got != "count" {
+			t.Errorf("expected output=count, got %q", got)
+		}
+		if q.Has("maxResults") {
+			t.Errorf("expected maxResults to be omitted, got %q", q.Get("maxResults"))
+		}
+		if got := q.Get("platform"); got != "Sentinel-1A" {
+			t.Errorf("expected platform filter, got %q", got)
+		}
+		w.Write([]byte("12345\n"))
+	}))
+	defer server.Close()
+
+	count, err := NewClient(WithBaseURL(server.URL)).Count(context.Background(), SearchOptions{
+		Platforms:  []Platform{PlatformSentinel1A},
+		MaxResults: 10,
+	})
+	if err != nil {
+		t.Fatalf("Count returned error: %v", err)
+	}
+	if count != 12345 {
+		t.Fatalf("expected 12345, got %d", count)
+	}
+}
+
+func TestCountRejectsNonNumericResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":"nope"}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(WithBaseURL(server.URL)).Count(context.Background(), SearchOptions{}); err == nil {
+		t.Fatalf("expected error for non-numeric count response")
+	}
+}
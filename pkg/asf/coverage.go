@@ -0,0 +1,156 @@
+package asf
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// FilterByCoverage keeps the products whose footprint covers at least
+// minFraction (0 to 1) of the area of interest given as a WKT POLYGON or
+// MULTIPOLYGON. The API's intersectsWith matches any overlap, so this drops
+// scenes that only graze the edge of the AOI. Products without a footprint
+// cannot be judged and are kept.
+func FilterByCoverage(products []Product, aoiWKT string, minFraction float64) ([]Product, error) {
+	aoi, err := ParseWKT(aoiWKT)
+	if err != nil {
+		return nil, err
+	}
+	var kept []Product
+	for _, product := range products {
+		footprint, err := product.ParseGeometry()
+		if err != nil {
+			return nil, err
+		}
+		if footprint.IsEmpty() || Coverage(footprint, aoi) >= minFraction {
+			kept = append(kept, product)
+		}
+	}
+	return kept, nil
+}
+
+// Coverage returns the fraction of the area of aoi that lies inside
+// footprint, from 0 to 1. Areas are computed in planar longitude/latitude,
+// which is accurate enough for the ratio over scene-sized regions. Footprint
+// polygons are treated as convex, as ASF footprints are.
+func Coverage(footprint, aoi Geometry) float64 {
+	total := aoi.Area()
+	if total == 0 {
+		return 0
+	}
+	var covered float64
+	for _, polygon := range footprint.Polygons {
+		if len(polygon) == 0 {
+			continue
+		}
+		hull := convexHull(polygon[0])
+		if len(hull) < 3 {
+			continue
+		}
+		for _, area := range aoi.Polygons {
+			for i, ring := range area {
+				clipped := math.Abs(ringArea(clipRing(ring, hull)))
+				if i > 0 {
+					clipped = -clipped
+				}
+				covered += clipped
+			}
+		}
+	}
+	return min(1, max(0, covered/total))
+}
+
+// Area returns the planar area of the geometry in square degrees, with holes
+// subtracted.
+func (g Geometry) Area() float64 {
+	var total float64
+	for _, polygon := range g.Polygons {
+		for i, ring := range polygon {
+			area := math.Abs(ringArea(ring))
+			if i > 0 {
+				area = -area
+			}
+			total += area
+		}
+	}
+	return total
+}
+
+// ringArea returns the signed shoelace area of a ring, positive when it is
+// wound counter-clockwise.
+func ringArea(ring Ring) float64 {
+	area, _, _ := ringMoments(ring)
+	return area
+}
+
+// convexHull returns the counter-clockwise convex hull of a ring, without a
+// closing position.
+func convexHull(ring Ring) Ring {
+	points := slices.Clone(ring)
+	slices.SortFunc(points, func(a, b Position) int {
+		if c := cmp.Compare(a[0], b[0]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a[1], b[1])
+	})
+	points = slices.Compact(points)
+	if len(points) < 3 {
+		return points
+	}
+
+	var hull Ring
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range points {
+			for len(hull) >= start+2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+		slices.Reverse(points)
+	}
+	return hull
+}
+
+// cross returns the z component of (b-a)×(c-a); positive when c lies left of
+// the line from a to b.
+func cross(a, b, c Position) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// clipRing clips a ring to a counter-clockwise convex polygon using the
+// Sutherland-Hodgman algorithm. The area of the result is exact even for
+// concave rings, though it may contain degenerate edges.
+func clipRing(ring Ring, clip Ring) Ring {
+	output := ring
+	if len(output) > 1 && output[0] == output[len(output)-1] {
+		output = output[:len(output)-1]
+	}
+	for i := range clip {
+		a, b := clip[i], clip[(i+1)%len(clip)]
+		input := output
+		output = nil
+		for j := range input {
+			p, q := input[j], input[(j+1)%len(input)]
+			pIn, qIn := cross(a, b, p) >= 0, cross(a, b, q) >= 0
+			if pIn {
+				output = append(output, p)
+			}
+			if pIn != qIn {
+				output = append(output, intersect(a, b, p, q))
+			}
+		}
+		if len(output) == 0 {
+			return nil
+		}
+	}
+	return output
+}
+
+// intersect returns where segment p-q crosses the line through a and b.
+func intersect(a, b, p, q Position) Position {
+	dp, dq := cross(a, b, p), cross(a, b, q)
+	t := dp / (dp - dq)
+	return Position{p[0] + t*(q[0]-p[0]), p[1] + t*(q[1]-p[1])}
+}
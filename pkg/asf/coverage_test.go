@@ -0,0 +1,81 @@
+package asf
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func squareProduct(name string, x0, y0, x1, y1 float64) Product {
+	geometry, _ := json.Marshal(map[string]any{
+		"type":        "Polygon",
+		"coordinates": [][][2]float64{{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}, {x0, y0}}},
+	})
+	return Product{Geometry: geometry, Properties: Properties{SceneName: name}}
+}
+
+func TestFilterByCoverage(t *testing.T) {
+	aoi := "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))"
+	products := []Product{
+		squareProduct("full", -1, -1, 11, 11),
+		squareProduct("half", 5, -5, 15, 15),
+		squareProduct("sliver", 9.5, 0, 20, 10),
+		{Properties: Properties{SceneName: "no-footprint"}},
+	}
+
+	kept, err := FilterByCoverage(products, aoi, 0.25)
+	if err != nil {
+		t.Fatalf("FilterByCoverage returned error: %v", err)
+	}
+	var names []string
+	for _, p := range kept {
+		names = append(names, p.Properties.SceneName)
+	}
+	if len(names) != 3 || names[0] != "full" || names[1] != "half" || names[2] != "no-footprint" {
+		t.Fatalf("unexpected products kept: %v", names)
+	}
+
+	if _, err := FilterByCoverage(products, "POINT (1 2)", 0.5); err == nil {
+		t.Fatalf("expected error for non-polygon AOI")
+	}
+}
+
+func TestCoverageConcaveAOIWithHole(t *testing.T) {
+	// An L-shaped AOI of area 3 with its inner corner at (1,1).
+	aoi, err := ParseWKT("POLYGON ((0 0, 2 0, 2 1, 1 1, 1 2, 0 2, 0 0))")
+	if err != nil {
+		t.Fatalf("ParseWKT returned error: %v", err)
+	}
+	footprint, _ := squareProduct("", 0, 0, 2, 1).ParseGeometry()
+	if got := Coverage(footprint, aoi); math.Abs(got-2.0/3) > 1e-9 {
+		t.Fatalf("expected 2/3 coverage, got %f", got)
+	}
+
+	holed, err := ParseWKT("POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 3 1, 3 3, 1 3, 1 1))")
+	if err != nil {
+		t.Fatalf("ParseWKT returned error: %v", err)
+	}
+	footprint, _ = squareProduct("", 0, 0, 4, 2).ParseGeometry()
+	// The footprint covers 8 of the square minus 2 of the hole, out of 12.
+	if got := Coverage(footprint, holed); math.Abs(got-0.5) > 1e-9 {
+		t.Fatalf("expected 0.5 coverage, got %f", got)
+	}
+}
+
+func TestParseWKT(t *testing.T) {
+	geometry, err := ParseWKT("MULTIPOLYGON Z (((0 0 1, 1 0 1, 1 1 1, 0 0 1)), ((5 5, 6 5, 6 6, 5 5)))")
+	if err != nil {
+		t.Fatalf("ParseWKT returned error: %v", err)
+	}
+	if geometry.Type != "MultiPolygon" || len(geometry.Polygons) != 2 || geometry.Polygons[1][0][2] != (Position{6, 6}) {
+		t.Fatalf("unexpected geometry: %+v", geometry)
+	}
+	if wkt := geometry.WKT(); wkt != "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))" {
+		t.Fatalf("unexpected round trip: %s", wkt)
+	}
+	for _, bad := range []string{"POLYGON ((0 0, 1 1", "POLYGON ((0 x, 1 1, 0 0))", "POLYGON ((0 0, 1 0, 0 0)) extra", "LINESTRING (0 0, 1 1)"} {
+		if _, err := ParseWKT(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// WithSearchDeduplication collapses identical searches issued concurrently,
+// as is common in web backends, into a single upstream request whose results
+// every caller receives. Searches are identical when their encoded queries,
+// endpoints and wire formats match.
+func WithSearchDeduplication() Option {
+	return func(c *Client) {
+		c.searchGroup = new(singleflight.Group)
+	}
+}
+
+// fetchProductsShared runs fetchProductsOnce through the client's singleflight
+// group. Each caller gets its own copy of the product slice, since later
+// filtering and sorting modify it in place.
+func (c *Client) fetchProductsShared(ctx context.Context, query url.Values, format OutputFormat, omitGeometry bool, path ...string) ([]Product, error) {
+	key := fmt.Sprintf("%s?%s|%s|%t", strings.Join(path, "/"), query.Encode(), format, omitGeometry)
+	results := c.searchGroup.DoChan(key, func() (any, error) {
+		return c.fetchProductsOnce(ctx, query, format, omitGeometry, path...)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-results:
+		if res.Err != nil {
+			// The shared request ran with the first caller's context; if that
+			// caller gave up, try again with our own.
+			if res.Shared && ctx.Err() == nil &&
+				(errors.Is(res.Err, context.Canceled) || errors.Is(res.Err, context.DeadlineExceeded)) {
+				return c.fetchProductsOnce(ctx, query, format, omitGeometry, path...)
+			}
+			return nil, res.Err
+		}
+		return slices.Clone(res.Val.([]Product)), nil
+	}
+}
@@ -0,0 +1,64 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSearchDeduplication(t *testing.T) {
+	fixture, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var calls atomic.Int32
+	arrived := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		arrived <- struct{}{}
+		<-release
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithSearchDeduplication())
+	opts := SearchOptions{Platforms: []Platform{PlatformSentinel1}}
+	const callers = 5
+	results := make([][]Product, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			products, err := client.Search(context.Background(), opts)
+			if err != nil {
+				t.Errorf("Search returned error: %v", err)
+			}
+			results[i] = products
+		}()
+	}
+	<-arrived
+	// Give the other callers time to join the in-flight search.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", got)
+	}
+	for i, products := range results {
+		if len(products) != 2 {
+			t.Fatalf("caller %d got %d products, want 2", i, len(products))
+		}
+	}
+	results[0][0].Properties.SceneName = "changed"
+	if results[1][0].Properties.SceneName == "changed" {
+		t.Fatalf("expected each caller to get its own copy of the results")
+	}
+}
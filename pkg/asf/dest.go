@@ -0,0 +1,96 @@
+package asf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// File describes a single file fetched for a product.
+type File struct {
+	URL  string
+	Name string
+}
+
+// productFile returns the file downloaded for a product.
+func productFile(product Product) File {
+	return File{URL: product.Properties.URL, Name: product.Properties.FileName}
+}
+
+// DestResolver chooses the destination path of a downloaded file. Relative
+// paths are resolved against the download's target folder, and missing
+// parent directories are created. Returning ErrSkipDownload skips the file.
+type DestResolver func(product Product, file File) (string, error)
+
+// WithDestResolver sets the function choosing where each downloaded file is
+// written, for archive layouts that a flat target folder cannot express. By
+// default files are written to the target folder under their own names.
+func WithDestResolver(resolve DestResolver) Option {
+	return func(c *Client) {
+		c.destResolver = resolve
+	}
+}
+
+// WithFilePatterns limits downloads to products whose file name
+// (Properties.FileName) matches at least one include pattern, or any name
+// when include is empty, and none of the exclude patterns, such as include
+// "*.zip" and exclude "*_RAW_*" for a mixed result set. Each product is one
+// file, so patterns select whole products; they do not reach into archives.
+// Patterns use path.Match syntax. Other products are skipped as if a
+// DestResolver had returned ErrSkipDownload.
+func WithFilePatterns(include, exclude []string) Option {
+	return func(c *Client) {
+		c.includePatterns = include
+		c.excludePatterns = exclude
+	}
+}
+
+// fileSelected reports whether a file name passes WithFilePatterns.
+func (c *Client) fileSelected(name string) (bool, error) {
+	for _, pattern := range c.excludePatterns {
+		if matched, err := path.Match(pattern, name); err != nil || matched {
+			return false, err
+		}
+	}
+	for _, pattern := range c.includePatterns {
+		if matched, err := path.Match(pattern, name); err != nil || matched {
+			return matched, err
+		}
+	}
+	return len(c.includePatterns) == 0, nil
+}
+
+// destPath returns where a product's file is written. A nil error with an
+// empty path means the file is skipped.
+func (c *Client) destPath(targetFolder string, product Product) (string, error) {
+	file := productFile(product)
+	if selected, err := c.fileSelected(file.Name); err != nil {
+		return "", fmt.Errorf("asf: match file patterns against %q: %w", file.Name, err)
+	} else if !selected {
+		return "", nil
+	}
+	if c.destResolver == nil {
+		name := safeFileName(file.Name)
+		if name != file.Name {
+			return "", fmt.Errorf("asf: unsafe file name %q", file.Name)
+		}
+		return filepath.Join(targetFolder, name), nil
+	}
+
+	path, err := c.destResolver(product, file)
+	if errors.Is(err, ErrSkipDownload) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("asf: resolve destination for %q: %w", file.Name, err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(targetFolder, path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("asf: create folder for %q: %w", path, err)
+	}
+	return path, nil
+}
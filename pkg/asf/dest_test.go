@@ -0,0 +1,80 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithDestResolver(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	products := []Product{
+		{Properties: Properties{SceneName: "a", Platform: "Sentinel-1A", FileName: "a.zip", URL: server.URL + "/a.zip"}},
+		{Properties: Properties{SceneName: "b", Platform: "Sentinel-1B", FileName: "b.zip", URL: server.URL + "/b.zip"}},
+	}
+	client := NewClient(WithDestResolver(func(p Product, f File) (string, error) {
+		if p.Properties.Platform == "Sentinel-1B" {
+			return "", ErrSkipDownload
+		}
+		return filepath.Join(p.Properties.Platform, "nested", f.Name), nil
+	}), WithInvalidFileRetries(1))
+
+	targetDir := t.TempDir()
+	if err := client.Download(context.Background(), targetDir, products...); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected the skipped product not to be requested, got %d requests", got)
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "Sentinel-1A", "nested", "a.zip"))
+	if err != nil || string(content) != "payload" {
+		t.Fatalf("unexpected file content %q (err %v)", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "b.zip")); !os.IsNotExist(err) {
+		t.Fatalf("expected skipped product not to be written, got %v", err)
+	}
+}
+
+func TestWithFilePatterns(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	names := []string{"OPERA_L2_RTC-S1_VV.tif", "OPERA_L2_RTC-S1_VH.tif", "OPERA_L2_RTC-S1_VV_browse.png", "OPERA_L2_RTC-S1.h5"}
+	var products []Product
+	for _, name := range names {
+		products = append(products, Product{Properties: Properties{SceneName: "s", FileName: name, URL: server.URL + "/" + name}})
+	}
+	client := NewClient(WithFilePatterns([]string{"*_VV*", "*.h5"}, []string{"*.png"}))
+	targetDir := t.TempDir()
+	if err := client.Download(context.Background(), targetDir, products...); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	for _, name := range names {
+		_, err := os.Stat(filepath.Join(targetDir, name))
+		if want := name == "OPERA_L2_RTC-S1_VV.tif" || name == "OPERA_L2_RTC-S1.h5"; want != (err == nil) {
+			t.Errorf("%s: downloaded = %v, want %v", name, err == nil, want)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+
+	client = NewClient(WithFilePatterns([]string{"[bad"}, nil))
+	if err := client.Download(context.Background(), targetDir, products[0]); err == nil {
+		t.Fatalf("expected an error for a malformed pattern")
+	}
+}
@@ -0,0 +1,77 @@
+package asf
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// WithPreferIPv4 makes the default HTTP client try IPv4 before falling back
+// to the regular dual-stack dial. Use it on networks with broken IPv6 routes.
+func WithPreferIPv4() Option {
+	return func(c *Client) {
+		c.dial.preferIPv4 = true
+	}
+}
+
+// WithResolver sets the DNS resolver used by the default HTTP client.
+func WithResolver(resolver *net.Resolver) Option {
+	return func(c *Client) {
+		c.dial.resolver = resolver
+	}
+}
+
+// WithHostOverrides maps host names to fixed addresses (IPs or other host
+// names) for the default HTTP client, like entries in /etc/hosts. TLS still
+// verifies certificates against the original host name.
+func WithHostOverrides(overrides map[string]string) Option {
+	return func(c *Client) {
+		if c.dial.hostOverrides == nil {
+			c.dial.hostOverrides = make(map[string]string, len(overrides))
+		}
+		for host, addr := range overrides {
+			c.dial.hostOverrides[host] = addr
+		}
+	}
+}
+
+// dialConfig collects dialer settings for the default HTTP client. These
+// options have no effect on a client supplied through WithHTTPClient.
+type dialConfig struct {
+	preferIPv4    bool
+	resolver      *net.Resolver
+	hostOverrides map[string]string
+}
+
+func (d dialConfig) isZero() bool {
+	return !d.preferIPv4 && d.resolver == nil && len(d.hostOverrides) == 0
+}
+
+// transport returns an HTTP transport honoring the dial configuration, or nil
+// to use http.DefaultTransport when nothing is configured.
+func (d dialConfig) transport() http.RoundTripper {
+	if d.isZero() {
+		return nil
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Resolver:  d.resolver,
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			if target, ok := d.hostOverrides[host]; ok {
+				addr = net.JoinHostPort(target, port)
+			}
+		}
+		if d.preferIPv4 && network == "tcp" {
+			if conn, err := dialer.DialContext(ctx, "tcp4", addr); err == nil {
+				return conn, nil
+			}
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+	return t
+}
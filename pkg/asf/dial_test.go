@@ -0,0 +1,42 @@
+package asf
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestHostOverrides(t *testing.T) {
+	var gotHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	_, port, _ := net.SplitHostPort(u.Host)
+	client := NewClient(
+		WithBaseURL("http://search.asf.invalid:"+port),
+		WithHostOverrides(map[string]string{"search.asf.invalid": "127.0.0.1"}),
+		WithPreferIPv4(),
+	)
+	if _, err := client.Search(context.Background(), SearchOptions{}); err != nil {
+		t.Fatalf("Search through host override failed: %v", err)
+	}
+	if want := "search.asf.invalid:" + port; gotHost != want {
+		t.Fatalf("expected Host header %q, got %q", want, gotHost)
+	}
+}
+
+func TestDialConfigDefaultsToStandardTransport(t *testing.T) {
+	if tr := (dialConfig{}).transport(); tr != nil {
+		t.Fatalf("expected nil transport without dial options, got %T", tr)
+	}
+	if tr := (dialConfig{preferIPv4: true}).transport(); tr == nil {
+		t.Fatalf("expected custom transport when preferring IPv4")
+	}
+}
@@ -0,0 +1,686 @@
+package asf
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Download fetches all products in the list and saves them to the targetFolder.
+// It downloads files concurrently, by default limiting concurrency to
+// runtime.NumCPU() (see WithDownloadConcurrency). A failed product does not
+// cancel the others unless WithBatchMode(FailFast) is set; failures are
+// reported together as a *BatchError.
+// Files are written to a ".part" file first, synced and renamed once
+// complete, so a cancelled download never leaves a truncated file under the
+// final name; an existing ".part" file is resumed rather than fetched again
+// from the start. See WithTempDir to keep partial files elsewhere.
+func (c *Client) Download(ctx context.Context, targetFolder string, products ...Product) error {
+	return c.download(ctx, targetFolder, products, nil)
+}
+
+// download implements Download, recording each product's outcome in results
+// when it is not nil.
+func (c *Client) download(ctx context.Context, targetFolder string, products []Product, results []DownloadResult) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ctx, done, err := c.beginDownload(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if err := c.createFolders(targetFolder); err != nil {
+		return err
+	}
+	if err := c.warmUp(ctx, products); err != nil {
+		return err
+	}
+
+	// The results record the paths actually written, which can differ from
+	// the product's file name, as with DownloadURLs.
+	if results == nil {
+		results = make([]DownloadResult, len(products))
+	}
+	err = c.downloadBatch(ctx, targetFolder, products, results)
+	for attempt := 0; err == nil && attempt < c.invalidFileRetries; attempt++ {
+		invalid := invalidDownloads(results)
+		if len(invalid) == 0 {
+			return nil
+		}
+		c.log().Warn("asf: re-downloading invalid files", "count", len(invalid), "attempt", attempt+1)
+		retry := make([]Product, len(invalid))
+		for j, i := range invalid {
+			retry[j] = products[i]
+		}
+		retryResults := make([]DownloadResult, len(invalid))
+		err = c.downloadBatch(ctx, targetFolder, retry, retryResults)
+		for j, i := range invalid {
+			results[i] = retryResults[j]
+		}
+	}
+	if err != nil {
+		if errors.Is(context.Cause(ctx), ErrClientClosed) {
+			return fmt.Errorf("%w: %w", ErrClientClosed, err)
+		}
+		return err
+	}
+	if c.invalidFileRetries > 0 {
+		if invalid := invalidDownloads(results); len(invalid) > 0 {
+			return fmt.Errorf("asf: %d file(s) still invalid after %d re-download(s), first %q: %w",
+				len(invalid), c.invalidFileRetries, products[invalid[0]].Properties.FileName, ErrInvalidDownload)
+		}
+	}
+	return nil
+}
+
+// createFolders creates the target folder and the temporary folder, if one
+// is configured.
+func (c *Client) createFolders(targetFolder string) error {
+	if err := os.MkdirAll(targetFolder, 0755); err != nil {
+		return fmt.Errorf("asf: create target folder %q: %w", targetFolder, err)
+	}
+	if c.tempDir != "" {
+		if err := os.MkdirAll(c.tempDir, 0755); err != nil {
+			return fmt.Errorf("asf: create temporary folder %q: %w", c.tempDir, err)
+		}
+	}
+	return nil
+}
+
+// downloadBatch downloads products concurrently. Unless the client is in
+// FailFast mode, a failed product does not stop the others; all failures are
+// returned together in a *BatchError. Once ctx is cancelled no further
+// products are started. If results is not nil, it receives the outcome of
+// each product, at the product's index.
+func (c *Client) downloadBatch(ctx context.Context, targetFolder string, products []Product, results []DownloadResult) error {
+	batchCtx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+
+	if results == nil {
+		results = make([]DownloadResult, len(products))
+	}
+	errs := make([]error, len(products))
+	// run downloads products[i], first taking a slot from the adaptive
+	// limit when adaptive is set.
+	run := func(i int, adaptive bool) {
+		if batchCtx.Err() != nil {
+			errs[i] = context.Cause(batchCtx)
+			results[i].Err = errs[i]
+			return
+		}
+		if adaptive {
+			if err := c.adaptive.acquire(batchCtx); err != nil {
+				errs[i], results[i].Err = err, err
+				return
+			}
+			defer c.adaptive.release()
+		}
+		start := c.now()
+		err := c.downloadProduct(batchCtx, targetFolder, products[i], &results[i])
+		results[i].Duration = c.now().Sub(start)
+		results[i].Err = err
+		if err != nil && c.batchMode == FailFast {
+			abort(errBatchAborted)
+		}
+		errs[i] = err
+	}
+
+	for i, product := range products {
+		results[i].Product = product
+	}
+	sequence := c.downloadSequence(products)
+	if c.hostLimits != nil {
+		c.downloadPerHost(batchCtx, products, sequence, run)
+	} else {
+		var g errgroup.Group
+		// Limit concurrency to avoid overwhelming the network or server.
+		g.SetLimit(c.downloadWorkers())
+		for _, i := range sequence {
+			if batchCtx.Err() != nil {
+				run(i, false)
+				continue
+			}
+			g.Go(func() error {
+				run(i, c.adaptive != nil)
+				return nil
+			})
+		}
+		g.Wait()
+	}
+
+	batchErr := &BatchError{Total: len(products)}
+	for i, err := range errs {
+		// Products cut short by FailFast are not failures of their own.
+		aborted := errors.Is(context.Cause(batchCtx), errBatchAborted) && ctx.Err() == nil &&
+			(errors.Is(err, errBatchAborted) || errors.Is(err, context.Canceled))
+		if err != nil && !aborted {
+			batchErr.Errors = append(batchErr.Errors, &ProductError{Product: products[i], Err: err})
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+// errBatchAborted cancels the rest of a FailFast batch.
+var errBatchAborted = errors.New("asf: batch aborted after a failure")
+
+// WithDownloadRetries retries a file whose download fails transiently, such as
+// a connection reset partway through a multi-gigabyte transfer, up to
+// maxRetries times. Each retry resumes from the bytes already saved (unless
+// WithNoResume is set) after a jittered backoff computed as for RetryPolicy,
+// with backoff as the base delay (zero means one second), the policy's
+// MaxDelay as the cap and its OnRetry hook called; a Retry-After header on
+// the failed response is honored up to that cap. This is separate from
+// WithRetryPolicy, which only retries the individual request and cannot
+// recover from a failure after the body has started streaming.
+func WithDownloadRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.downloadRetries = maxRetries
+		c.downloadBackoff = backoff
+	}
+}
+
+// downloadProduct handles the download of a single product, retrying
+// transient failures as configured by WithDownloadRetries, and records its
+// destination in result. A partial file the server shows to be stale is
+// discarded and the download restarted from the beginning.
+func (c *Client) downloadProduct(ctx context.Context, targetFolder string, product Product, result *DownloadResult) error {
+	policy := c.retry
+	policy.BaseDelay = cmp.Or(c.downloadBackoff, time.Second)
+	restarted := false
+	for retry := 1; ; retry++ {
+		err := c.downloadFile(ctx, targetFolder, product, result)
+		if errors.Is(err, errStalePart) && !restarted {
+			// Start over from the beginning; this is not a retry.
+			restarted = true
+			retry--
+			continue
+		}
+		if err == nil || retry > c.downloadRetries || ctx.Err() != nil || !IsTransient(err) {
+			return err
+		}
+		delay := policy.backoff(retry)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			delay = policy.honorRetryAfter(delay, apiErr.RetryAfter)
+		}
+		c.log().Warn("asf: retrying download", "file", product.Properties.FileName, "retry", retry, "delay", delay, "error", err)
+		if policy.OnRetry != nil {
+			policy.OnRetry(retry, delay, err)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-c.after(delay):
+		}
+	}
+}
+
+// errStalePart reports a partial file that did not match the server's copy
+// of a product and was removed, so the download must start over.
+var errStalePart = errors.New("asf: partial file does not match the server's copy")
+
+// discardPart removes a partial file the server's response to a resume
+// request shows to be stale and returns an error wrapping errStalePart.
+func (c *Client) discardPart(product Product, partPath string, resp *http.Response) error {
+	c.log().Warn("asf: discarding stale partial file", "file", product.Properties.FileName,
+		"status", resp.StatusCode, "contentRange", resp.Header.Get("Content-Range"))
+	if err := os.Remove(partPath); err != nil {
+		return fmt.Errorf("asf: remove stale partial file %q: %w", partPath, err)
+	}
+	return fmt.Errorf("%w: %q (status %d, content range %q)", errStalePart,
+		product.Properties.FileName, resp.StatusCode, resp.Header.Get("Content-Range"))
+}
+
+// downloadFile makes a single attempt at downloading a product. Failures
+// after the destination is resolved are wrapped in a *DownloadError.
+func (c *Client) downloadFile(ctx context.Context, targetFolder string, product Product, result *DownloadResult) (err error) {
+	if product.Properties.URL == "" {
+		return fmt.Errorf("asf: product %q has no URL", product.Properties.SceneName)
+	}
+	if product.Properties.FileName == "" {
+		return fmt.Errorf("asf: product %q has no FileName", product.Properties.SceneName)
+	}
+
+	destPath, err := c.destPath(targetFolder, product)
+	if err != nil || destPath == "" {
+		return err
+	}
+	result.Path = destPath
+
+	// Bytes already fetched by an interrupted attempt are kept in a .part
+	// file and resumed with a Range request.
+	partPath := c.partPath(destPath)
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil && !c.noResume {
+		offset = info.Size()
+	}
+
+	downloadURL := c.sourceURL(product)
+	defer func() {
+		if err != nil {
+			err = &DownloadError{URL: downloadURL, Dest: destPath, Err: err}
+		}
+	}()
+	if c.urlRewriter != nil {
+		rewritten, err := c.urlRewriter(downloadURL)
+		if err != nil {
+			return fmt.Errorf("asf: rewrite URL for %q: %w", product.Properties.FileName, err)
+		}
+		downloadURL = rewritten
+	}
+
+	req, err := c.newFileRequest(ctx, downloadURL)
+	if err != nil {
+		return fmt.Errorf("asf: create download request for %q: %w", product.Properties.FileName, err)
+	}
+	release, err := c.acquireHost(ctx, req.URL.Hostname())
+	if err != nil {
+		return err
+	}
+	defer release()
+	// A large file is first probed for its size and Range support, then
+	// fetched in segments; a server that ignores the probe's Range header
+	// just sends the whole file, which is saved as usual.
+	probing := offset == 0 && c.segmentParts > 1 &&
+		(product.Properties.Bytes <= 0 || product.Properties.Bytes >= c.segmentMinSize)
+	switch {
+	case probing:
+		req.Header.Set("Range", "bytes=0-0")
+	case offset > 0:
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("asf: send download request for %q: %w", product.Properties.FileName, err)
+	}
+	defer resp.Body.Close()
+	if name := serverFileName(ctx, product, resp); name != "" {
+		// The partial file keeps the name derived from the URL, which stays
+		// the same between runs, so it can still be resumed.
+		destPath = filepath.Join(filepath.Dir(destPath), name)
+		result.Path = destPath
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		// The server ignored or was not sent a Range header; start over.
+		offset = 0
+	case resp.StatusCode == http.StatusPartialContent && probing:
+		total := contentRangeTotal(resp.Header.Get("Content-Range"))
+		if total < 0 {
+			return fmt.Errorf("asf: unexpected content range for %q: %q", product.Properties.FileName, resp.Header.Get("Content-Range"))
+		}
+		if err := c.downloadSegments(ctx, product, req.URL.Hostname(), downloadURL, total, partPath, destPath); err != nil {
+			return err
+		}
+		return c.preserveModTime(destPath, resp)
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return c.discardPart(product, partPath, resp)
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		if contentRangeTotal(resp.Header.Get("Content-Range")) != offset {
+			// The partial file is longer than the product, so it belongs
+			// to another version of it.
+			return c.discardPart(product, partPath, resp)
+		}
+		// The partial file already holds the whole product.
+		if err := c.finishDownload(ctx, product, partPath, destPath); err != nil {
+			return err
+		}
+		return c.preserveModTime(destPath, resp)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("asf: unexpected download status for %q: %w", product.Properties.FileName, c.statusError(resp, body))
+	}
+
+	// Guard against login pages being saved in place of the product.
+	body := bufio.NewReader(resp.Body)
+	if isHTMLResponse(resp, body) {
+		return fmt.Errorf("asf: download %q: %w", product.Properties.FileName, ErrAuthRedirect)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("asf: create file %q: %w", partPath, err)
+	}
+
+	// Stream the response body to the partial file.
+	dst := io.Writer(file)
+	var progress *progressWriter
+	if c.progress != nil {
+		// Without a Content-Length, fall back to the size in the
+		// product's metadata.
+		total, estimated := product.Properties.Bytes, product.Properties.Bytes > 0
+		if resp.ContentLength >= 0 {
+			total, estimated = offset+resp.ContentLength, false
+		}
+		progress = &progressWriter{report: c.progress, progress: Progress{
+			FileName:  product.Properties.FileName,
+			Bytes:     offset,
+			Resumed:   offset,
+			Total:     total,
+			Estimated: estimated,
+		}}
+		c.progress(progress.progress)
+		dst = io.MultiWriter(file, progress)
+	}
+	n, err := io.Copy(dst, body)
+	c.usage.bytesDownloaded.Add(n)
+	if err == nil {
+		// Flush to disk before the rename publishes the file, so a crash
+		// cannot leave a complete-looking but truncated product.
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("asf: save file %q: %w", destPath, err)
+	}
+	if err := c.finishDownload(ctx, product, partPath, destPath); err != nil {
+		return err
+	}
+	if err := c.preserveModTime(destPath, resp); err != nil {
+		return err
+	}
+	if progress != nil {
+		progress.progress.Done = true
+		if progress.progress.Estimated {
+			progress.progress.Total, progress.progress.Estimated = progress.progress.Bytes, false
+		}
+		c.progress(progress.progress)
+	}
+	return nil
+}
+
+// finishDownload verifies a completed partial file, when configured to, and
+// moves it into place. Only a checksum mismatch discards the partial file;
+// when verification itself fails, such as a checksum lookup, it is kept so
+// that a later attempt can verify it without fetching it again.
+func (c *Client) finishDownload(ctx context.Context, product Product, partPath, destPath string) error {
+	if c.checksumVerifier != nil {
+		if err := c.checksumVerifier.Verify(ctx, partPath, product); err != nil {
+			if errors.Is(err, ErrChecksumMismatch) {
+				os.Remove(partPath)
+			}
+			return fmt.Errorf("asf: verify %q: %w", product.Properties.FileName, err)
+		}
+	}
+	if err := moveFile(partPath, destPath); err != nil {
+		return fmt.Errorf("asf: save file %q: %w", destPath, err)
+	}
+	c.usage.downloads.Add(1)
+	return nil
+}
+
+// WithPreserveTimestamps sets the modification time of each downloaded file
+// from the Last-Modified header of its response, so sync tools and
+// make-style pipelines can tell fresh data from old. Files whose response
+// carries no valid Last-Modified keep the time they were written.
+func WithPreserveTimestamps() Option {
+	return func(c *Client) {
+		c.preserveTimestamps = true
+	}
+}
+
+// preserveModTime applies the Last-Modified time of resp to the file at path
+// when WithPreserveTimestamps is set.
+func (c *Client) preserveModTime(path string, resp *http.Response) error {
+	if !c.preserveTimestamps {
+		return nil
+	}
+	modTime, err := http.ParseTime(resp.Header.Get("Last-Modified"))
+	if err != nil {
+		return nil
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		return fmt.Errorf("asf: set modification time of %q: %w", path, err)
+	}
+	return nil
+}
+
+// WithTempDir keeps partial downloads in dir, such as fast local scratch
+// space, instead of next to their destination. Completed files are moved
+// into place, by copying when dir is on another file system. Partial files
+// are still resumed, as long as dir persists between runs.
+func WithTempDir(dir string) Option {
+	return func(c *Client) {
+		c.tempDir = dir
+	}
+}
+
+// partPath returns where the partial download of destPath is kept. In a
+// temporary directory the name carries a hash of destPath, so products with
+// the same file name in different destinations do not collide.
+func (c *Client) partPath(destPath string) string {
+	if c.tempDir == "" {
+		return destPath + ".part"
+	}
+	abs, err := filepath.Abs(destPath)
+	if err != nil {
+		abs = destPath
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(c.tempDir, fmt.Sprintf("%s.%x.part", filepath.Base(destPath), sum[:6]))
+}
+
+// moveFile renames src to dst, falling back to copying through a synced
+// ".part" file next to dst and renaming that when they are on different
+// file systems, so dst only ever appears complete.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil || filepath.Dir(src) == filepath.Dir(dst) {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp := dst + ".part"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, dst)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
+
+// isHTMLResponse reports whether a download response is an HTML document,
+// judged by its Content-Type or, failing that, by a preview of the body.
+func isHTMLResponse(resp *http.Response, body *bufio.Reader) bool {
+	if strings.HasPrefix(strings.ToLower(resp.Header.Get("Content-Type")), "text/html") {
+		return true
+	}
+	preview, _ := body.Peek(512)
+	return looksLikeHTML(preview)
+}
+
+// looksLikeHTML reports whether data starts like an HTML document.
+func looksLikeHTML(data []byte) bool {
+	data = bytes.ToLower(bytes.TrimSpace(data))
+	return bytes.HasPrefix(data, []byte("<!doctype html")) || bytes.HasPrefix(data, []byte("<html"))
+}
+
+// DownloadURLs downloads files directly from their URLs into targetFolder.
+// Each file is named after the last element of its URL path, unless the URL
+// looks like an API endpoint (it has a query string or no file extension);
+// then the name from the Content-Disposition header of the download response
+// is preferred. Names are sanitized so that no URL or header can place a
+// file outside targetFolder. It behaves like Download for products carrying
+// only a URL.
+func (c *Client) DownloadURLs(ctx context.Context, targetFolder string, urls ...string) error {
+	ctx = context.WithValue(ctx, serverNamesKey{}, true)
+	products := make([]Product, 0, len(urls))
+	for _, u := range urls {
+		name := fileNameFromURL(u)
+		products = append(products, Product{Properties: Properties{
+			URL:       u,
+			FileName:  name,
+			SceneName: sceneNameFromFile(name),
+		}})
+	}
+	return c.Download(ctx, targetFolder, products...)
+}
+
+// WithDownloadConcurrency sets how many files Download fetches at once. Zero
+// or a negative value uses runtime.NumCPU().
+func WithDownloadConcurrency(n int) Option {
+	return func(c *Client) {
+		c.downloadConcurrency = n
+	}
+}
+
+// WithNoResume makes downloads discard partial files left by interrupted
+// attempts and start over, for servers with unreliable Range support.
+func WithNoResume() Option {
+	return func(c *Client) {
+		c.noResume = true
+	}
+}
+
+// URLRewriter maps a product's download URL to the URL actually fetched.
+type URLRewriter func(rawURL string) (string, error)
+
+// WithURLRewriter rewrites download URLs before they are fetched, for sites
+// that front ASF with an institutional mirror or caching proxy. Destination
+// file names are still derived from the product, not the rewritten URL.
+func WithURLRewriter(rewrite URLRewriter) Option {
+	return func(c *Client) {
+		c.urlRewriter = rewrite
+	}
+}
+
+// RewriteHosts returns a URLRewriter that replaces the host of URLs found in
+// hosts with the mapped host, leaving other URLs unchanged. Hosts include the
+// port if one is present, e.g. "datapool.asf.alaska.edu" or "cache.local:8080".
+func RewriteHosts(hosts map[string]string) URLRewriter {
+	return func(rawURL string) (string, error) {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return "", err
+		}
+		if host, ok := hosts[u.Host]; ok {
+			u.Host = host
+		}
+		return u.String(), nil
+	}
+}
+
+// WithDownloadHeaders adds static headers, such as a per-worker User-Agent or
+// a Referer required by a mirror, to download requests only. The default HTTP
+// client re-applies them when a download is redirected.
+func WithDownloadHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.downloadHeaders = make(http.Header, len(headers))
+		for key, value := range headers {
+			if value != "" {
+				c.downloadHeaders.Set(key, value)
+			}
+		}
+	}
+}
+
+// downloadHeadersKey marks download request contexts with the headers to
+// re-apply on redirects.
+type downloadHeadersKey struct{}
+
+// newFileRequest creates a GET request for a product file, signed for S3 when
+// downloadURL is an s3:// URL.
+func (c *Client) newFileRequest(ctx context.Context, downloadURL string) (*http.Request, error) {
+	if strings.HasPrefix(downloadURL, "s3://") {
+		return c.s3ObjectRequest(ctx, downloadURL)
+	}
+	return c.newDownloadRequest(ctx, downloadURL)
+}
+
+// newDownloadRequest creates a GET request for a download URL carrying the
+// configured download headers, and records them in its context for the
+// redirect path.
+func (c *Client) newDownloadRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	if len(c.downloadHeaders) > 0 {
+		ctx = context.WithValue(ctx, downloadHeadersKey{}, c.downloadHeaders)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.downloadHeaders {
+		req.Header[key] = values
+	}
+	return req, nil
+}
+
+// WithInvalidFileRetries enables a validation pass after each download batch
+// that detects zero-byte files and HTML pages saved in place of products, and
+// re-downloads them up to retries times. Files still invalid afterwards make
+// Download fail with ErrInvalidDownload.
+func WithInvalidFileRetries(retries int) Option {
+	return func(c *Client) {
+		c.invalidFileRetries = retries
+	}
+}
+
+// invalidDownloads returns the indices of the results whose downloaded file
+// is empty or looks like an HTML document. Skipped products, which have no
+// Path, are never invalid.
+func invalidDownloads(results []DownloadResult) []int {
+	var invalid []int
+	for i, result := range results {
+		if result.Path != "" && !validDownload(result.Path) {
+			invalid = append(invalid, i)
+		}
+	}
+	return invalid
+}
+
+// validDownload reports whether the file at path is non-empty and not HTML.
+func validDownload(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	preview := make([]byte, 512)
+	n, _ := io.ReadFull(file, preview)
+	return n > 0 && !looksLikeHTML(preview[:n])
+}
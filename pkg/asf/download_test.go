@@ -0,0 +1,566 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownloadRequeuesInvalidFiles(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// First attempt silently delivers an empty file.
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	client := NewClient(WithInvalidFileRetries(2))
+	if err := client.Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected one re-download, got %d requests", got)
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+	if err != nil || string(content) != "payload" {
+		t.Fatalf("unexpected file content %q (err %v)", content, err)
+	}
+}
+
+func TestDownloadInvalidFilesExhaustRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	err := NewClient(WithInvalidFileRetries(2)).Download(context.Background(), t.TempDir(), product)
+	if !errors.Is(err, ErrInvalidDownload) {
+		t.Fatalf("expected ErrInvalidDownload, got: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected initial attempt plus 2 re-downloads, got %d requests", got)
+	}
+}
+
+func TestDownloadWithoutValidationKeepsEmptyFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	if err := NewClient().Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("validation should be opt-in, got: %v", err)
+	}
+}
+
+func TestDownloadResumesPartialFile(t *testing.T) {
+	const payload = "0123456789abcdef"
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "f.zip", time.Time{}, strings.NewReader(payload))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(targetDir, "f.zip.part"), []byte(payload[:6]), 0644); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	client := NewClient()
+	if err := client.Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=6-" {
+		t.Fatalf("expected a single ranged request from byte 6, got %q", ranges)
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+	if err != nil || string(content) != payload {
+		t.Fatalf("unexpected file content %q (err %v)", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "f.zip.part")); !os.IsNotExist(err) {
+		t.Fatalf("expected partial file to be renamed, got %v", err)
+	}
+	if got := client.Stats().BytesDownloaded; got != int64(len(payload)-6) {
+		t.Fatalf("expected only the remaining %d bytes to be fetched, got %d", len(payload)-6, got)
+	}
+}
+
+func TestDownloadDiscardsStalePartialFile(t *testing.T) {
+	const payload = "0123456789abcdef"
+	for name, handler := range map[string]http.HandlerFunc{
+		// A partial file longer than the product gets 416 with another total.
+		"LongerThanProduct": func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "f.zip", time.Time{}, strings.NewReader(payload))
+		},
+		"MismatchedRange": func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(payload)-1, len(payload)))
+				w.WriteHeader(http.StatusPartialContent)
+			}
+			w.Write([]byte(payload))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var ranges []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				handler(w, r)
+			}))
+			defer server.Close()
+
+			targetDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(targetDir, "f.zip.part"), []byte(payload+"stale"), 0644); err != nil {
+				t.Fatalf("write partial file: %v", err)
+			}
+			product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+			if err := NewClient().Download(context.Background(), targetDir, product); err != nil {
+				t.Fatalf("Download returned error: %v", err)
+			}
+			if len(ranges) != 2 || ranges[1] != "" {
+				t.Fatalf("expected a resume attempt and then a full request, got %q", ranges)
+			}
+			content, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+			if err != nil || string(content) != payload {
+				t.Fatalf("unexpected file content %q (err %v)", content, err)
+			}
+		})
+	}
+}
+
+func TestDownloadRestartsWhenRangeIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("complete"))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(targetDir, "f.zip.part"), []byte("stale"), 0644); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	if err := NewClient().Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+	if err != nil || string(content) != "complete" {
+		t.Fatalf("unexpected file content %q (err %v)", content, err)
+	}
+}
+
+func TestDownloadCompletesFullPartialFile(t *testing.T) {
+	const payload = "0123456789"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "f.zip", time.Time{}, strings.NewReader(payload))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(targetDir, "f.zip.part"), []byte(payload), 0644); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	if err := NewClient().Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+	if err != nil || string(content) != payload {
+		t.Fatalf("unexpected file content %q (err %v)", content, err)
+	}
+}
+
+func TestDownloadChecksumVerification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("payload"))
+	}))
+	defer server.Close()
+
+	// md5("payload")
+	const sum = "321c3cf486ed509164edec1e1981fec8"
+	client := NewClient(WithChecksumVerification())
+
+	t.Run("Match", func(t *testing.T) {
+		targetDir := t.TempDir()
+		product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL, Md5sum: sum}}
+		if err := client.Download(context.Background(), targetDir, product); err != nil {
+			t.Fatalf("Download returned error: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(targetDir, "f.zip")); err != nil {
+			t.Fatalf("expected verified file to be kept: %v", err)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		targetDir := t.TempDir()
+		product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL, Md5sum: "00000000000000000000000000000000"}}
+		err := client.Download(context.Background(), targetDir, product)
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+		}
+		for _, name := range []string{"f.zip", "f.zip.part"} {
+			if _, err := os.Stat(filepath.Join(targetDir, name)); !os.IsNotExist(err) {
+				t.Fatalf("expected %s to be removed, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestDownloadHeaders(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Referer"); got != "https://portal.example.edu" {
+			t.Errorf("expected configured Referer after redirect, got %q", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "worker-7" {
+			t.Errorf("expected configured User-Agent after redirect, got %q", got)
+		}
+		w.Write([]byte("payload"))
+	}))
+	defer target.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/search/param" {
+			if got := r.Header.Get("Referer"); got != "" {
+				t.Errorf("expected no download headers on search, got Referer %q", got)
+			}
+			w.Write([]byte(`{"features":[]}`))
+			return
+		}
+		if got := r.Header.Get("User-Agent"); got != "worker-7" {
+			t.Errorf("expected configured User-Agent, got %q", got)
+		}
+		http.Redirect(w, r, target.URL+"/f.zip", http.StatusFound)
+	}))
+	defer origin.Close()
+
+	client := NewClient(WithBaseURL(origin.URL), WithDownloadHeaders(map[string]string{
+		"Referer":    "https://portal.example.edu",
+		"User-Agent": "worker-7",
+	}))
+	if _, err := client.Search(context.Background(), SearchOptions{}); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: origin.URL + "/f.zip"}}
+	if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+}
+
+func TestDownloadURLs(t *testing.T) {
+	var concurrent, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := concurrent.Add(1)
+		defer concurrent.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	client := NewClient(WithDownloadConcurrency(1))
+	urls := []string{server.URL + "/a/one.zip", server.URL + "/b/two.zip", server.URL + "/three.zip"}
+	if err := client.DownloadURLs(context.Background(), targetDir, urls...); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	for name, want := range map[string]string{"one.zip": "/a/one.zip", "two.zip": "/b/two.zip", "three.zip": "/three.zip"} {
+		content, err := os.ReadFile(filepath.Join(targetDir, name))
+		if err != nil || string(content) != want {
+			t.Fatalf("unexpected content of %s: %q (err %v)", name, content, err)
+		}
+	}
+	if got := peak.Load(); got != 1 {
+		t.Fatalf("expected at most 1 concurrent download, got %d", got)
+	}
+}
+
+func TestDownloadURLsContentDisposition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Like many presigned URLs, these only accept GET.
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Query().Get("id") {
+		case "1":
+			w.Header().Set("Content-Disposition", `attachment; filename="granule.zip"`)
+		case "2":
+			w.Header().Set("Content-Disposition", `attachment; filename="../../escape.zip"`)
+		}
+		w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	urls := []string{server.URL + "/download?id=1", server.URL + "/get/file?id=2"}
+	if err := NewClient().DownloadURLs(context.Background(), targetDir, urls...); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	for name, want := range map[string]string{"granule.zip": "id=1", "escape.zip": "id=2"} {
+		content, err := os.ReadFile(filepath.Join(targetDir, name))
+		if err != nil || string(content) != want {
+			t.Fatalf("unexpected content of %s: %q (err %v)", name, content, err)
+		}
+	}
+	if entries, _ := os.ReadDir(targetDir); len(entries) != 2 {
+		t.Fatalf("expected only the two named files, got %d entries", len(entries))
+	}
+}
+
+func TestDownloadURLsValidatesServerNamedFiles(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Disposition", `attachment; filename="granule.zip"`)
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	client := NewClient(WithInvalidFileRetries(2))
+	if err := client.DownloadURLs(context.Background(), targetDir, server.URL+"/download?id=1"); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected the valid file to be fetched once, got %d requests", got)
+	}
+	if content, err := os.ReadFile(filepath.Join(targetDir, "granule.zip")); err != nil || string(content) != "data" {
+		t.Fatalf("unexpected content %q (err %v)", content, err)
+	}
+}
+
+func TestSafeFileName(t *testing.T) {
+	for name, want := range map[string]string{
+		"scene.zip":       "scene.zip",
+		"a/b/scene.zip":   "scene.zip",
+		`..\..\scene.zip`: "scene.zip",
+		"..":              "",
+		".":               "",
+		"":                "",
+		"dir/":            "",
+		"bad\nname.zip":   "",
+	} {
+		if got := safeFileName(name); got != want {
+			t.Errorf("safeFileName(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if got := fileNameFromURL("https://example.com/a/..%2F..%2Fetc%2Fpasswd"); got != "passwd" {
+		t.Errorf("fileNameFromURL returned %q, want %q", got, "passwd")
+	}
+	product := Product{Properties: Properties{FileName: "../escape.zip", URL: "https://example.com/x.zip"}}
+	if _, err := NewClient().destPath(t.TempDir(), product); err == nil {
+		t.Errorf("expected destPath to reject a traversing file name")
+	}
+}
+
+func TestDownloadPreserveTimestamps(t *testing.T) {
+	modTime := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dated.zip" {
+			w.Header().Set("Last-Modified", modTime.Format(http.TimeFormat))
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	client := NewClient(WithPreserveTimestamps())
+	if err := client.DownloadURLs(context.Background(), targetDir, server.URL+"/dated.zip", server.URL+"/undated.zip"); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(targetDir, "dated.zip"))
+	if err != nil {
+		t.Fatalf("stat downloaded file: %v", err)
+	}
+	if !info.ModTime().Equal(modTime) {
+		t.Fatalf("expected modification time %v, got %v", modTime, info.ModTime())
+	}
+	info, err = os.Stat(filepath.Join(targetDir, "undated.zip"))
+	if err != nil {
+		t.Fatalf("stat downloaded file: %v", err)
+	}
+	if time.Since(info.ModTime()) > time.Hour {
+		t.Fatalf("expected a file without Last-Modified to keep its write time, got %v", info.ModTime())
+	}
+}
+
+func TestDownloadNoResume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Range"); got != "" {
+			t.Errorf("expected no Range header, got %q", got)
+		}
+		w.Write([]byte("fresh"))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(targetDir, "f.zip.part"), []byte("stale"), 0644); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	if err := NewClient(WithNoResume()).Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+	if err != nil || string(content) != "fresh" {
+		t.Fatalf("unexpected file content %q (err %v)", content, err)
+	}
+}
+
+func TestDownloadURLRewriter(t *testing.T) {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("from mirror"))
+	}))
+	defer mirror.Close()
+
+	mirrorURL, _ := url.Parse(mirror.URL)
+	client := NewClient(WithURLRewriter(RewriteHosts(map[string]string{
+		"datapool.asf.alaska.edu": mirrorURL.Host,
+	})))
+
+	targetDir := t.TempDir()
+	product := Product{Properties: Properties{
+		SceneName: "s",
+		FileName:  "f.zip",
+		URL:       "http://datapool.asf.alaska.edu/SLC/SA/f.zip",
+	}}
+	if err := client.Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+	if err != nil || string(content) != "from mirror" {
+		t.Fatalf("unexpected file content %q (err %v)", content, err)
+	}
+}
+
+func TestDownloadRetriesResumeTruncatedTransfer(t *testing.T) {
+	payload := []byte("0123456789")
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			// Promise the whole file but drop the connection halfway.
+			w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+			w.Write(payload[:4])
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 4-9/%d", len(payload)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(payload[4:])
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	product := Product{Properties: Properties{FileName: "big.zip", URL: server.URL}}
+	if err := NewClient().Download(context.Background(), dir, product); err == nil {
+		t.Fatalf("expected the truncated transfer to fail without download retries")
+	}
+
+	ranges = nil
+	client := NewClient(WithDownloadRetries(2, time.Millisecond))
+	if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if len(ranges) != 2 || ranges[1] != "bytes=4-" {
+		t.Fatalf("expected a resumed retry, got ranges %q", ranges)
+	}
+}
+
+func TestDownloadRetriesUseRetryPolicyBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	client := NewClient(
+		WithDownloadRetries(1, time.Millisecond),
+		WithRetryPolicy(RetryPolicy{
+			MaxDelay: 20 * time.Millisecond,
+			OnRetry:  func(attempt int, delay time.Duration, cause error) { delays = append(delays, delay) },
+		}),
+	)
+	product := Product{Properties: Properties{FileName: "f.zip", URL: server.URL}}
+	if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if len(delays) != 1 || delays[0] != 20*time.Millisecond {
+		t.Fatalf("expected one retry waiting Retry-After capped at MaxDelay, got %v", delays)
+	}
+}
+
+func TestDownloadWithTempDir(t *testing.T) {
+	payload := []byte("0123456789")
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+			w.Write(payload[:4])
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 4-9/%d", len(payload)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(payload[4:])
+	}))
+	defer server.Close()
+
+	dir, tmp := t.TempDir(), filepath.Join(t.TempDir(), "scratch")
+	client := NewClient(WithTempDir(tmp))
+	product := Product{Properties: Properties{FileName: "big.zip", URL: server.URL}}
+	if err := client.Download(context.Background(), dir, product); err == nil {
+		t.Fatalf("expected the truncated transfer to fail")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("expected nothing in the target folder after a failed download, got %v", entries)
+	}
+	if _, err := os.Stat(client.partPath(filepath.Join(dir, "big.zip"))); err != nil {
+		t.Fatalf("expected the partial file in the temporary folder: %v", err)
+	}
+
+	if err := client.Download(context.Background(), dir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "big.zip")); err != nil || string(data) != string(payload) {
+		t.Fatalf("unexpected file contents %q, %v", data, err)
+	}
+	if ranges[1] != "bytes=4-" {
+		t.Fatalf("expected the second download to resume, got ranges %q", ranges)
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Fatalf("expected the temporary folder to be empty, got %v", entries)
+	}
+}
@@ -0,0 +1,32 @@
+package asf
+
+import "errors"
+
+// ErrAuthRedirect reports that a download returned an HTML page instead of
+// the product file. This almost always means the request was redirected to
+// the Earthdata login page because credentials are missing or invalid.
+var ErrAuthRedirect = errors.New("asf: download returned an HTML page; check your credentials")
+
+// ErrAuthFailed reports that the download server rejected the configured
+// credentials with 401 Unauthorized or 403 Forbidden.
+var ErrAuthFailed = errors.New("asf: authentication failed; check your credentials")
+
+// ErrInvalidDownload reports that a downloaded file is empty or an HTML page
+// even after the configured re-download attempts.
+var ErrInvalidDownload = errors.New("asf: downloaded file is empty or HTML")
+
+// ErrSkipDownload can be returned by a DestResolver to skip downloading a
+// file without failing the batch.
+var ErrSkipDownload = errors.New("asf: skip download")
+
+// ErrChecksumMismatch reports that a downloaded file does not match the MD5
+// checksum published for its product.
+var ErrChecksumMismatch = errors.New("asf: checksum mismatch")
+
+// ErrCorruptArchive reports that a downloaded zip archive cannot be read or
+// that one of its entries fails its CRC-32 check.
+var ErrCorruptArchive = errors.New("asf: corrupt zip archive")
+
+// ErrBodyNotReplayable reports that a failed request could not be retried
+// because its body was too large to buffer and had no GetBody to recreate it.
+var ErrBodyNotReplayable = errors.New("asf: request body cannot be replayed for a retry")
@@ -0,0 +1,64 @@
+package asf
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// safeFileName reduces name to a plain file name that cannot leave the
+// directory it is joined to: only the last element of a slash- or
+// backslash-separated path is kept, and names that are empty, "." or ".."
+// or contain control characters are rejected with "".
+func safeFileName(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.ContainsFunc(name, func(r rune) bool { return r < 0x20 || r == 0x7f }) {
+		return ""
+	}
+	return name
+}
+
+// contentDispositionName returns the sanitized file name suggested by a
+// Content-Disposition header, or "" if it suggests none.
+func contentDispositionName(header string) string {
+	if header == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		return ""
+	}
+	// mime decodes the RFC 5987 filename* form into "filename".
+	return safeFileName(params["filename"])
+}
+
+// needsServerName reports whether a URL's own last path element is unlikely
+// to be the real file name, as for API endpoints like /download?id=42, so
+// the name the server suggests should be preferred.
+func needsServerName(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return true
+	}
+	return u.RawQuery != "" || path.Ext(fileNameFromURL(rawURL)) == ""
+}
+
+// serverNamesKey marks a download context whose endpoint-like URLs are
+// saved under the name suggested by their responses.
+type serverNamesKey struct{}
+
+// serverFileName returns the sanitized name suggested by the
+// Content-Disposition header of a download response when ctx asks for server
+// names and the product's URL needs one, or "" otherwise.
+func serverFileName(ctx context.Context, product Product, resp *http.Response) string {
+	if ctx.Value(serverNamesKey{}) == nil || !needsServerName(product.Properties.URL) {
+		return ""
+	}
+	return contentDispositionName(resp.Header.Get("Content-Disposition"))
+}
@@ -0,0 +1,179 @@
+package asf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// OutputFormat selects the wire format requested from the search API.
+type OutputFormat string
+
+const (
+	// OutputGeoJSON requests full GeoJSON features, including footprints.
+	OutputGeoJSON OutputFormat = "geojson"
+	// OutputJSONLite requests the lighter jsonlite format, which suits
+	// metadata-only harvests. Its footprint is decoded into Geometry, but
+	// jsonlite has no ProcessingDate, Md5sum or exact size, so those stay
+	// zero: checksum verification has nothing to check, downloads are not
+	// sized in advance, and processing-date cursors (pkg/asfsync,
+	// pkg/watch) cannot be used with it.
+	OutputJSONLite OutputFormat = "jsonlite"
+)
+
+// WithOutputFormat sets the default wire format for searches. Individual
+// searches may override it with SearchOptions.Output.
+func WithOutputFormat(format OutputFormat) Option {
+	return func(c *Client) {
+		c.outputFormat = format
+	}
+}
+
+// searchFormat resolves the wire format for a search.
+func (c *Client) searchFormat(opts SearchOptions) OutputFormat {
+	switch {
+	case opts.Output != "":
+		return opts.Output
+	case c.outputFormat != "":
+		return c.outputFormat
+	default:
+		return OutputGeoJSON
+	}
+}
+
+// decodeProducts decodes a search response body in the given format. With
+// omitGeometry, GeoJSON footprints are skipped by the decoder rather than
+// copied into each Product.
+func decodeProducts(r io.Reader, format OutputFormat, omitGeometry bool) ([]Product, error) {
+	switch {
+	case format == OutputGeoJSON && omitGeometry:
+		var payload struct {
+			Features []struct {
+				Properties Properties `json:"properties"`
+			} `json:"features"`
+		}
+		if err := json.NewDecoder(r).Decode(&payload); err != nil {
+			return nil, err
+		}
+		products := make([]Product, len(payload.Features))
+		for i, feature := range payload.Features {
+			products[i] = Product{Properties: feature.Properties}
+		}
+		return products, nil
+	case format == OutputGeoJSON:
+		var payload FeatureCollection
+		if err := json.NewDecoder(r).Decode(&payload); err != nil {
+			return nil, err
+		}
+		return payload.Features, nil
+	case format == OutputJSONLite:
+		var payload jsonLiteResponse
+		if err := json.NewDecoder(r).Decode(&payload); err != nil {
+			return nil, err
+		}
+		products := make([]Product, len(payload.Results))
+		for i, result := range payload.Results {
+			product, err := result.product()
+			if err != nil {
+				return nil, err
+			}
+			products[i] = product
+		}
+		return products, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+// jsonLiteResponse is the top-level jsonlite payload.
+type jsonLiteResponse struct {
+	Results []jsonLiteResult `json:"results"`
+}
+
+// jsonLiteResult is a single jsonlite result.
+type jsonLiteResult struct {
+	GranuleName     string    `json:"granuleName"`
+	FileName        string    `json:"fileName"`
+	ProductID       string    `json:"productID"`
+	URL             string    `json:"url"`
+	StartTime       time.Time `json:"startTime"`
+	StopTime        time.Time `json:"stopTime"`
+	Platform        string    `json:"platform"`
+	Instrument      string    `json:"instrument"`
+	BeamMode        string    `json:"beamMode"`
+	FlightDirection string    `json:"flightDirection"`
+	Path            int       `json:"path"`
+	Frame           int       `json:"frame"`
+	Orbit           int       `json:"orbit"`
+	Polarization    string    `json:"polarization"`
+	ProductType     string    `json:"productType"`
+	SizeMB          float64   `json:"sizeMB"`
+	CenterLat       float64   `json:"centerLat"`
+	CenterLon       float64   `json:"centerLon"`
+	GroupID         string    `json:"groupID"`
+	PgeVersion      string    `json:"pgeVersion"`
+	Browse          []string  `json:"browse"`
+	S3Urls          []string  `json:"s3Urls"`
+	WKT             string    `json:"wkt"`
+}
+
+// UnmarshalJSON accepts the timestamp layouts used across ASF endpoints.
+func (r *jsonLiteResult) UnmarshalJSON(data []byte) error {
+	type plain jsonLiteResult
+	var raw struct {
+		plain
+		StartTime string `json:"startTime"`
+		StopTime  string `json:"stopTime"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = jsonLiteResult(raw.plain)
+
+	if err := setFlexibleTime(&r.StartTime, "startTime", raw.StartTime); err != nil {
+		return err
+	}
+	return setFlexibleTime(&r.StopTime, "stopTime", raw.StopTime)
+}
+
+// product normalizes a jsonlite result into the common Product model. The
+// approximate sizeMB is not carried over, since Bytes must be exact.
+func (r jsonLiteResult) product() (Product, error) {
+	props := Properties{
+		CenterLat:       r.CenterLat,
+		CenterLon:       r.CenterLon,
+		StopTime:        r.StopTime,
+		FileID:          r.ProductID,
+		FlightDirection: r.FlightDirection,
+		PathNumber:      r.Path,
+		ProcessingLevel: r.ProductType,
+		URL:             r.URL,
+		StartTime:       r.StartTime,
+		SceneName:       r.GranuleName,
+		Platform:        r.Platform,
+		FrameNumber:     r.Frame,
+		Orbit:           r.Orbit,
+		Polarization:    r.Polarization,
+		Sensor:          r.Instrument,
+		GroupID:         r.GroupID,
+		PgeVersion:      r.PgeVersion,
+		FileName:        r.FileName,
+		BeamModeType:    r.BeamMode,
+		S3Urls:          r.S3Urls,
+	}
+	if len(r.Browse) > 0 {
+		props.Browse = r.Browse[0]
+	}
+	product := Product{Properties: props}
+	if r.WKT != "" {
+		geometry, err := ParseWKT(r.WKT)
+		if err != nil {
+			return Product{}, fmt.Errorf("asf: footprint of %q: %w", r.GranuleName, err)
+		}
+		if product.Geometry, err = geometry.geoJSON(); err != nil {
+			return Product{}, err
+		}
+	}
+	return product, nil
+}
@@ -0,0 +1,110 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSearchJSONLite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("output"); got != "jsonlite" {
+			t.Errorf("expected output=jsonlite, got %q", got)
+		}
+		w.Write([]byte(`{"results":[{
+			"granuleName": "S1A_SCENE",
+			"fileName": "S1A_SCENE.zip",
+			"productID": "S1A_SCENE-SLC",
+			"url": "https://example.com/S1A_SCENE.zip",
+			"startTime": "2024-05-01T10:00:00Z",
+			"stopTime": "2024-05-01T10:00:30Z",
+			"platform": "Sentinel-1A",
+			"beamMode": "IW",
+			"flightDirection": "ASCENDING",
+			"path": 35,
+			"frame": 160,
+			"productType": "SLC",
+			"sizeMB": 2,
+			"browse": ["https://example.com/browse.png"],
+			"wkt": "POLYGON((0 0,1 0,1 1,0 1,0 0))"
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithOutputFormat(OutputJSONLite))
+	products, err := client.Search(context.Background(), SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(products) != 1 {
+		t.Fatalf("expected 1 product, got %d", len(products))
+	}
+	props := products[0].Properties
+	if props.SceneName != "S1A_SCENE" || props.FileID != "S1A_SCENE-SLC" || props.ProcessingLevel != "SLC" {
+		t.Fatalf("unexpected identity fields: %+v", props)
+	}
+	if props.PathNumber != 35 || props.FrameNumber != 160 || props.BeamModeType != "IW" {
+		t.Fatalf("unexpected track fields: %+v", props)
+	}
+	if props.Bytes != 0 {
+		t.Fatalf("expected the approximate size not to be used as Bytes, got %d", props.Bytes)
+	}
+	geometry, err := products[0].ParseGeometry()
+	if err != nil {
+		t.Fatalf("ParseGeometry returned error: %v", err)
+	}
+	if geometry.Type != "Polygon" || geometry.Bounds() != [4]float64{0, 0, 1, 1} {
+		t.Fatalf("unexpected footprint %+v", geometry)
+	}
+	if !props.StartTime.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected start time: %s", props.StartTime)
+	}
+	if props.Browse != "https://example.com/browse.png" {
+		t.Fatalf("unexpected browse: %s", props.Browse)
+	}
+}
+
+func TestSearchOutputOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("output"); got != "geojson" {
+			t.Errorf("expected per-query override to geojson, got %q", got)
+		}
+		w.Write([]byte(`{"features":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithOutputFormat(OutputJSONLite))
+	if _, err := client.Search(context.Background(), SearchOptions{Output: OutputGeoJSON}); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+}
+
+func TestSearchOmitGeometry(t *testing.T) {
+	fixture, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	products, err := NewClient(WithBaseURL(server.URL)).Search(context.Background(), SearchOptions{OmitGeometry: true})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(products))
+	}
+	for _, p := range products {
+		if p.Geometry != nil {
+			t.Fatalf("expected no geometry, got %s", p.Geometry)
+		}
+		if p.Properties.SceneName == "" || p.Properties.ProcessingDate.IsZero() {
+			t.Fatalf("expected properties to be decoded, got %+v", p.Properties)
+		}
+	}
+}
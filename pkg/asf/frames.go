@@ -0,0 +1,71 @@
+package asf
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Frames returns the ASF and ESA frame numbers of the granule, from its
+// FRAME_NUMBER and CENTER_ESA_FRAME attributes, or zero for either one CMR
+// does not publish.
+func (g UMMGranule) Frames() (asfFrame, esaFrame int) {
+	asfFrame, _ = strconv.Atoi(strings.TrimSpace(g.Attribute("FRAME_NUMBER")))
+	esaFrame, _ = strconv.Atoi(strings.TrimSpace(g.Attribute("CENTER_ESA_FRAME")))
+	return asfFrame, esaFrame
+}
+
+// esaFramePlatforms are the platforms whose search results number frames
+// in ESA's scheme; Sentinel-1 results use ASF's.
+var esaFramePlatforms = []string{"ALOS", "ERS-1", "ERS-2", "JERS-1", "RADARSAT-1", "SEASAT 1"}
+
+// fillFramesFromResult sets whichever of ASFFrame and ESAFrame the
+// platform's FrameNumber follows, if it is not already set.
+func fillFramesFromResult(props *Properties) {
+	if props.FrameNumber == 0 {
+		return
+	}
+	switch {
+	case strings.HasPrefix(props.Platform, "Sentinel-1"):
+		if props.ASFFrame == 0 {
+			props.ASFFrame = props.FrameNumber
+		}
+	case slices.Contains(esaFramePlatforms, props.Platform):
+		if props.ESAFrame == 0 {
+			props.ESAFrame = props.FrameNumber
+		}
+	}
+}
+
+// ResolveFrames fills in Properties.ASFFrame and ESAFrame of each product.
+// Search results carry a single FrameNumber whose numbering scheme depends
+// on the platform, so it first sets the field that scheme matches, then
+// looks up the frames still missing in the product's CMR record, one request
+// per product. A product whose lookup fails keeps the frames it has and the
+// others are still resolved; the failures are returned joined.
+func (c *Client) ResolveFrames(ctx context.Context, products []Product) error {
+	var errs []error
+	for i := range products {
+		props := &products[i].Properties
+		fillFramesFromResult(props)
+		if props.ASFFrame != 0 && props.ESAFrame != 0 {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		umm, err := c.productUMM(ctx, products[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("asf: resolve frames of %q: %w", props.SceneName, err))
+			continue
+		}
+		asfFrame, esaFrame := umm.Frames()
+		props.ASFFrame = cmp.Or(asfFrame, props.ASFFrame)
+		props.ESAFrame = cmp.Or(esaFrame, props.ESAFrame)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,75 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolveFrames(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if got := r.URL.Query().Get("granule_ur"); got != "S1A_SCENE-SLC" {
+			t.Errorf("unexpected granule_ur %q", got)
+		}
+		w.Write([]byte(`{"hits":1,"items":[{"meta":{"concept-id":"G1-ASF"},"umm":{
+			"GranuleUR": "S1A_SCENE-SLC",
+			"AdditionalAttributes": [
+				{"Name": "FRAME_NUMBER", "Values": ["590"]},
+				{"Name": "CENTER_ESA_FRAME", "Values": ["1163"]}
+			]
+		}}]}`))
+	}))
+	defer server.Close()
+
+	products := []Product{
+		{Properties: Properties{SceneName: "S1A_SCENE", FileID: "S1A_SCENE-SLC", FrameNumber: 590}},
+		{Properties: Properties{SceneName: "S1A_KNOWN", ASFFrame: 1, ESAFrame: 2}},
+	}
+	if err := NewClient(WithCMRURL(server.URL)).ResolveFrames(context.Background(), products); err != nil {
+		t.Fatalf("ResolveFrames returned error: %v", err)
+	}
+	if got := products[0].Properties; got.ASFFrame != 590 || got.ESAFrame != 1163 {
+		t.Fatalf("unexpected frames asf=%d esa=%d", got.ASFFrame, got.ESAFrame)
+	}
+	if got := products[1].Properties; got.ASFFrame != 1 || got.ESAFrame != 2 {
+		t.Fatalf("expected resolved frames to be kept, got asf=%d esa=%d", got.ASFFrame, got.ESAFrame)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected 1 CMR request, got %d", got)
+	}
+}
+
+func TestResolveFramesUsesFrameNumberAndCollectsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("granule_ur") {
+		case "S1A_MISSING-SLC":
+			w.Write([]byte(`{"hits":0,"items":[]}`))
+		default:
+			w.Write([]byte(`{"hits":1,"items":[{"umm":{"AdditionalAttributes":[
+				{"Name": "FRAME_NUMBER", "Values": ["120"]},
+				{"Name": "CENTER_ESA_FRAME", "Values": ["2950"]}
+			]}}]}`))
+		}
+	}))
+	defer server.Close()
+
+	products := []Product{
+		{Properties: Properties{SceneName: "S1A_MISSING", FileID: "S1A_MISSING-SLC", Platform: "Sentinel-1A", FrameNumber: 590}},
+		{Properties: Properties{SceneName: "ERS_SCENE", FileID: "ERS_SCENE-L0", Platform: "ERS-2", FrameNumber: 2950}},
+	}
+	err := NewClient(WithCMRURL(server.URL)).ResolveFrames(context.Background(), products)
+	if !errors.Is(err, ErrGranuleNotFound) {
+		t.Fatalf("expected the missing granule to be reported, got %v", err)
+	}
+	if got := products[0].Properties; got.ASFFrame != 590 || got.ESAFrame != 0 {
+		t.Fatalf("expected the Sentinel-1 frame number as the ASF frame, got asf=%d esa=%d", got.ASFFrame, got.ESAFrame)
+	}
+	if got := products[1].Properties; got.ASFFrame != 120 || got.ESAFrame != 2950 {
+		t.Fatalf("expected the product after the failure to be resolved, got asf=%d esa=%d", got.ASFFrame, got.ESAFrame)
+	}
+}
@@ -0,0 +1,193 @@
+package asf
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Position is a [longitude, latitude] pair.
+type Position [2]float64
+
+// Ring is a closed linear ring of positions.
+type Ring []Position
+
+// Polygon is a list of rings; the first is the exterior and any others are
+// holes.
+type Polygon []Ring
+
+// Geometry is a decoded product footprint. ASF footprints are GeoJSON
+// Polygons or, for scenes crossing the antimeridian, MultiPolygons; both are
+// held as a list of polygons.
+type Geometry struct {
+	// Type is the GeoJSON type, "Polygon" or "MultiPolygon", or empty for a
+	// product without a footprint.
+	Type     string
+	Polygons []Polygon
+}
+
+// ParseGeometry decodes the product's GeoJSON footprint. A missing or null
+// geometry yields an empty Geometry.
+func (p Product) ParseGeometry() (Geometry, error) {
+	return ParseGeometry(p.Geometry)
+}
+
+// ParseGeometry decodes a GeoJSON Polygon or MultiPolygon.
+func ParseGeometry(raw json.RawMessage) (Geometry, error) {
+	var payload struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return Geometry{}, nil
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return Geometry{}, fmt.Errorf("asf: decode geometry: %w", err)
+	}
+
+	geometry := Geometry{Type: payload.Type}
+	switch payload.Type {
+	case "Polygon":
+		var polygon Polygon
+		if err := json.Unmarshal(payload.Coordinates, &polygon); err != nil {
+			return Geometry{}, fmt.Errorf("asf: decode polygon: %w", err)
+		}
+		geometry.Polygons = []Polygon{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(payload.Coordinates, &geometry.Polygons); err != nil {
+			return Geometry{}, fmt.Errorf("asf: decode multipolygon: %w", err)
+		}
+	default:
+		return Geometry{}, fmt.Errorf("asf: unsupported geometry type %q", payload.Type)
+	}
+	return geometry, nil
+}
+
+// IsEmpty reports whether the geometry has no positions.
+func (g Geometry) IsEmpty() bool {
+	for _, polygon := range g.Polygons {
+		for _, ring := range polygon {
+			if len(ring) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Bounds returns the bounding box of the geometry as [west, south, east,
+// north]. An empty geometry has all-zero bounds.
+func (g Geometry) Bounds() [4]float64 {
+	if g.IsEmpty() {
+		return [4]float64{}
+	}
+	b := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, polygon := range g.Polygons {
+		for _, ring := range polygon {
+			for _, p := range ring {
+				b[0], b[1] = min(b[0], p[0]), min(b[1], p[1])
+				b[2], b[3] = max(b[2], p[0]), max(b[3], p[1])
+			}
+		}
+	}
+	return b
+}
+
+// Centroid returns the area-weighted centroid of the geometry in planar
+// longitude/latitude coordinates, with holes subtracted. Degenerate
+// geometries fall back to the mean of their exterior positions.
+func (g Geometry) Centroid() (lon, lat float64) {
+	var area, cx, cy float64
+	var sumX, sumY float64
+	var n int
+	for _, polygon := range g.Polygons {
+		for i, ring := range polygon {
+			a, x, y := ringMoments(ring)
+			if a < 0 {
+				// Normalize the winding so that exteriors add and holes
+				// subtract whichever way the rings are wound.
+				a, x, y = -a, -x, -y
+			}
+			if i > 0 {
+				a, x, y = -a, -x, -y
+			} else {
+				for _, p := range ring {
+					sumX, sumY = sumX+p[0], sumY+p[1]
+					n++
+				}
+			}
+			area, cx, cy = area+a, cx+x, cy+y
+		}
+	}
+	if area == 0 {
+		if n == 0 {
+			return 0, 0
+		}
+		return sumX / float64(n), sumY / float64(n)
+	}
+	return cx / (6 * area), cy / (6 * area)
+}
+
+// ringMoments returns the signed area of a ring and the first moments used
+// by the shoelace centroid formula.
+func ringMoments(ring Ring) (area, x, y float64) {
+	for i := range ring {
+		p, q := ring[i], ring[(i+1)%len(ring)]
+		cross := p[0]*q[1] - q[0]*p[1]
+		area += cross
+		x += (p[0] + q[0]) * cross
+		y += (p[1] + q[1]) * cross
+	}
+	return area / 2, x, y
+}
+
+// geoJSON encodes the geometry as a GeoJSON Polygon or MultiPolygon, the
+// form of Product.Geometry. An empty geometry encodes as nil.
+func (g Geometry) geoJSON() (json.RawMessage, error) {
+	if g.IsEmpty() {
+		return nil, nil
+	}
+	payload := struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}{Type: "MultiPolygon", Coordinates: g.Polygons}
+	if len(g.Polygons) == 1 && g.Type != "MultiPolygon" {
+		payload.Type, payload.Coordinates = "Polygon", g.Polygons[0]
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("asf: encode geometry: %w", err)
+	}
+	return data, nil
+}
+
+// WKT renders the geometry as a WKT POLYGON or MULTIPOLYGON, suitable for
+// SearchOptions.IntersectsWith. An empty geometry renders as
+// "POLYGON EMPTY".
+func (g Geometry) WKT() string {
+	if g.IsEmpty() {
+		return "POLYGON EMPTY"
+	}
+	if len(g.Polygons) == 1 && g.Type != "MultiPolygon" {
+		return "POLYGON " + wktPolygon(g.Polygons[0])
+	}
+	parts := make([]string, len(g.Polygons))
+	for i, polygon := range g.Polygons {
+		parts[i] = wktPolygon(polygon)
+	}
+	return "MULTIPOLYGON (" + strings.Join(parts, ", ") + ")"
+}
+
+func wktPolygon(polygon Polygon) string {
+	rings := make([]string, len(polygon))
+	for i, ring := range polygon {
+		points := make([]string, len(ring))
+		for j, p := range ring {
+			points[j] = strconv.FormatFloat(p[0], 'f', -1, 64) + " " + strconv.FormatFloat(p[1], 'f', -1, 64)
+		}
+		rings[i] = "(" + strings.Join(points, ", ") + ")"
+	}
+	return "(" + strings.Join(rings, ", ") + ")"
+}
@@ -0,0 +1,71 @@
+package asf
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+)
+
+func TestParseGeometry(t *testing.T) {
+	data, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("failed to read asf_response.json: %v", err)
+	}
+	products, err := ReadProducts(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadProducts returned error: %v", err)
+	}
+
+	geometry, err := products[0].ParseGeometry()
+	if err != nil {
+		t.Fatalf("ParseGeometry returned error: %v", err)
+	}
+	if geometry.Type != "Polygon" || len(geometry.Polygons) != 1 || len(geometry.Polygons[0][0]) != 5 {
+		t.Fatalf("unexpected geometry: %+v", geometry)
+	}
+	if got, want := geometry.Bounds(), [4]float64{-127.428642, 49.01503, -123.430382, 51.085098}; got != want {
+		t.Fatalf("unexpected bounds: %v, want %v", got, want)
+	}
+	lon, lat := geometry.Centroid()
+	props := products[0].Properties
+	if math.Abs(lon-props.CenterLon) > 0.05 || math.Abs(lat-props.CenterLat) > 0.05 {
+		t.Fatalf("centroid (%f, %f) far from scene center (%f, %f)", lon, lat, props.CenterLon, props.CenterLat)
+	}
+	if wkt := geometry.WKT(); wkt[:30] != "POLYGON ((-126.904083 49.01503" {
+		t.Fatalf("unexpected WKT: %s", wkt)
+	}
+}
+
+func TestGeometryHelpers(t *testing.T) {
+	raw := json.RawMessage(`{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[4,0],[4,4],[0,4],[0,0]],[[1,1],[1,3],[3,3],[3,1],[1,1]]],
+		[[[10,0],[12,0],[12,2],[10,2],[10,0]]]
+	]}`)
+	geometry, err := ParseGeometry(raw)
+	if err != nil {
+		t.Fatalf("ParseGeometry returned error: %v", err)
+	}
+	if got := geometry.Bounds(); got != [4]float64{0, 0, 12, 4} {
+		t.Fatalf("unexpected bounds: %v", got)
+	}
+	// A 4x4 square with a 2x2 hole (area 12, centroid 2,2) and a 2x2 square
+	// (area 4, centroid 11,1).
+	lon, lat := geometry.Centroid()
+	if math.Abs(lon-4.25) > 1e-9 || math.Abs(lat-1.75) > 1e-9 {
+		t.Fatalf("unexpected centroid: (%f, %f)", lon, lat)
+	}
+	want := "MULTIPOLYGON (((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 1 3, 3 3, 3 1, 1 1)), ((10 0, 12 0, 12 2, 10 2, 10 0)))"
+	if got := geometry.WKT(); got != want {
+		t.Fatalf("unexpected WKT:\n got %s\nwant %s", got, want)
+	}
+
+	empty, err := ParseGeometry(json.RawMessage("null"))
+	if err != nil || !empty.IsEmpty() || empty.WKT() != "POLYGON EMPTY" {
+		t.Fatalf("unexpected empty geometry %+v: %v", empty, err)
+	}
+	if _, err := ParseGeometry(json.RawMessage(`{"type":"LineString","coordinates":[]}`)); err == nil {
+		t.Fatalf("expected error for unsupported geometry type")
+	}
+}
@@ -0,0 +1,44 @@
+package asf
+
+// grdRank orders GRD resolution classes from best to worst.
+var grdRank = map[ProcessingLevel]int{
+	ProcessingLevelGRDFD: 4,
+	ProcessingLevelGRDHD: 3,
+	ProcessingLevelGRDMD: 2,
+	ProcessingLevelGRDMS: 1,
+}
+
+// GRDRank returns the rank of a product's GRD resolution class, higher being
+// better: full (GRD_FD), high (GRD_HD), medium (GRD_MD) and then medium
+// resolution strip map (GRD_MS). Products that are not GRD rank zero.
+func GRDRank(p Product) int {
+	return grdRank[ProcessingLevel(p.Properties.ProcessingLevel)]
+}
+
+// BestGRD keeps, for each acquisition, only the GRD product with the best
+// available resolution class. Products are grouped by GroupID, falling back
+// to SceneName. Non-GRD products are dropped; the order of first appearance
+// is preserved.
+func BestGRD(products []Product) []Product {
+	best := make(map[string]int)
+	var result []Product
+	for _, product := range products {
+		rank := GRDRank(product)
+		if rank == 0 {
+			continue
+		}
+		key := product.Properties.GroupID
+		if key == "" {
+			key = product.Properties.SceneName
+		}
+		i, seen := best[key]
+		switch {
+		case !seen:
+			best[key] = len(result)
+			result = append(result, product)
+		case rank > GRDRank(result[i]):
+			result[i] = product
+		}
+	}
+	return result
+}
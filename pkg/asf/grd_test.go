@@ -0,0 +1,32 @@
+package asf
+
+import "testing"
+
+func TestBestGRD(t *testing.T) {
+	product := func(scene, group string, level ProcessingLevel) Product {
+		return Product{Properties: Properties{SceneName: scene, GroupID: group, ProcessingLevel: string(level)}}
+	}
+	products := []Product{
+		product("a-md", "A", ProcessingLevelGRDMD),
+		product("b-ms", "B", ProcessingLevelGRDMS),
+		product("a-hd", "A", ProcessingLevelGRDHD),
+		product("a-slc", "A", ProcessingLevelSLC),
+		product("b-fd", "B", ProcessingLevelGRDFD),
+		product("c-hd", "", ProcessingLevelGRDHD),
+	}
+
+	best := BestGRD(products)
+	var scenes []string
+	for _, p := range best {
+		scenes = append(scenes, p.Properties.SceneName)
+	}
+	want := []string{"a-hd", "b-fd", "c-hd"}
+	if len(scenes) != len(want) {
+		t.Fatalf("BestGRD = %v, want %v", scenes, want)
+	}
+	for i := range want {
+		if scenes[i] != want[i] {
+			t.Fatalf("BestGRD = %v, want %v", scenes, want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package asf
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithHedging enables hedged search requests. When a search has not returned
+// a response within delay, an identical request is sent and whichever answers
+// first is used; the slower request is cancelled. A zero delay disables
+// hedging, which is the default.
+func WithHedging(delay time.Duration) Option {
+	return func(c *Client) {
+		c.hedgeDelay = delay
+	}
+}
+
+// hedgeResult carries the outcome of a single hedged attempt.
+type hedgeResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+}
+
+// doSearch sends a search request, hedging it when the client is configured
+// to do so.
+func (c *Client) doSearch(req *http.Request) (*http.Response, error) {
+	if c.hedgeDelay <= 0 {
+		return c.do(req)
+	}
+	return c.doHedged(req, c.hedgeDelay)
+}
+
+// doHedged sends req and, if no response arrives within delay, a second copy
+// of it. The first response wins; the other attempt is cancelled and its
+// response, if any, is discarded.
+func (c *Client) doHedged(req *http.Request, delay time.Duration) (*http.Response, error) {
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	send := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := c.do(req.Clone(ctx))
+			results <- hedgeResult{attempt: attempt, resp: resp, err: err}
+		}()
+	}
+
+	send()
+	hedge := c.after(delay)
+
+	pending := 1
+	for {
+		select {
+		case <-hedge:
+			if len(cancels) == 1 {
+				send()
+				pending++
+			}
+		case r := <-results:
+			pending--
+			if r.err != nil && pending > 0 {
+				// The other attempt may still succeed.
+				continue
+			}
+			for i, cancel := range cancels {
+				if i != r.attempt {
+					cancel()
+				}
+			}
+			go discardHedgeResults(results, pending)
+			if r.err != nil {
+				cancels[r.attempt]()
+				return nil, r.err
+			}
+			r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: cancels[r.attempt]}
+			return r.resp, nil
+		}
+	}
+}
+
+// discardHedgeResults closes the bodies of losing attempts as they finish.
+func discardHedgeResults(results <-chan hedgeResult, pending int) {
+	for ; pending > 0; pending-- {
+		if r := <-results; r.resp != nil {
+			io.Copy(io.Discard, r.resp.Body)
+			r.resp.Body.Close()
+		}
+	}
+}
+
+// cancelOnClose releases a request context once its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,154 @@
+package asf
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// WithHostConcurrency caps parallel downloads separately for each host,
+// such as 8 for "datapool.asf.alaska.edu" and 32 for "amazonaws.com",
+// since endpoints throttle very differently and one overall limit
+// underuses S3. A key matches its host and all of that host's
+// subdomains, and the most specific key wins. Downloads from a host with a
+// cap are limited only by that cap; downloads from other hosts share the
+// overall limit of WithDownloadConcurrency. The cap applies to the host
+// first requested, before any redirects; an s3:// URL counts against the
+// host of its bucket endpoint. Each host's downloads are queued separately,
+// so products for a busy host never hold up those for others, and
+// WithAdaptiveConcurrency only tunes the downloads sharing the overall
+// limit. Segments of a segmented download count against the cap too.
+func WithHostConcurrency(limits map[string]int) Option {
+	return func(c *Client) {
+		c.hostLimits = &hostLimits{hosts: make(map[string]chan struct{}, len(limits))}
+		for host, n := range limits {
+			if n > 0 {
+				c.hostLimits.hosts[strings.ToLower(host)] = make(chan struct{}, n)
+			}
+		}
+	}
+}
+
+// hostLimits holds a semaphore per configured host, and one shared by all
+// other hosts, sized to the overall download limit by NewClient.
+type hostLimits struct {
+	hosts map[string]chan struct{}
+	other chan struct{}
+}
+
+// total returns the sum of the per-host caps.
+func (h *hostLimits) total() int {
+	if h == nil {
+		return 0
+	}
+	n := 0
+	for _, sem := range h.hosts {
+		n += cap(sem)
+	}
+	return n
+}
+
+// semaphore returns the semaphore of the most specific key matching host.
+func (h *hostLimits) semaphore(host string) chan struct{} {
+	for candidate := strings.ToLower(host); ; {
+		if sem, ok := h.hosts[candidate]; ok {
+			return sem
+		}
+		i := strings.IndexByte(candidate, '.')
+		if i < 0 {
+			return h.other
+		}
+		candidate = candidate[i+1:]
+	}
+}
+
+// acquireHost waits for a download slot for host and returns the function
+// releasing it.
+func (c *Client) acquireHost(ctx context.Context, host string) (func(), error) {
+	if c.hostLimits == nil {
+		return func() {}, nil
+	}
+	sem := c.hostLimits.semaphore(host)
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+// tryAcquireHost takes up to n download slots for host without waiting and
+// returns how many it took and the function releasing them. Without host
+// limits all n are granted.
+func (c *Client) tryAcquireHost(host string, n int) (int, func()) {
+	if c.hostLimits == nil || n <= 0 {
+		return max(n, 0), func() {}
+	}
+	sem := c.hostLimits.semaphore(host)
+	got := 0
+acquire:
+	for got < n {
+		select {
+		case sem <- struct{}{}:
+			got++
+		default:
+			break acquire
+		}
+	}
+	return got, func() {
+		for range got {
+			<-sem
+		}
+	}
+}
+
+// downloadHost returns the host product is requested from, before any
+// redirects, or "" if it cannot be determined; the download itself then
+// reports the problem.
+func (c *Client) downloadHost(ctx context.Context, product Product) string {
+	downloadURL := c.sourceURL(product)
+	if c.urlRewriter != nil {
+		rewritten, err := c.urlRewriter(downloadURL)
+		if err != nil {
+			return ""
+		}
+		downloadURL = rewritten
+	}
+	req, err := c.newFileRequest(ctx, downloadURL)
+	if err != nil {
+		return ""
+	}
+	return req.URL.Hostname()
+}
+
+// downloadPerHost calls run for the products at the indexes in sequence,
+// queued by host limit and each queue served by as many workers as its
+// limit allows, in sequence order. run is told to take an adaptive slot
+// for products of hosts without a cap of their own.
+func (c *Client) downloadPerHost(ctx context.Context, products []Product, sequence []int, run func(i int, adaptive bool)) {
+	queues := make(map[chan struct{}][]int)
+	for _, i := range sequence {
+		sem := c.hostLimits.semaphore(c.downloadHost(ctx, products[i]))
+		queues[sem] = append(queues[sem], i)
+	}
+
+	var wg sync.WaitGroup
+	for sem, queue := range queues {
+		adaptive := c.adaptive != nil && sem == c.hostLimits.other
+		next := make(chan int, len(queue))
+		for _, i := range queue {
+			next <- i
+		}
+		close(next)
+		for range min(cap(sem), len(queue)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range next {
+					run(i, adaptive)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
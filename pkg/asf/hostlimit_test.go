@@ -0,0 +1,152 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHostLimitsSemaphore(t *testing.T) {
+	c := NewClient(WithDownloadConcurrency(2), WithHostConcurrency(map[string]int{
+		"amazonaws.com":              32,
+		"s3.us-west-2.amazonaws.com": 16,
+		"datapool.asf.alaska.edu":    8,
+		"ignored.example.com":        0,
+	}))
+	for host, want := range map[string]int{
+		"bucket.s3.us-west-2.amazonaws.com": 16,
+		"bucket.s3.us-east-1.amazonaws.com": 32,
+		"DATAPOOL.asf.alaska.edu":           8,
+		"asf.alaska.edu":                    2,
+		"ignored.example.com":               2,
+	} {
+		if got := cap(c.hostLimits.semaphore(host)); got != want {
+			t.Errorf("semaphore(%q) has capacity %d, want %d", host, got, want)
+		}
+	}
+	if got := c.downloadWorkers(); got != 2+32+16+8 {
+		t.Errorf("downloadWorkers() = %d, want %d", got, 2+32+16+8)
+	}
+}
+
+func TestDownloadHostConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	concurrent, peak := map[string]int{}, map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		mu.Lock()
+		concurrent[host]++
+		peak[host] = max(peak[host], concurrent[host])
+		mu.Unlock()
+		time.Sleep(30 * time.Millisecond)
+		mu.Lock()
+		concurrent[host]--
+		mu.Unlock()
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	slow, fast := u.Host, "localhost:"+u.Port()
+	var urls []string
+	for i := range 6 {
+		urls = append(urls, fmt.Sprintf("http://%s/slow%d.zip", slow, i), fmt.Sprintf("http://%s/fast%d.zip", fast, i))
+	}
+	client := NewClient(WithDownloadConcurrency(1), WithHostConcurrency(map[string]int{"localhost": 3}))
+	if err := client.DownloadURLs(context.Background(), t.TempDir(), urls...); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	if got := peak[slow]; got != 1 {
+		t.Fatalf("expected hosts without a cap to share the overall limit of 1, got %d", got)
+	}
+	if got := peak[fast]; got < 2 || got > 3 {
+		t.Fatalf("expected up to 3 concurrent downloads from the capped host, got %d", got)
+	}
+}
+
+func TestDownloadCappedHostDoesNotHoldUpOthers(t *testing.T) {
+	var mu sync.Mutex
+	cappedDone, cappedDoneAtFirstOther := 0, -1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Host, "localhost") {
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			cappedDone++
+			mu.Unlock()
+		} else {
+			mu.Lock()
+			if cappedDoneAtFirstOther < 0 {
+				cappedDoneAtFirstOther = cappedDone
+			}
+			mu.Unlock()
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	var urls []string
+	for i := range 4 {
+		urls = append(urls, fmt.Sprintf("http://localhost:%s/capped%d.zip", u.Port(), i))
+	}
+	for i := range 4 {
+		urls = append(urls, fmt.Sprintf("http://%s/other%d.zip", u.Host, i))
+	}
+	client := NewClient(WithDownloadConcurrency(2), WithHostConcurrency(map[string]int{"localhost": 1}))
+	if err := client.DownloadURLs(context.Background(), t.TempDir(), urls...); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	if cappedDoneAtFirstOther != 0 {
+		t.Fatalf("expected other hosts to start before the capped host's first download finished, %d had", cappedDoneAtFirstOther)
+	}
+}
+
+func TestSegmentedDownloadRespectsHostCap(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var mu sync.Mutex
+	concurrent, peak, ranged := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		concurrent++
+		peak = max(peak, concurrent)
+		if r.Header.Get("Range") != "bytes=0-0" {
+			ranged++
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		concurrent--
+		mu.Unlock()
+		http.ServeContent(w, r, "file.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	client := NewClient(
+		WithSegmentedDownloads(8, 1),
+		WithHostConcurrency(map[string]int{u.Hostname(): 3}),
+	)
+	dir := t.TempDir()
+	if err := client.DownloadURLs(context.Background(), dir, server.URL+"/file.zip"); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "file.zip"))
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("unexpected file content (err %v)", err)
+	}
+	if peak > 3 {
+		t.Fatalf("expected at most 3 connections to the capped host, got %d", peak)
+	}
+	if ranged != 3 {
+		t.Fatalf("expected the file to be fetched in 3 segments, got %d", ranged)
+	}
+}
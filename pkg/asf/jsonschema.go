@@ -0,0 +1,86 @@
+package asf
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect ProductSchema declares.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ProductSchema returns a JSON Schema describing Product as it is encoded to
+// JSON, generated from the Go types so that it always lists every field the
+// library knows. Downstream code generators (database DDL, protobuf) can
+// consume it to stay in sync as fields are added. Nullable fields, such as
+// baselines and burst metadata, allow null; timestamps are strings in
+// date-time format.
+func ProductSchema() []byte {
+	return productSchema()
+}
+
+var productSchema = sync.OnceValue(func() []byte {
+	schema := typeSchema(reflect.TypeFor[Product]())
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = "Product"
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		panic(err) // the schema holds only strings, maps and slices
+	}
+	return data
+})
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// typeSchema returns the JSON Schema of values of type t.
+func typeSchema(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		// Only Product.Geometry is raw; it holds a GeoJSON geometry.
+		return map[string]any{"type": []string{"object", "null"}}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := typeSchema(t.Elem())
+		if kind, ok := schema["type"].(string); ok {
+			schema["type"] = []string{kind, "null"}
+		}
+		return schema
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": []string{"array", "null"}, "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = typeSchema(field.Type)
+		}
+		return map[string]any{"type": "object", "properties": properties}
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,41 @@
+package asf
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProductSchema(t *testing.T) {
+	var schema struct {
+		Schema     string `json:"$schema"`
+		Properties struct {
+			Properties struct {
+				Properties map[string]struct {
+					Type   any    `json:"type"`
+					Format string `json:"format"`
+				} `json:"properties"`
+			} `json:"properties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(ProductSchema(), &schema); err != nil {
+		t.Fatalf("ProductSchema is not valid JSON: %v", err)
+	}
+	if schema.Schema != jsonSchemaDraft {
+		t.Fatalf("unexpected $schema %q", schema.Schema)
+	}
+	fields := schema.Properties.Properties.Properties
+	for key := range knownPropertyKeys() {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("schema is missing property %q", key)
+		}
+	}
+	if f := fields["startTime"]; f.Type != "string" || f.Format != "date-time" {
+		t.Errorf("unexpected startTime schema %+v", f)
+	}
+	if f := fields["bytes"]; f.Type != "integer" {
+		t.Errorf("unexpected bytes schema %+v", f)
+	}
+	if f, ok := fields["temporalBaseline"].Type.([]any); !ok || len(f) != 2 || f[1] != "null" {
+		t.Errorf("expected temporalBaseline to be nullable, got %+v", fields["temporalBaseline"])
+	}
+}
@@ -0,0 +1,162 @@
+package asf
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FormatKML writes the footprints of products to w as a KML document, one
+// placemark per product named after its scene and spanning its acquisition
+// time, for checking an AOI in Google Earth. Products without a footprint,
+// such as those decoded from jsonlite, are placed at their center point.
+func FormatKML(w io.Writer, products []Product) error {
+	doc := kmlDocument{Name: "ASF search results"}
+	for _, p := range products {
+		placemark, err := newKMLPlacemark(p)
+		if err != nil {
+			return fmt.Errorf("asf: kml for %q: %w", p.Properties.SceneName, err)
+		}
+		doc.Placemarks = append(doc.Placemarks, placemark)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(kmlRoot{Namespace: "http://www.opengis.net/kml/2.2", Document: doc}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// FormatKMZ writes the KML document produced by FormatKML to w as a KMZ
+// archive.
+func FormatKMZ(w io.Writer, products []Product) error {
+	zw := zip.NewWriter(w)
+	entry, err := zw.Create("doc.kml")
+	if err != nil {
+		return err
+	}
+	if err := FormatKML(entry, products); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+type kmlRoot struct {
+	XMLName   xml.Name    `xml:"kml"`
+	Namespace string      `xml:"xmlns,attr"`
+	Document  kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name          string           `xml:"name"`
+	Description   string           `xml:"description,omitempty"`
+	TimeSpan      *kmlTimeSpan     `xml:"TimeSpan,omitempty"`
+	Point         *kmlPoint        `xml:"Point,omitempty"`
+	Polygon       *kmlPolygon      `xml:"Polygon,omitempty"`
+	MultiGeometry *kmlMultiPolygon `xml:"MultiGeometry,omitempty"`
+}
+
+type kmlTimeSpan struct {
+	Begin string `xml:"begin,omitempty"`
+	End   string `xml:"end,omitempty"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	Outer kmlRing   `xml:"outerBoundaryIs"`
+	Inner []kmlRing `xml:"innerBoundaryIs"`
+}
+
+type kmlRing struct {
+	Coordinates string `xml:"LinearRing>coordinates"`
+}
+
+type kmlMultiPolygon struct {
+	Polygons []kmlPolygon `xml:"Polygon"`
+}
+
+// newKMLPlacemark converts a product into a placemark.
+func newKMLPlacemark(p Product) (kmlPlacemark, error) {
+	props := p.Properties
+	placemark := kmlPlacemark{
+		Name:        props.SceneName,
+		Description: kmlDescription(props),
+	}
+	if !props.StartTime.IsZero() || !props.StopTime.IsZero() {
+		placemark.TimeSpan = &kmlTimeSpan{Begin: formatCSVTime(props.StartTime), End: formatCSVTime(props.StopTime)}
+	}
+
+	geometry, err := p.ParseGeometry()
+	if err != nil {
+		return kmlPlacemark{}, err
+	}
+	switch {
+	case geometry.IsEmpty():
+		placemark.Point = &kmlPoint{Coordinates: kmlCoordinates(Ring{{props.CenterLon, props.CenterLat}})}
+	case len(geometry.Polygons) == 1:
+		placemark.Polygon = newKMLPolygon(geometry.Polygons[0])
+	default:
+		placemark.MultiGeometry = &kmlMultiPolygon{}
+		for _, polygon := range geometry.Polygons {
+			placemark.MultiGeometry.Polygons = append(placemark.MultiGeometry.Polygons, *newKMLPolygon(polygon))
+		}
+	}
+	return placemark, nil
+}
+
+func newKMLPolygon(rings Polygon) *kmlPolygon {
+	polygon := &kmlPolygon{}
+	for i, ring := range rings {
+		if i == 0 {
+			polygon.Outer = kmlRing{Coordinates: kmlCoordinates(ring)}
+		} else {
+			polygon.Inner = append(polygon.Inner, kmlRing{Coordinates: kmlCoordinates(ring)})
+		}
+	}
+	return polygon
+}
+
+// kmlCoordinates renders lon,lat pairs as a KML coordinate tuple list.
+func kmlCoordinates(points Ring) string {
+	tuples := make([]string, len(points))
+	for i, p := range points {
+		tuples[i] = strconv.FormatFloat(p[0], 'f', -1, 64) + "," + strconv.FormatFloat(p[1], 'f', -1, 64)
+	}
+	return strings.Join(tuples, " ")
+}
+
+// kmlDescription summarizes the product shown in a placemark's balloon.
+func kmlDescription(props Properties) string {
+	var lines []string
+	for _, field := range []struct{ label, value string }{
+		{"Platform", props.Platform},
+		{"Beam mode", props.BeamModeType},
+		{"Flight direction", props.FlightDirection},
+		{"Path", strconv.Itoa(props.PathNumber)},
+		{"Frame", strconv.Itoa(props.FrameNumber)},
+		{"Start", formatCSVTime(props.StartTime)},
+		{"Stop", formatCSVTime(props.StopTime)},
+		{"URL", props.URL},
+	} {
+		if field.value != "" && field.value != "0" {
+			lines = append(lines, field.label+": "+field.value)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,72 @@
+package asf
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormatKML(t *testing.T) {
+	data, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("failed to read asf_response.json: %v", err)
+	}
+	products, err := ReadProducts(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadProducts returned error: %v", err)
+	}
+	products = append(products, Product{Properties: Properties{SceneName: "LITE", CenterLat: 10, CenterLon: 20}})
+
+	var buf bytes.Buffer
+	if err := FormatKML(&buf, products); err != nil {
+		t.Fatalf("FormatKML returned error: %v", err)
+	}
+	var doc kmlRoot
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("parse kml: %v\n%s", err, buf.String())
+	}
+	placemarks := doc.Document.Placemarks
+	if len(placemarks) != 3 {
+		t.Fatalf("expected 3 placemarks, got %d", len(placemarks))
+	}
+	first := placemarks[0]
+	if first.Name != "S1C_IW_SLC__1SDV_20251028T021014_20251028T021042_004756_00963D_8B2E" {
+		t.Fatalf("unexpected placemark name: %s", first.Name)
+	}
+	if first.TimeSpan == nil || first.TimeSpan.Begin != "2025-10-28T02:10:14Z" {
+		t.Fatalf("unexpected time span: %+v", first.TimeSpan)
+	}
+	if first.Polygon == nil || !strings.HasPrefix(first.Polygon.Outer.Coordinates, "-126.904083,49.01503 ") {
+		t.Fatalf("unexpected polygon: %+v", first.Polygon)
+	}
+	if lite := placemarks[2]; lite.Point == nil || lite.Point.Coordinates != "20,10" {
+		t.Fatalf("expected center point for product without geometry, got %+v", lite)
+	}
+}
+
+func TestFormatKMZ(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FormatKMZ(&buf, []Product{{Properties: Properties{SceneName: "S1"}}}); err != nil {
+		t.Fatalf("FormatKMZ returned error: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open kmz: %v", err)
+	}
+	if len(archive.File) != 1 || archive.File[0].Name != "doc.kml" {
+		t.Fatalf("unexpected kmz entries: %v", archive.File)
+	}
+	r, err := archive.File[0].Open()
+	if err != nil {
+		t.Fatalf("open doc.kml: %v", err)
+	}
+	defer r.Close()
+	kml, _ := io.ReadAll(r)
+	if !bytes.Contains(kml, []byte("<name>S1</name>")) {
+		t.Fatalf("unexpected doc.kml: %s", kml)
+	}
+}
@@ -0,0 +1,82 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned by downloads started after Shutdown, and wraps
+// the error of downloads that Shutdown had to abort.
+var ErrClientClosed = errors.New("asf: client is shut down")
+
+// lifecycle tracks in-flight downloads so they can be drained on shutdown.
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// Shutdown stops the client from accepting new downloads and waits for those
+// in flight to finish. If ctx expires first, the remaining downloads are
+// cancelled, Shutdown waits for them to unwind and returns ctx's error.
+// Searches are not affected. Shutdown may be called more than once.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.lifecycle.mu.Lock()
+	c.lifecycle.closed = true
+	c.lifecycle.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.lifecycle.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		c.lifecycle.stopOnce.Do(func() { close(c.stopChan()) })
+		<-done
+		return ctx.Err()
+	}
+}
+
+// stopChan returns the channel closed when Shutdown aborts downloads.
+func (c *Client) stopChan() chan struct{} {
+	c.lifecycle.mu.Lock()
+	defer c.lifecycle.mu.Unlock()
+	if c.lifecycle.stop == nil {
+		c.lifecycle.stop = make(chan struct{})
+	}
+	return c.lifecycle.stop
+}
+
+// beginDownload registers an in-flight download batch. The returned context
+// is cancelled with ErrClientClosed if Shutdown runs out of time; the
+// returned function must be called once the batch has finished.
+func (c *Client) beginDownload(ctx context.Context) (context.Context, func(), error) {
+	stop := c.stopChan()
+
+	c.lifecycle.mu.Lock()
+	defer c.lifecycle.mu.Unlock()
+	if c.lifecycle.closed {
+		return nil, nil, ErrClientClosed
+	}
+	c.lifecycle.inflight.Add(1)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	go func() {
+		select {
+		case <-stop:
+			cancel(ErrClientClosed)
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		cancel(nil)
+		c.lifecycle.inflight.Done()
+	}, nil
+}
@@ -0,0 +1,75 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestShutdownWaitsForInflightDownloads(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+
+	errc := make(chan error, 1)
+	go func() { errc <- client.Download(context.Background(), t.TempDir(), product) }()
+	<-started
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("in-flight download should complete, got: %v", err)
+	}
+	if err := client.Download(context.Background(), t.TempDir(), product); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("expected ErrClientClosed after shutdown, got: %v", err)
+	}
+	if _, err := client.Search(context.Background(), SearchOptions{}); errors.Is(err, ErrClientClosed) {
+		t.Fatalf("searches should not be affected by shutdown")
+	}
+}
+
+func TestShutdownDeadlineCancelsDownloads(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+
+	errc := make(chan error, 1)
+	go func() { errc <- client.Download(context.Background(), t.TempDir(), product) }()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error from Shutdown, got: %v", err)
+	}
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrClientClosed) {
+			t.Fatalf("expected aborted download to report ErrClientClosed, got: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("download was not cancelled by Shutdown")
+	}
+}
@@ -0,0 +1,132 @@
+package asf
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ReadMetalink parses a Metalink 3.0 or 4.0 bulk-download manifest, as
+// exported from Vertex, into downloadable products.
+func ReadMetalink(r io.Reader) ([]Product, error) {
+	var doc struct {
+		Files []metalinkFile `xml:"files>file"`
+		// Metalink 4.0 (RFC 5854) lists files directly below the root.
+		Files4 []metalinkFile `xml:"file"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("asf: decode metalink: %w", err)
+	}
+
+	files := append(doc.Files, doc.Files4...)
+	products := make([]Product, 0, len(files))
+	for _, f := range files {
+		p := Product{Properties: Properties{
+			FileName: f.Name,
+			Bytes:    f.Size,
+		}}
+		for _, u := range append(f.Resources, f.URLs...) {
+			if u = strings.TrimSpace(u); u != "" {
+				p.Properties.URL = u
+				break
+			}
+		}
+		for _, h := range f.Hashes {
+			if strings.EqualFold(h.Type, "md5") {
+				p.Properties.Md5sum = strings.TrimSpace(h.Value)
+			}
+		}
+		if p.Properties.FileName == "" {
+			p.Properties.FileName = fileNameFromURL(p.Properties.URL)
+		}
+		p.Properties.SceneName = sceneNameFromFile(p.Properties.FileName)
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+type metalinkFile struct {
+	Name      string   `xml:"name,attr"`
+	Size      int64    `xml:"size"`
+	Resources []string `xml:"resources>url"`
+	URLs      []string `xml:"url"`
+	Hashes    []struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"verification>hash"`
+}
+
+// ReadCSVManifest parses a CSV export from Vertex or the ASF API (or one
+// written by CSVSink) into downloadable products. Only the "URL" column is
+// required; granule name, size and checksum are used when present.
+func ReadCSVManifest(r io.Reader) ([]Product, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("asf: read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	urlCol, ok := columns["URL"]
+	if !ok {
+		return nil, fmt.Errorf("asf: csv manifest has no URL column")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var products []Product
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("asf: read csv manifest: %w", err)
+		}
+		if urlCol >= len(record) || strings.TrimSpace(record[urlCol]) == "" {
+			continue
+		}
+		props := Properties{
+			URL:       strings.TrimSpace(record[urlCol]),
+			SceneName: field(record, "Granule Name"),
+			Md5sum:    field(record, "MD5 Sum"),
+		}
+		props.FileName = fileNameFromURL(props.URL)
+		if props.SceneName == "" {
+			props.SceneName = sceneNameFromFile(props.FileName)
+		}
+		if mb, err := strconv.ParseFloat(field(record, "Size (MB)"), 64); err == nil {
+			props.Bytes = int64(mb * (1 << 20))
+		}
+		products = append(products, Product{Properties: props})
+	}
+	return products, nil
+}
+
+// fileNameFromURL returns the last path element of a download URL, or ""
+// if it has none that is safe to use as a file name.
+func fileNameFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return safeFileName(path.Base(u.Path))
+}
+
+// sceneNameFromFile strips the extension from a product file name.
+func sceneNameFromFile(name string) string {
+	return strings.TrimSuffix(name, path.Ext(name))
+}
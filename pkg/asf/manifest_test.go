@@ -0,0 +1,66 @@
+package asf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadMetalink(t *testing.T) {
+	const doc = `<?xml version="1.0"?>
+<metalink xmlns="http://www.metalinker.org/" version="3.0">
+  <publisher><name>Alaska Satellite Facility</name><url>http://www.asf.alaska.edu/</url></publisher>
+  <files>
+    <file name="S1A_IW_SLC__1SDV_A.zip">
+      <resources><url type="http">https://datapool.asf.alaska.edu/SLC/SA/S1A_IW_SLC__1SDV_A.zip</url></resources>
+      <verification><hash type="md5">abc123</hash></verification>
+      <size>4096</size>
+    </file>
+    <file name="S1A_IW_SLC__1SDV_B.zip">
+      <resources><url type="http">https://datapool.asf.alaska.edu/SLC/SA/S1A_IW_SLC__1SDV_B.zip</url></resources>
+    </file>
+  </files>
+</metalink>`
+
+	products, err := ReadMetalink(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ReadMetalink returned error: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(products))
+	}
+	props := products[0].Properties
+	if props.FileName != "S1A_IW_SLC__1SDV_A.zip" || props.SceneName != "S1A_IW_SLC__1SDV_A" {
+		t.Fatalf("unexpected names: %+v", props)
+	}
+	if props.URL != "https://datapool.asf.alaska.edu/SLC/SA/S1A_IW_SLC__1SDV_A.zip" {
+		t.Fatalf("unexpected url: %s", props.URL)
+	}
+	if props.Md5sum != "abc123" || props.Bytes != 4096 {
+		t.Fatalf("unexpected verification fields: %+v", props)
+	}
+}
+
+func TestReadCSVManifest(t *testing.T) {
+	const doc = `"Granule Name","Platform","URL","Size (MB)"
+"S1A_GRANULE","Sentinel-1A","https://datapool.asf.alaska.edu/GRD_HD/SA/S1A_GRANULE.zip","1.5"
+"S1B_GRANULE","Sentinel-1B","",""
+`
+	products, err := ReadCSVManifest(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ReadCSVManifest returned error: %v", err)
+	}
+	if len(products) != 1 {
+		t.Fatalf("expected rows without URL to be skipped, got %d products", len(products))
+	}
+	props := products[0].Properties
+	if props.SceneName != "S1A_GRANULE" || props.FileName != "S1A_GRANULE.zip" {
+		t.Fatalf("unexpected names: %+v", props)
+	}
+	if props.Bytes != int64(1.5*(1<<20)) {
+		t.Fatalf("unexpected bytes: %d", props.Bytes)
+	}
+
+	if _, err := ReadCSVManifest(strings.NewReader("a,b\n1,2\n")); err == nil {
+		t.Fatalf("expected error for manifest without URL column")
+	}
+}
@@ -0,0 +1,21 @@
+package asf
+
+import (
+	"context"
+	"net/url"
+)
+
+// Missions returns the campaign (mission) names known for a platform, as used
+// by airborne datasets such as UAVSAR and AIRSAR.
+func (c *Client) Missions(ctx context.Context, platform Platform) ([]string, error) {
+	q := url.Values{}
+	setQueryIfNonEmpty(q, "platform", platform)
+
+	var payload struct {
+		Result []string `json:"result"`
+	}
+	if err := c.getJSON(ctx, q, &payload, "services", "utils", "mission_list"); err != nil {
+		return nil, err
+	}
+	return payload.Result, nil
+}
@@ -0,0 +1,30 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestMissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/utils/mission_list" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("platform"); got != "UAVSAR" {
+			t.Errorf("expected platform UAVSAR, got %q", got)
+		}
+		w.Write([]byte(`{"result":["ABoVE","Gulf of Mexico"]}`))
+	}))
+	defer server.Close()
+
+	missions, err := NewClient(WithBaseURL(server.URL)).Missions(context.Background(), PlatformUAVSAR)
+	if err != nil {
+		t.Fatalf("Missions returned error: %v", err)
+	}
+	if !slices.Equal(missions, []string{"ABoVE", "Gulf of Mexico"}) {
+		t.Fatalf("unexpected missions: %v", missions)
+	}
+}
@@ -0,0 +1,229 @@
+package asf
+
+import (
+	"context"
+	"iter"
+)
+
+// defaultPageSize is the number of results requested per page by SearchAll
+// and SearchIter unless the client or search sets another.
+const defaultPageSize = 250
+
+// WithPageSize sets the default number of results requested per page by
+// SearchAll, SearchIter and Results. SearchOptions.PageSize overrides it.
+func WithPageSize(n int) Option {
+	return func(c *Client) {
+		c.pageSize = n
+	}
+}
+
+// SearchAll pages through the search API until the results are exhausted or
+// MaxResults products have been collected, requesting PageSize products per
+// page. Results are sorted by SortBy, if set, once all pages have arrived.
+func (c *Client) SearchAll(ctx context.Context, opts SearchOptions) ([]Product, error) {
+	var all []Product
+	for product, err := range c.SearchIter(ctx, opts) {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, product)
+	}
+	sortProducts(all, opts)
+	return all, nil
+}
+
+// SearchIter streams search results page by page, yielding each product as
+// its page arrives so that large result sets need not be held in memory.
+// Paging follows the same rules as SearchAll, and stopping at MaxResults while
+// more products remain is logged as a warning. If a request fails, the error
+// is yielded with a zero Product and iteration stops.
+func (c *Client) SearchIter(ctx context.Context, opts SearchOptions) iter.Seq2[Product, error] {
+	return func(yield func(Product, error) bool) {
+		it := c.Results(ctx, opts)
+		for it.Next() {
+			if !yield(it.Product(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(Product{}, err)
+		}
+	}
+}
+
+// ResultIterator pages through search results on demand. Call Next to
+// advance, then Product for the current result; once Next returns false, Err
+// reports why iteration stopped. It is not safe for concurrent use.
+type ResultIterator struct {
+	c        *Client
+	ctx      context.Context
+	opts     SearchOptions
+	pageSize int
+
+	page      int
+	buf       []Product
+	current   Product
+	count     int
+	exhausted bool
+	err       error
+
+	// total caches the count used by EstimatedRemaining; -1 until fetched.
+	total int
+}
+
+// Results returns an iterator over the products matching opts, following the
+// same paging rules as SearchIter.
+func (c *Client) Results(ctx context.Context, opts SearchOptions) *ResultIterator {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = c.pageSize
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return &ResultIterator{c: c, ctx: ctx, opts: opts, pageSize: pageSize, total: -1}
+}
+
+// PageSize returns the number of results per page. It starts as the requested
+// page size and is lowered to the server's limit once the first pages reveal
+// that the server caps it; caps below 50 are not detected.
+func (it *ResultIterator) PageSize() int {
+	return it.pageSize
+}
+
+// Next advances to the next product, fetching another page when needed. It
+// returns false when the results are exhausted, MaxResults is reached or a
+// request fails.
+func (it *ResultIterator) Next() bool {
+	if it.err != nil || (it.opts.MaxResults > 0 && it.count >= it.opts.MaxResults) {
+		return false
+	}
+	if !it.fill() {
+		return false
+	}
+	it.current, it.buf = it.buf[0], it.buf[1:]
+	it.count++
+	if limit := it.opts.MaxResults; limit > 0 && it.count >= limit && it.more() {
+		it.c.warnTruncated(it.count)
+	}
+	return true
+}
+
+// Peek returns the product the next call to Next would advance to, fetching
+// its page if needed, without advancing. It reports false where Next would.
+func (it *ResultIterator) Peek() (Product, bool) {
+	if it.err != nil || (it.opts.MaxResults > 0 && it.count >= it.opts.MaxResults) {
+		return Product{}, false
+	}
+	if !it.fill() {
+		return Product{}, false
+	}
+	return it.buf[0], true
+}
+
+// EstimatedRemaining estimates how many products Next has yet to return, for
+// progress reporting and batching. The first call asks the count endpoint
+// for the total; since the count ignores client-side filters, the estimate
+// may be high. Once the results are known to be exhausted it is exact.
+func (it *ResultIterator) EstimatedRemaining() (int, error) {
+	remaining := len(it.buf)
+	if !it.exhausted {
+		if it.total < 0 {
+			total, err := it.c.Count(it.ctx, it.opts)
+			if err != nil {
+				return 0, err
+			}
+			it.total = total
+		}
+		// Products before the buffered ones were either returned or filtered.
+		fetched := it.page * it.pageSize
+		remaining = max(remaining, len(it.buf)+it.total-fetched)
+	}
+	if limit := it.opts.MaxResults; limit > 0 {
+		remaining = min(remaining, limit-it.count)
+	}
+	return max(remaining, 0), nil
+}
+
+// Product returns the product Next advanced to.
+func (it *ResultIterator) Product() Product {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *ResultIterator) Err() error {
+	return it.err
+}
+
+// more reports whether products may remain after the current one, without
+// fetching.
+func (it *ResultIterator) more() bool {
+	return len(it.buf) > 0 || !it.exhausted
+}
+
+// fill fetches pages until the buffer holds a product or the results are
+// exhausted.
+func (it *ResultIterator) fill() bool {
+	for len(it.buf) == 0 {
+		if it.exhausted {
+			return false
+		}
+		products, err := it.fetch(it.page + 1)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page++
+		full := len(products) == it.pageSize
+		if !full && it.page == 1 && len(products) >= minCappedPageSize {
+			if full, err = it.learnPageSize(products); err != nil {
+				it.err = err
+				return false
+			}
+			products = append(products, it.buf...)
+			it.buf = nil
+		}
+		it.exhausted = !full
+		it.buf = filterProducts(products, it.opts)
+	}
+	return true
+}
+
+// minCappedPageSize is the smallest short first page taken as a possible
+// server cap on the page size. Shorter pages are assumed to hold all results,
+// sparing small searches the extra request.
+const minCappedPageSize = 50
+
+// learnPageSize checks whether a short first page means the results are
+// exhausted or that the server caps the page size below the one requested,
+// by fetching the second page at the size of the first. Asking for the
+// learned size makes the second page start right after the first whether
+// the server computes offsets from the requested size or from its cap. If
+// the server caps it, the page size is lowered to match and the second page
+// is left in the buffer. It reports whether the second page was full.
+func (it *ResultIterator) learnPageSize(first []Product) (bool, error) {
+	requested := it.pageSize
+	it.pageSize = len(first)
+	second, err := it.fetch(2)
+	if err != nil {
+		it.pageSize = requested
+		return false, err
+	}
+	// A server that ignores paging answers every page alike.
+	if len(second) == 0 || second[0].ID() == first[0].ID() {
+		it.pageSize = requested
+		return false, nil
+	}
+	it.c.log().Debug("asf: server caps page size", "requested", requested, "actual", len(first))
+	it.page = 2
+	it.buf = second
+	return len(second) == it.pageSize, nil
+}
+
+// fetch requests a page. The page size stays fixed so page offsets line up;
+// the final page is truncated locally instead.
+func (it *ResultIterator) fetch(page int) ([]Product, error) {
+	pageOpts := it.opts
+	pageOpts.MaxResults = it.pageSize
+	return it.c.searchPage(it.ctx, pageOpts, page)
+}
@@ -0,0 +1,220 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pagedServer serves total products in pages according to the page and
+// maxResults query parameters, recording each requested page.
+func pagedServer(t *testing.T, total int, pages *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("output") == "count" {
+			fmt.Fprint(w, total)
+			return
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("maxResults"))
+		*pages = append(*pages, q.Get("page")+"/"+q.Get("maxResults"))
+
+		var features []string
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			features = append(features, fmt.Sprintf(`{"properties":{"sceneName":"S%d"}}`, i))
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+}
+
+func TestSearchAllPagesUntilExhausted(t *testing.T) {
+	var pages []string
+	server := pagedServer(t, 5, &pages)
+	defer server.Close()
+
+	products, err := NewClient(WithBaseURL(server.URL)).SearchAll(context.Background(), SearchOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("SearchAll returned error: %v", err)
+	}
+	if len(products) != 5 || products[4].Properties.SceneName != "S4" {
+		t.Fatalf("expected all 5 products in order, got %d", len(products))
+	}
+	if want := "1/2 2/2 3/2"; strings.Join(pages, " ") != want {
+		t.Fatalf("unexpected pages requested: %v, want %s", pages, want)
+	}
+}
+
+func TestSearchAllHonorsMaxResults(t *testing.T) {
+	var pages []string
+	server := pagedServer(t, 100, &pages)
+	defer server.Close()
+
+	products, err := NewClient(WithBaseURL(server.URL)).SearchAll(context.Background(), SearchOptions{PageSize: 4, MaxResults: 6})
+	if err != nil {
+		t.Fatalf("SearchAll returned error: %v", err)
+	}
+	if len(products) != 6 {
+		t.Fatalf("expected 6 products, got %d", len(products))
+	}
+	if want := "1/4 2/4"; strings.Join(pages, " ") != want {
+		t.Fatalf("unexpected pages requested: %v, want %s", pages, want)
+	}
+}
+
+func TestSearchIterStopsFetchingWhenCallerBreaks(t *testing.T) {
+	var pages []string
+	server := pagedServer(t, 100, &pages)
+	defer server.Close()
+
+	var names []string
+	for product, err := range NewClient(WithBaseURL(server.URL)).SearchIter(context.Background(), SearchOptions{PageSize: 3}) {
+		if err != nil {
+			t.Fatalf("SearchIter yielded error: %v", err)
+		}
+		names = append(names, product.Properties.SceneName)
+		if len(names) == 4 {
+			break
+		}
+	}
+	if want := "S0 S1 S2 S3"; strings.Join(names, " ") != want {
+		t.Fatalf("unexpected products: %v, want %s", names, want)
+	}
+	if want := "1/3 2/3"; strings.Join(pages, " ") != want {
+		t.Fatalf("unexpected pages requested: %v, want %s", pages, want)
+	}
+}
+
+func TestSearchIterYieldsRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var errs int
+	for _, err := range NewClient(WithBaseURL(server.URL)).SearchIter(context.Background(), SearchOptions{}) {
+		if err == nil {
+			t.Fatalf("expected only an error to be yielded")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Fatalf("expected exactly one error, got %d", errs)
+	}
+}
+
+func TestResultIteratorLearnsServerPageCap(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		pages = append(pages, q.Get("page"))
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("maxResults"))
+		size = min(size, 60)
+		var features []string
+		for i := (page - 1) * size; i < page*size && i < 150; i++ {
+			features = append(features, fmt.Sprintf(`{"properties":{"sceneName":"S%d"}}`, i))
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+	defer server.Close()
+
+	it := NewClient(WithBaseURL(server.URL), WithPageSize(100)).Results(context.Background(), SearchOptions{})
+	if it.PageSize() != 100 {
+		t.Fatalf("PageSize before fetching = %d, want the configured 100", it.PageSize())
+	}
+	var n int
+	for it.Next() {
+		if want := fmt.Sprintf("S%d", n); it.Product().Properties.SceneName != want {
+			t.Fatalf("product %d = %s, want %s", n, it.Product().Properties.SceneName, want)
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+	if n != 150 || it.PageSize() != 60 {
+		t.Fatalf("got %d products with page size %d, want 150 with 60", n, it.PageSize())
+	}
+	if want := "1 2 3"; strings.Join(pages, " ") != want {
+		t.Fatalf("unexpected pages requested: %v, want %s", pages, want)
+	}
+}
+
+func TestResultIteratorLearnsPageCapOfRequestedSizeOffsets(t *testing.T) {
+	// This server caps pages at 60 but places page n at (n-1) times the
+	// requested size, so probing page 2 at the requested 100 would skip
+	// products 60 to 99.
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		pages = append(pages, q.Get("page")+"/"+q.Get("maxResults"))
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("maxResults"))
+		var features []string
+		for i := (page - 1) * size; i < (page-1)*size+min(size, 60) && i < 150; i++ {
+			features = append(features, fmt.Sprintf(`{"properties":{"sceneName":"S%d"}}`, i))
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+	defer server.Close()
+
+	it := NewClient(WithBaseURL(server.URL), WithPageSize(100)).Results(context.Background(), SearchOptions{})
+	var n int
+	for it.Next() {
+		if want := fmt.Sprintf("S%d", n); it.Product().Properties.SceneName != want {
+			t.Fatalf("product %d = %s, want %s", n, it.Product().Properties.SceneName, want)
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+	if n != 150 || it.PageSize() != 60 {
+		t.Fatalf("got %d products with page size %d, want 150 with 60", n, it.PageSize())
+	}
+	if want := "1/100 2/60 3/60"; strings.Join(pages, " ") != want {
+		t.Fatalf("unexpected pages requested: %v, want %s", pages, want)
+	}
+}
+
+func TestResultIteratorPeekAndEstimatedRemaining(t *testing.T) {
+	var pages []string
+	server := pagedServer(t, 5, &pages)
+	defer server.Close()
+
+	it := NewClient(WithBaseURL(server.URL)).Results(context.Background(), SearchOptions{PageSize: 2})
+	remaining, err := it.EstimatedRemaining()
+	if err != nil || remaining != 5 {
+		t.Fatalf("EstimatedRemaining before iterating = %d, %v; want 5", remaining, err)
+	}
+	if len(pages) != 0 {
+		t.Fatalf("expected no page to be fetched yet, got %v", pages)
+	}
+
+	peeked, ok := it.Peek()
+	if !ok || peeked.Properties.SceneName != "S0" {
+		t.Fatalf("Peek = %v, %v; want S0", peeked.Properties.SceneName, ok)
+	}
+	if !it.Next() || it.Product().Properties.SceneName != "S0" {
+		t.Fatalf("expected Next to return the peeked product")
+	}
+	if !it.Next() || !it.Next() {
+		t.Fatalf("expected more products")
+	}
+	if remaining, _ := it.EstimatedRemaining(); remaining != 2 {
+		t.Fatalf("EstimatedRemaining after 3 products = %d, want 2", remaining)
+	}
+	for it.Next() {
+	}
+	if _, ok := it.Peek(); ok {
+		t.Fatalf("expected Peek to report false once exhausted")
+	}
+	if remaining, _ := it.EstimatedRemaining(); remaining != 0 {
+		t.Fatalf("EstimatedRemaining when exhausted = %d, want 0", remaining)
+	}
+}
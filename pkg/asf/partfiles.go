@@ -0,0 +1,84 @@
+package asf
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// PartFile is a partial download left in a download directory.
+type PartFile struct {
+	Path string
+	// FileName is the name of the file being downloaded.
+	FileName string
+	// DestPath is where the finished file would have been saved, or empty
+	// for a part file in a WithTempDir directory, whose name only records
+	// a hash of the destination.
+	DestPath string
+	// Segmented is set for the ".segments.part" files of
+	// WithSegmentedDownloads, which are restarted rather than resumed.
+	Segmented bool
+	Size      int64
+	ModTime   time.Time
+}
+
+// StalePartFiles walks dir and returns the ".part" files last written before
+// cutoff, which are most likely orphaned by interrupted downloads that were
+// never retried. Passing the same products to Download resumes them instead.
+// Part files named by WithTempDir ("name.<hash>.part") and by
+// WithSegmentedDownloads ("name.segments.part") are recognized.
+func StalePartFiles(dir string, cutoff time.Time) ([]PartFile, error) {
+	var parts []PartFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".part") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(cutoff) {
+			part := PartFile{Path: path, Size: info.Size(), ModTime: info.ModTime()}
+			name := strings.TrimSuffix(d.Name(), ".part")
+			name, part.Segmented = strings.CutSuffix(name, ".segments")
+			if i := strings.LastIndexByte(name, '.'); i > 0 && tempPartHash.MatchString(name[i+1:]) {
+				part.FileName = name[:i]
+			} else {
+				part.FileName = name
+				part.DestPath = filepath.Join(filepath.Dir(path), name)
+			}
+			parts = append(parts, part)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("asf: scan %q for partial downloads: %w", dir, err)
+	}
+	return parts, nil
+}
+
+// tempPartHash matches the destination hash in the names of part files kept
+// in a WithTempDir directory.
+var tempPartHash = regexp.MustCompile(`^[0-9a-f]{12}$`)
+
+// RemoveStalePartFiles deletes the ".part" files below dir last written
+// before cutoff and returns those it removed.
+func RemoveStalePartFiles(dir string, cutoff time.Time) ([]PartFile, error) {
+	parts, err := StalePartFiles(dir, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	for i, part := range parts {
+		if err := os.Remove(part.Path); err != nil && !os.IsNotExist(err) {
+			return parts[:i], fmt.Errorf("asf: remove partial download: %w", err)
+		}
+	}
+	return parts, nil
+}
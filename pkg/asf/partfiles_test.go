@@ -0,0 +1,84 @@
+package asf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRemoveStalePartFiles(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-48 * time.Hour)
+	write := func(name string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("partial"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	stale := write("nested/old.zip.part", old)
+	fresh := write("new.zip.part", time.Now())
+	done := write("old.zip", old)
+
+	removed, err := RemoveStalePartFiles(dir, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("RemoveStalePartFiles returned error: %v", err)
+	}
+	if len(removed) != 1 || removed[0].Path != stale || removed[0].DestPath != filepath.Join(dir, "nested", "old.zip") || removed[0].Size != 7 {
+		t.Fatalf("unexpected removed files %+v", removed)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected stale part file to be removed, got %v", err)
+	}
+	for _, path := range []string{fresh, done} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("expected %s to be kept: %v", path, err)
+		}
+	}
+}
+
+func TestStalePartFileNames(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-48 * time.Hour)
+	client := NewClient(WithTempDir(dir))
+	for _, path := range []string{
+		filepath.Join(dir, "plain.zip.part"),
+		segmentsPath(filepath.Join(dir, "seg.zip.part")),
+		client.partPath(filepath.Join(t.TempDir(), "temp.zip")),
+		segmentsPath(client.partPath(filepath.Join(t.TempDir(), "tempseg.zip"))),
+	} {
+		if err := os.WriteFile(path, []byte("partial"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	parts, err := StalePartFiles(dir, time.Now())
+	if err != nil {
+		t.Fatalf("StalePartFiles returned error: %v", err)
+	}
+	got := make(map[string]PartFile)
+	for _, part := range parts {
+		got[part.FileName] = part
+	}
+	for name, want := range map[string]PartFile{
+		"plain.zip":   {DestPath: filepath.Join(dir, "plain.zip")},
+		"seg.zip":     {DestPath: filepath.Join(dir, "seg.zip"), Segmented: true},
+		"temp.zip":    {},
+		"tempseg.zip": {Segmented: true},
+	} {
+		part, ok := got[name]
+		if !ok || part.DestPath != want.DestPath || part.Segmented != want.Segmented {
+			t.Errorf("%s: got %+v (found %v), want DestPath %q and Segmented %v", name, part, ok, want.DestPath, want.Segmented)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package asf
+
+import (
+	"cmp"
+	"slices"
+)
+
+// DownloadOrder compares two products of a batch, returning a negative
+// number when a should be downloaded before b. It has the shape expected by
+// slices.SortFunc.
+type DownloadOrder func(a, b Product) int
+
+// WithDownloadOrder makes Download start products in the given order rather
+// than the order they were passed in, so that critical scenes arrive before
+// bulk backfill sharing the same worker pool. Products that compare equal
+// keep their relative order.
+func WithDownloadOrder(order DownloadOrder) Option {
+	return func(c *Client) {
+		c.downloadOrder = order
+	}
+}
+
+// SmallestFirst downloads small products first, getting the most files done
+// early.
+func SmallestFirst(a, b Product) int {
+	return cmp.Compare(a.Properties.Bytes, b.Properties.Bytes)
+}
+
+// NewestFirst downloads the most recently acquired products first.
+func NewestFirst(a, b Product) int {
+	return b.Properties.StartTime.Compare(a.Properties.StartTime)
+}
+
+// ByPriority downloads products with a higher priority first, for explicit
+// per-product priorities such as a lookup in a set of urgent granules.
+func ByPriority(priority func(Product) int) DownloadOrder {
+	return func(a, b Product) int {
+		return cmp.Compare(priority(b), priority(a))
+	}
+}
+
+// downloadSequence returns the indexes of products in the order they should
+// be started.
+func (c *Client) downloadSequence(products []Product) []int {
+	order := make([]int, len(products))
+	for i := range order {
+		order[i] = i
+	}
+	if c.downloadOrder != nil {
+		slices.SortStableFunc(order, func(i, j int) int {
+			return c.downloadOrder(products[i], products[j])
+		})
+	}
+	return order
+}
@@ -0,0 +1,58 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDownloadOrder(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched = append(fetched, strings.TrimPrefix(r.URL.Path, "/"))
+		mu.Unlock()
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	products := []Product{
+		{Properties: Properties{FileName: "old-big.zip", Bytes: 300, StartTime: day(1)}},
+		{Properties: Properties{FileName: "new-mid.zip", Bytes: 200, StartTime: day(3)}},
+		{Properties: Properties{FileName: "mid-small.zip", Bytes: 100, StartTime: day(2)}},
+	}
+	for i := range products {
+		products[i].Properties.URL = server.URL + "/" + products[i].Properties.FileName
+	}
+	urgent := func(p Product) int {
+		if p.Properties.FileName == "old-big.zip" {
+			return 1
+		}
+		return 0
+	}
+
+	for name, tc := range map[string]struct {
+		order DownloadOrder
+		want  string
+	}{
+		"none":     {nil, "old-big.zip new-mid.zip mid-small.zip"},
+		"smallest": {SmallestFirst, "mid-small.zip new-mid.zip old-big.zip"},
+		"newest":   {NewestFirst, "new-mid.zip mid-small.zip old-big.zip"},
+		"priority": {ByPriority(urgent), "old-big.zip new-mid.zip mid-small.zip"},
+	} {
+		fetched = nil
+		client := NewClient(WithDownloadConcurrency(1), WithDownloadOrder(tc.order))
+		if err := client.Download(context.Background(), t.TempDir(), products...); err != nil {
+			t.Fatalf("%s: Download returned error: %v", name, err)
+		}
+		if got := strings.Join(fetched, " "); got != tc.want {
+			t.Errorf("%s: fetched %s, want %s", name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProbeResult describes the outcome of probing a download URL.
+type ProbeResult struct {
+	URL string
+	// FinalURL is the URL that answered after following redirects.
+	FinalURL   string
+	StatusCode int
+	// Size is the file size in bytes, or -1 when the server did not say.
+	Size int64
+}
+
+// OK reports whether the URL resolved to a retrievable file.
+func (r ProbeResult) OK() bool {
+	return r.StatusCode == http.StatusOK || r.StatusCode == http.StatusPartialContent
+}
+
+// Probe checks a download URL without transferring the file. It sends a HEAD
+// request and, if the server rejects HEAD, a GET for the first byte only.
+// Redirects and authentication are handled as for downloads.
+func (c *Client) Probe(ctx context.Context, rawURL string) (ProbeResult, error) {
+	result, err := c.probe(ctx, http.MethodHead, rawURL)
+	if err != nil {
+		return result, err
+	}
+	if result.StatusCode == http.StatusMethodNotAllowed || result.StatusCode == http.StatusForbidden {
+		return c.probe(ctx, http.MethodGet, rawURL)
+	}
+	return result, nil
+}
+
+func (c *Client) probe(ctx context.Context, method, rawURL string) (ProbeResult, error) {
+	result := ProbeResult{URL: rawURL, Size: -1}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return result, fmt.Errorf("asf: create probe request for %q: %w", rawURL, err)
+	}
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return result, fmt.Errorf("asf: probe %q: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<10))
+
+	result.StatusCode = resp.StatusCode
+	result.FinalURL = resp.Request.URL.String()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		result.Size = resp.ContentLength
+	case http.StatusPartialContent:
+		result.Size = contentRangeTotal(resp.Header.Get("Content-Range"))
+	}
+	return result, nil
+}
+
+// contentRangeTotal extracts the complete length from a Content-Range header
+// such as "bytes 0-0/1234", returning -1 when it is absent or unknown.
+func contentRangeTotal(header string) int64 {
+	_, total, ok := strings.Cut(header, "/")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,57 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbe(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redirect.zip", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/file.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/file.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1234")
+	})
+	mux.HandleFunc("/nohead.zip", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if got := r.Header.Get("Range"); got != "bytes=0-0" {
+			t.Errorf("expected single byte range, got %q", got)
+		}
+		w.Header().Set("Content-Range", "bytes 0-0/5678")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("x"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := NewClient()
+
+	result, err := client.Probe(context.Background(), server.URL+"/redirect.zip")
+	if err != nil {
+		t.Fatalf("Probe returned error: %v", err)
+	}
+	if !result.OK() || result.Size != 1234 || result.FinalURL != server.URL+"/file.zip" {
+		t.Fatalf("unexpected redirect probe result: %+v", result)
+	}
+
+	result, err = client.Probe(context.Background(), server.URL+"/nohead.zip")
+	if err != nil {
+		t.Fatalf("Probe returned error: %v", err)
+	}
+	if !result.OK() || result.Size != 5678 {
+		t.Fatalf("unexpected range probe result: %+v", result)
+	}
+
+	result, err = client.Probe(context.Background(), server.URL+"/missing.zip")
+	if err != nil {
+		t.Fatalf("Probe returned error: %v", err)
+	}
+	if result.OK() || result.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected dead link, got %+v", result)
+	}
+}
@@ -1,7 +1,10 @@
 package asf
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"time"
 )
 
@@ -13,6 +16,9 @@ const (
 	PlatformSentinel1B Platform = "Sentinel-1B"
 	PlatformSentinel1C Platform = "Sentinel-1C"
 	PlatformSentinel1  Platform = "Sentinel-1"
+	PlatformUAVSAR     Platform = "UAVSAR"
+	PlatformAIRSAR     Platform = "AIRSAR"
+	PlatformSMAP       Platform = "SMAP"
 )
 
 // BeamMode enumerates radar beam mode values.
@@ -42,10 +48,22 @@ type ProductType string
 const (
 	ProductTypeSLC      ProductType = "SLC"
 	ProductTypeGRD      ProductType = "GRD"
+	ProductTypeGRDHD    ProductType = "GRD_HD"
 	ProductTypeGRDMD    ProductType = "GRD_MD"
+	ProductTypeGRDMS    ProductType = "GRD_MS"
+	ProductTypeGRDFD    ProductType = "GRD_FD"
 	ProductTypeOCN      ProductType = "OCN"
 	ProductTypeRAW      ProductType = "RAW"
 	ProductTypeMETADATA ProductType = "METADATA"
+	ProductTypeBurst    ProductType = "BURST"
+)
+
+// Dataset names a dataset grouping recognized by the search API.
+type Dataset string
+
+const (
+	DatasetSentinel1       Dataset = "SENTINEL-1"
+	DatasetSentinel1Bursts Dataset = "SLC-BURST"
 )
 
 // CollectionName denotes an ASF collection value.
@@ -66,6 +84,9 @@ const (
 	ProcessingLevelGRD   ProcessingLevel = "GRD"
 	ProcessingLevelGRDMD ProcessingLevel = "GRD_MD"
 	ProcessingLevelGRDHD ProcessingLevel = "GRD_HD"
+	ProcessingLevelGRDMS ProcessingLevel = "GRD_MS"
+	ProcessingLevelGRDFD ProcessingLevel = "GRD_FD"
+	ProcessingLevelBurst ProcessingLevel = "BURST"
 )
 
 // LookDirection describes the look direction parameter.
@@ -89,12 +110,46 @@ type FeatureCollection struct {
 	Features []Product `json:"features"`
 }
 
+// ReadProducts decodes saved search results, accepting either a GeoJSON
+// FeatureCollection (as returned by the API) or a JSON array of products (as
+// written by asfcli --output json).
+func ReadProducts(r io.Reader) ([]Product, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("asf: read products: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(data, []byte("[")) {
+		var products []Product
+		if err := json.Unmarshal(data, &products); err != nil {
+			return nil, fmt.Errorf("asf: decode products: %w", err)
+		}
+		return products, nil
+	}
+
+	var payload FeatureCollection
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("asf: decode products: %w", err)
+	}
+	return payload.Features, nil
+}
+
 // Feature represents a single feature in the collection
 type Product struct {
 	Geometry   json.RawMessage `json:"geometry"`
 	Properties Properties      `json:"properties"`
 }
 
+// ID identifies the product across searches, runs and result files: its file
+// ID, or the scene name when the file ID is missing.
+func (p Product) ID() string {
+	if p.Properties.FileID != "" {
+		return p.Properties.FileID
+	}
+	return p.Properties.SceneName
+}
+
 // Properties represents the metadata associated with a feature
 type Properties struct {
 	CenterLat       float64   `json:"centerLat"`
@@ -111,15 +166,73 @@ type Properties struct {
 	Platform        string    `json:"platform"`
 	Bytes           int64     `json:"bytes"`
 	Md5sum          string    `json:"md5sum"`
-	FrameNumber     int       `json:"frameNumber"`
-	GranuleType     string    `json:"granuleType"`
-	Orbit           int       `json:"orbit"`
-	Polarization    string    `json:"polarization"`
-	ProcessingDate  time.Time `json:"processingDate"`
-	Sensor          string    `json:"sensor"`
-	GroupID         string    `json:"groupID"`
-	PgeVersion      string    `json:"pgeVersion"`
-	FileName        string    `json:"fileName"`
-	BeamModeType    string    `json:"beamModeType"`
-	S3Urls          []string  `json:"s3Urls"`
+	// FrameNumber is the frame as the search API reports it, which is the
+	// ASF frame for some platforms and the ESA frame for others. Use
+	// ASFFrame and ESAFrame, filled in by ResolveFrames, when it matters.
+	FrameNumber    int       `json:"frameNumber"`
+	ASFFrame       int       `json:"asfFrame"`
+	ESAFrame       int       `json:"esaFrame"`
+	GranuleType    string    `json:"granuleType"`
+	Orbit          int       `json:"orbit"`
+	Polarization   string    `json:"polarization"`
+	ProcessingDate time.Time `json:"processingDate"`
+	Sensor         string    `json:"sensor"`
+	GroupID        string    `json:"groupID"`
+	PgeVersion     string    `json:"pgeVersion"`
+	FileName       string    `json:"fileName"`
+	BeamModeType   string    `json:"beamModeType"`
+	S3Urls         []string  `json:"s3Urls"`
+
+	// Baseline fields are only populated by stack (baseline) searches.
+	TemporalBaseline      *int     `json:"temporalBaseline"`
+	PerpendicularBaseline *float64 `json:"perpendicularBaseline"`
+
+	// Burst is only populated for Sentinel-1 SLC-BURST products.
+	Burst *BurstInfo `json:"burst"`
+
+	// campaign keeps the raw properties of missions with platform-specific
+	// fields; see Product.SMAP and Product.AIRSAR.
+	campaign json.RawMessage
+}
+
+// UnmarshalJSON accepts the timestamp layouts used across ASF endpoints,
+// including those without a time zone, and normalizes the acquisition and
+// processing times to UTC.
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	type plain Properties
+	var raw struct {
+		plain
+		StartTime      string `json:"startTime"`
+		StopTime       string `json:"stopTime"`
+		ProcessingDate string `json:"processingDate"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = Properties(raw.plain)
+	if isCampaignPlatform(p.Platform) {
+		p.campaign = append(json.RawMessage(nil), data...)
+	}
+
+	if err := setFlexibleTime(&p.StartTime, "startTime", raw.StartTime); err != nil {
+		return err
+	}
+	if err := setFlexibleTime(&p.StopTime, "stopTime", raw.StopTime); err != nil {
+		return err
+	}
+	return setFlexibleTime(&p.ProcessingDate, "processingDate", raw.ProcessingDate)
+}
+
+// setFlexibleTime parses a timestamp field in any API layout into dst as UTC.
+// An empty value leaves dst unset.
+func setFlexibleTime(dst *time.Time, name, value string) error {
+	if value == "" {
+		return nil
+	}
+	t, err := parseFlexibleTime(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	*dst = t.UTC()
+	return nil
 }
@@ -2,6 +2,7 @@ package asf
 
 import (
 	"encoding/json"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -101,3 +102,146 @@ func TestFeatureCollectionMarshal(t *testing.T) {
 		t.Fatalf("expected url in JSON, got %s", got)
 	}
 }
+
+func TestReadProducts(t *testing.T) {
+	t.Run("FeatureCollection", func(t *testing.T) {
+		file, err := os.Open("asf_response.json")
+		if err != nil {
+			t.Fatalf("open asf_response.json: %v", err)
+		}
+		defer file.Close()
+
+		products, err := ReadProducts(file)
+		if err != nil {
+			t.Fatalf("ReadProducts returned error: %v", err)
+		}
+		if len(products) != 2 {
+			t.Fatalf("expected 2 products, got %d", len(products))
+		}
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		products, err := ReadProducts(strings.NewReader(`  [{"geometry":null,"properties":{"sceneName":"A"}}]`))
+		if err != nil {
+			t.Fatalf("ReadProducts returned error: %v", err)
+		}
+		if len(products) != 1 || products[0].Properties.SceneName != "A" {
+			t.Fatalf("unexpected products: %+v", products)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := ReadProducts(strings.NewReader("not json")); err == nil {
+			t.Fatalf("expected decode error")
+		}
+	})
+}
+
+func TestLocalSolarTime(t *testing.T) {
+	// Near the equinox the equation of time is small, so solar time is close
+	// to UTC shifted by the longitude: 12:00 UTC at 90°W is about 06:00.
+	p := Product{Properties: Properties{
+		StartTime: time.Date(2024, 3, 20, 11, 59, 0, 0, time.UTC),
+		StopTime:  time.Date(2024, 3, 20, 12, 1, 0, 0, time.UTC),
+		CenterLon: -90,
+	}}
+	got := p.LocalSolarTime()
+	want := 6 * time.Hour
+	if diff := got - want; diff < -10*time.Minute || diff > 10*time.Minute {
+		t.Fatalf("expected local solar time near %s, got %s", want, got)
+	}
+
+	// Wraps around midnight for eastern longitudes.
+	p.Properties.CenterLon = 179
+	if got := p.LocalSolarTime(); got < 23*time.Hour || got >= 24*time.Hour {
+		t.Fatalf("expected local solar time just before midnight, got %s", got)
+	}
+
+	if got := (Product{}).LocalSolarTime(); got != 0 {
+		t.Fatalf("expected zero for product without times, got %s", got)
+	}
+}
+
+func TestOrbitDirection(t *testing.T) {
+	tests := map[string]FlightDirection{
+		"ASCENDING":   FlightDirectionAscending,
+		"descending ": FlightDirectionDescending,
+		"":            "",
+	}
+	for input, want := range tests {
+		p := Product{Properties: Properties{FlightDirection: input}}
+		if got := p.OrbitDirection(); got != want {
+			t.Fatalf("OrbitDirection(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestOrbitDirectionFromSolarTime(t *testing.T) {
+	// Sentinel-1 crosses Alaska around 03:30 UTC ascending (about 17:40
+	// local solar time) and around 16:30 UTC descending (about 06:40).
+	alaska := json.RawMessage(`{"type":"Polygon","coordinates":[[[-149,63],[-146,63],[-146,65],[-149,65],[-149,63]]]}`)
+	tests := []struct {
+		name  string
+		props Properties
+		want  FlightDirection
+	}{
+		{"Ascending", Properties{Platform: "Sentinel-1A", CenterLon: -147.5, CenterLat: 64, StartTime: time.Date(2024, 3, 1, 3, 30, 0, 0, time.UTC)}, FlightDirectionAscending},
+		{"Descending", Properties{Platform: "Sentinel-1A", CenterLon: -147.5, CenterLat: 64, StartTime: time.Date(2024, 3, 1, 16, 30, 0, 0, time.UTC)}, FlightDirectionDescending},
+		// The property is ignored when the direction can be computed.
+		{"OverridesProperty", Properties{Platform: "Sentinel-1B", CenterLon: -147.5, CenterLat: 64, StartTime: time.Date(2024, 3, 1, 3, 30, 0, 0, time.UTC), FlightDirection: "DESCENDING"}, FlightDirectionAscending},
+		// ALOS descends around 10:30 local solar time.
+		{"ALOS", Properties{Platform: "ALOS", CenterLon: 135, CenterLat: 35, StartTime: time.Date(2008, 6, 1, 1, 30, 0, 0, time.UTC)}, FlightDirectionDescending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Product{Properties: tt.props}
+			if got := p.OrbitDirection(); got != tt.want {
+				t.Fatalf("OrbitDirection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Footprint", func(t *testing.T) {
+		p := Product{Geometry: alaska, Properties: Properties{Platform: "Sentinel-1A", StartTime: time.Date(2024, 3, 1, 16, 30, 0, 0, time.UTC)}}
+		if got := p.OrbitDirection(); got != FlightDirectionDescending {
+			t.Fatalf("OrbitDirection() = %q, want %q", got, FlightDirectionDescending)
+		}
+	})
+}
+
+func TestPropertiesTolerantTimes(t *testing.T) {
+	var props Properties
+	err := json.Unmarshal([]byte(`{
+		"startTime": "2016-05-01T10:20:30.123456",
+		"stopTime": "2016-05-01T12:20:45+02:00",
+		"processingDate": "2016-05-02",
+		"sceneName": "LEGACY"
+	}`), &props)
+	if err != nil {
+		t.Fatalf("unmarshal properties: %v", err)
+	}
+	if want := time.Date(2016, 5, 1, 10, 20, 30, 123456000, time.UTC); !props.StartTime.Equal(want) {
+		t.Fatalf("unexpected start time: %s", props.StartTime)
+	}
+	if props.StopTime.Location() != time.UTC || props.StopTime.Hour() != 10 {
+		t.Fatalf("expected stop time normalized to UTC, got %s", props.StopTime)
+	}
+	if want := time.Date(2016, 5, 2, 0, 0, 0, 0, time.UTC); !props.ProcessingDate.Equal(want) || props.SceneName != "LEGACY" {
+		t.Fatalf("unexpected properties: %+v", props)
+	}
+
+	if err := json.Unmarshal([]byte(`{"startTime": "yesterday"}`), &props); err == nil || !strings.Contains(err.Error(), "startTime") {
+		t.Fatalf("expected startTime error, got %v", err)
+	}
+}
+
+func TestProductID(t *testing.T) {
+	p := Product{Properties: Properties{FileID: "S1A_SCENE-SLC", SceneName: "S1A_SCENE"}}
+	if got := p.ID(); got != "S1A_SCENE-SLC" {
+		t.Fatalf("expected file ID, got %q", got)
+	}
+	p.Properties.FileID = ""
+	if got := p.ID(); got != "S1A_SCENE" {
+		t.Fatalf("expected scene name fallback, got %q", got)
+	}
+}
@@ -0,0 +1,49 @@
+package asf
+
+// Progress describes the state of a single file download.
+type Progress struct {
+	// FileName is the product's file name.
+	FileName string
+	// Bytes is the number of bytes on disk so far, including any resumed
+	// partial data.
+	Bytes int64
+	// Resumed is the number of bytes that were already on disk when the
+	// transfer started.
+	Resumed int64
+	// Total is the expected file size, or zero when unknown.
+	Total int64
+	// Estimated is set when the server sent no Content-Length, as for some
+	// redirected or chunked downloads, and Total is the size from the
+	// product's metadata instead. An estimate is raised if more data
+	// arrives, and the final report carries the real size.
+	Estimated bool
+	// Done is set on the final report for a successfully completed file.
+	Done bool
+}
+
+// ProgressFunc receives download progress reports. It is called from the
+// downloading goroutines, concurrently for different files, and should return
+// quickly.
+type ProgressFunc func(Progress)
+
+// WithProgress reports download progress to fn as data is written.
+func WithProgress(fn ProgressFunc) Option {
+	return func(c *Client) {
+		c.progress = fn
+	}
+}
+
+// progressWriter reports progress for every write passing through it.
+type progressWriter struct {
+	report   ProgressFunc
+	progress Progress
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.progress.Bytes += int64(len(p))
+	if w.progress.Estimated && w.progress.Bytes > w.progress.Total {
+		w.progress.Total = w.progress.Bytes
+	}
+	w.report(w.progress)
+	return len(p), nil
+}
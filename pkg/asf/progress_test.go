@@ -0,0 +1,90 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithProgress(t *testing.T) {
+	payload := strings.Repeat("x", 100_000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "f.zip", time.Time{}, strings.NewReader(payload))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(targetDir, "f.zip.part"), []byte(payload[:1000]), 0644); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+
+	var mu sync.Mutex
+	var reports []Progress
+	client := NewClient(WithProgress(func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, p)
+	}))
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip"}}
+	if err := client.Download(context.Background(), targetDir, product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+
+	if len(reports) < 2 {
+		t.Fatalf("expected several progress reports, got %d", len(reports))
+	}
+	first, last := reports[0], reports[len(reports)-1]
+	if first.Bytes != 1000 || first.Resumed != 1000 || first.Total != int64(len(payload)) {
+		t.Fatalf("unexpected first report: %+v", first)
+	}
+	if !last.Done || last.Bytes != int64(len(payload)) || last.FileName != "f.zip" {
+		t.Fatalf("unexpected final report: %+v", last)
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i].Bytes < reports[i-1].Bytes {
+			t.Fatalf("progress went backwards: %+v then %+v", reports[i-1], reports[i])
+		}
+	}
+}
+
+func TestProgressEstimatedTotal(t *testing.T) {
+	payload := strings.Repeat("x", 50_000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before writing forces a chunked response without a
+		// Content-Length.
+		w.(http.Flusher).Flush()
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var reports []Progress
+	client := NewClient(WithProgress(func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, p)
+	}))
+	product := Product{Properties: Properties{SceneName: "s", FileName: "f.zip", URL: server.URL + "/f.zip", Bytes: 40_000}}
+	if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+
+	first, last := reports[0], reports[len(reports)-1]
+	if !first.Estimated || first.Total != 40_000 {
+		t.Fatalf("expected an estimated total from the product size, got %+v", first)
+	}
+	for _, p := range reports {
+		if p.Total < p.Bytes {
+			t.Fatalf("estimated total fell behind the bytes received: %+v", p)
+		}
+	}
+	if !last.Done || last.Estimated || last.Total != int64(len(payload)) {
+		t.Fatalf("expected the final report to carry the real size, got %+v", last)
+	}
+}
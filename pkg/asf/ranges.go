@@ -0,0 +1,75 @@
+package asf
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseIntRanges parses a comma-separated list of integers and inclusive
+// ranges, such as "15-17,20", into the individual values it denotes.
+func ParseIntRanges(s string) ([]int, error) {
+	var values []int
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("asf: invalid range %q: %w", part, err)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(strings.TrimSpace(hi)); err != nil {
+				return nil, fmt.Errorf("asf: invalid range %q: %w", part, err)
+			}
+			if last < first {
+				return nil, fmt.Errorf("asf: invalid range %q: end before start", part)
+			}
+		}
+		for v := first; v <= last; v++ {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// formatIntRanges renders values as sorted, de-duplicated terms, collapsing
+// consecutive runs into inclusive ranges: [20 15 16 17] becomes
+// ["15-17", "20"].
+func formatIntRanges(values []int) []string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	var terms []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] == sorted[j]+1 {
+			j++
+		}
+		if j == i {
+			terms = append(terms, strconv.Itoa(sorted[i]))
+		} else {
+			terms = append(terms, fmt.Sprintf("%d-%d", sorted[i], sorted[j]))
+		}
+		i = j + 1
+	}
+	return terms
+}
+
+// formatFrameRange renders an inclusive frame range, a single frame when end
+// is unset or equal to start, or "" when neither bound is set.
+func formatFrameRange(start, end int) string {
+	switch {
+	case start <= 0 && end <= 0:
+		return ""
+	case end <= 0 || end == start:
+		return strconv.Itoa(start)
+	default:
+		return fmt.Sprintf("%d-%d", start, end)
+	}
+}
@@ -0,0 +1,55 @@
+package asf
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseIntRanges(t *testing.T) {
+	got, err := ParseIntRanges("15-17, 20,")
+	if err != nil {
+		t.Fatalf("ParseIntRanges returned error: %v", err)
+	}
+	if want := []int{15, 16, 17, 20}; !slices.Equal(got, want) {
+		t.Fatalf("ParseIntRanges = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"a", "17-15", "3-x"} {
+		if _, err := ParseIntRanges(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestFormatIntRanges(t *testing.T) {
+	got := formatIntRanges([]int{20, 15, 17, 16, 16, 3, 5})
+	if want := []string{"3", "5", "15-17", "20"}; !slices.Equal(got, want) {
+		t.Fatalf("formatIntRanges = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeSearchOptionsOrbitAndFrameRanges(t *testing.T) {
+	q := encodeSearchOptions(SearchOptions{
+		AbsoluteOrbits: []int{4752, 4750, 4751, 4800},
+		FrameStart:     100,
+		FrameEnd:       120,
+		ASFFrameStart:  590,
+	})
+	if got, want := q["absoluteOrbit"], []string{"4750-4752", "4800"}; !slices.Equal(got, want) {
+		t.Fatalf("absoluteOrbit = %v, want %v", got, want)
+	}
+	if got := q.Get("frame"); got != "100-120" {
+		t.Fatalf("frame = %q, want 100-120", got)
+	}
+	if got := q.Get("asfframe"); got != "590" {
+		t.Fatalf("asfframe = %q, want 590", got)
+	}
+
+	q = encodeSearchOptions(SearchOptions{FrameStart: 42})
+	if got := q.Get("frame"); got != "42" {
+		t.Fatalf("frame = %q, want 42", got)
+	}
+	if q.Has("absoluteOrbit") || q.Has("asfframe") {
+		t.Fatalf("unexpected absoluteOrbit %v or asfframe %v", q["absoluteOrbit"], q["asfframe"])
+	}
+}
@@ -0,0 +1,42 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Raw sends a GET request to an arbitrary API path below the base URL, such
+// as "services/utils/date", giving access to less common ASF endpoints. The
+// request goes through the same authentication, retry policy and logging as
+// searches. The response is returned whatever its status; the caller must
+// close its body.
+func (c *Client) Raw(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	endpoint, err := url.JoinPath(c.baseURL, strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("asf: invalid base URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("asf: create request: %w", err)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	c.log().Debug("asf: raw request", "path", req.URL.Path)
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("asf: send request: %w", err)
+	}
+	return resp, nil
+}
+
+// Do sends req with the client's authentication, retry policy and logging,
+// for companion ASF services such as HyP3 that are not below the base URL.
+// Request bodies are replayed on retry as described for RetryPolicy.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	c.log().Debug("asf: request", "method", req.Method, "host", req.URL.Host, "path", req.URL.Path)
+	return c.do(req)
+}
@@ -0,0 +1,40 @@
+package asf
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestRaw(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/services/utils/date" || r.URL.Query().Get("date") != "2024-01-01" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected auth header, got %q", got)
+		}
+		w.Write([]byte(`{"date":{"parsed":"2024-01-01T00:00:00Z"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithAuthToken("secret"), WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: 1}))
+	resp, err := client.Raw(context.Background(), "/services/utils/date", url.Values{"date": {"2024-01-01"}})
+	if err != nil {
+		t.Fatalf("Raw returned error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || attempts != 2 || string(body) != `{"date":{"parsed":"2024-01-01T00:00:00Z"}}` {
+		t.Fatalf("unexpected response %d after %d attempts: %s", resp.StatusCode, attempts, body)
+	}
+}
@@ -0,0 +1,96 @@
+package asf
+
+import (
+	"strings"
+	"time"
+)
+
+// Products is a list of search results with helpers for bulk conversion.
+type Products []Product
+
+// ToRecords flattens each product into a map keyed by the API's property
+// names, for data frame libraries and templates that cannot walk nested
+// structs. Every record has the same keys and each key always holds the same
+// type: string, int64, float64 or time.Time, or nil where the value is
+// missing. Burst fields are prefixed with "burst.", S3 URLs are joined with
+// commas and the footprint is given as WKT under "geometry".
+func (ps Products) ToRecords() []map[string]any {
+	records := make([]map[string]any, len(ps))
+	for i, p := range ps {
+		records[i] = p.record()
+	}
+	return records
+}
+
+// record flattens a single product; see Products.ToRecords.
+func (p Product) record() map[string]any {
+	props := p.Properties
+	r := map[string]any{
+		"sceneName":             props.SceneName,
+		"fileID":                props.FileID,
+		"fileName":              props.FileName,
+		"platform":              props.Platform,
+		"sensor":                props.Sensor,
+		"beamModeType":          props.BeamModeType,
+		"polarization":          props.Polarization,
+		"processingLevel":       props.ProcessingLevel,
+		"granuleType":           props.GranuleType,
+		"groupID":               props.GroupID,
+		"pgeVersion":            props.PgeVersion,
+		"flightDirection":       props.FlightDirection,
+		"orbit":                 int64(props.Orbit),
+		"pathNumber":            int64(props.PathNumber),
+		"frameNumber":           int64(props.FrameNumber),
+		"asfFrame":              int64(props.ASFFrame),
+		"esaFrame":              int64(props.ESAFrame),
+		"centerLat":             props.CenterLat,
+		"centerLon":             props.CenterLon,
+		"startTime":             recordTime(props.StartTime),
+		"stopTime":              recordTime(props.StopTime),
+		"processingDate":        recordTime(props.ProcessingDate),
+		"url":                   props.URL,
+		"browse":                props.Browse,
+		"s3Urls":                strings.Join(props.S3Urls, ","),
+		"bytes":                 props.Bytes,
+		"md5sum":                props.Md5sum,
+		"temporalBaseline":      nil,
+		"perpendicularBaseline": nil,
+		"burst.absoluteBurstID": nil,
+		"burst.relativeBurstID": nil,
+		"burst.fullBurstID":     nil,
+		"burst.burstIndex":      nil,
+		"burst.subswath":        nil,
+		"burst.samplesPerBurst": nil,
+		"burst.azimuthTime":     nil,
+		"burst.azimuthAnxTime":  nil,
+		"geometry":              nil,
+	}
+	if props.TemporalBaseline != nil {
+		r["temporalBaseline"] = int64(*props.TemporalBaseline)
+	}
+	if props.PerpendicularBaseline != nil {
+		r["perpendicularBaseline"] = *props.PerpendicularBaseline
+	}
+	if b := props.Burst; b != nil {
+		r["burst.absoluteBurstID"] = int64(b.AbsoluteBurstID)
+		r["burst.relativeBurstID"] = int64(b.RelativeBurstID)
+		r["burst.fullBurstID"] = b.FullBurstID
+		r["burst.burstIndex"] = int64(b.BurstIndex)
+		r["burst.subswath"] = b.Subswath
+		r["burst.samplesPerBurst"] = int64(b.SamplesPerBurst)
+		r["burst.azimuthTime"] = recordTime(b.AzimuthTime)
+		r["burst.azimuthAnxTime"] = b.AzimuthAnxTime
+	}
+	if g, err := p.ParseGeometry(); err == nil && !g.IsEmpty() {
+		r["geometry"] = g.WKT()
+	}
+	return r
+}
+
+// recordTime returns t in UTC, or nil for the zero time.
+func recordTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
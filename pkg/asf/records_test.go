@@ -0,0 +1,46 @@
+package asf
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProductsToRecords(t *testing.T) {
+	f, err := os.Open("asf_response.json")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+	products, err := ReadProducts(f)
+	if err != nil {
+		t.Fatalf("ReadProducts returned error: %v", err)
+	}
+	baseline := 12
+	products[1].Properties.TemporalBaseline = &baseline
+
+	records := Products(products).ToRecords()
+	if len(records) != 2 || len(records[0]) != len(records[1]) {
+		t.Fatalf("expected two records with the same keys, got %v", records)
+	}
+	first := records[0]
+	if first["sceneName"] != "S1C_IW_SLC__1SDV_20251028T021014_20251028T021042_004756_00963D_8B2E" {
+		t.Fatalf("unexpected sceneName %v", first["sceneName"])
+	}
+	if _, ok := first["pathNumber"].(int64); !ok {
+		t.Fatalf("expected pathNumber as int64, got %T", first["pathNumber"])
+	}
+	if start, ok := first["startTime"].(time.Time); !ok || start.Location() != time.UTC {
+		t.Fatalf("expected startTime as UTC time.Time, got %v", first["startTime"])
+	}
+	if wkt, ok := first["geometry"].(string); !ok || !strings.HasPrefix(wkt, "POLYGON") {
+		t.Fatalf("expected WKT geometry, got %v", first["geometry"])
+	}
+	if first["temporalBaseline"] != nil || records[1]["temporalBaseline"] != int64(12) {
+		t.Fatalf("unexpected temporalBaseline values %v and %v", first["temporalBaseline"], records[1]["temporalBaseline"])
+	}
+	if v, ok := first["burst.subswath"]; !ok || v != nil {
+		t.Fatalf("expected nil burst.subswath for a non-burst product, got %v", v)
+	}
+}
@@ -0,0 +1,67 @@
+package asf
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultMaxRedirects matches the limit of net/http's default redirect
+// policy, which stops after 10 requests.
+const defaultMaxRedirects = 10
+
+// WithMaxRedirects sets the redirect limit of the default HTTP client. Like
+// net/http's default policy, a request fails with a RedirectError once its
+// chain has made n requests, so at most n-1 redirects are followed. The
+// Earthdata login flow takes several hops, so values below 5 usually break
+// downloads. Zero or a negative value keeps the default of 10.
+func WithMaxRedirects(n int) Option {
+	return func(c *Client) {
+		c.maxRedirects = n
+	}
+}
+
+// RedirectError reports a request that was redirected more times than
+// allowed. Hosts lists the host of each request in the chain, starting with
+// the original one, to help debug redirect loops in the login flow; paths
+// and query strings are left out because they may carry tokens.
+type RedirectError struct {
+	Limit int
+	Hosts []string
+}
+
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("asf: stopped after %d redirects: %s", e.Limit, strings.Join(e.Hosts, " -> "))
+}
+
+// checkRedirect is the redirect policy of the default HTTP client. It
+// enforces the redirect limit and carries authentication and download
+// headers over to the next request.
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) == 0 {
+		return nil
+	}
+	limit := c.maxRedirects
+	if limit <= 0 {
+		limit = defaultMaxRedirects
+	}
+	if len(via) >= limit {
+		hosts := make([]string, 0, len(via)+1)
+		for _, r := range via {
+			hosts = append(hosts, r.URL.Host)
+		}
+		return &RedirectError{Limit: limit, Hosts: append(hosts, req.URL.Host)}
+	}
+	prev := via[len(via)-1]
+
+	// Only re-apply auth header on redirect
+	if authHeader := prev.Header.Get("Authorization"); authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	if headers, ok := req.Context().Value(downloadHeadersKey{}).(http.Header); ok {
+		for key, values := range headers {
+			req.Header[key] = values
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxRedirects(t *testing.T) {
+	var hops int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops++
+		http.Redirect(w, r, "/loop?token=secret", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithMaxRedirects(3))
+	err := client.Download(context.Background(), t.TempDir(), Product{Properties: Properties{
+		URL:      server.URL + "/f.zip",
+		FileName: "f.zip",
+	}})
+
+	var redirectErr *RedirectError
+	if !errors.As(err, &redirectErr) {
+		t.Fatalf("expected RedirectError, got %v", err)
+	}
+	if redirectErr.Limit != 3 || len(redirectErr.Hosts) != 4 || hops != 3 {
+		t.Fatalf("unexpected redirect error %+v after %d hops", redirectErr, hops)
+	}
+	if strings.Contains(err.Error(), "secret") || !strings.Contains(err.Error(), strings.TrimPrefix(server.URL, "http://")) {
+		t.Fatalf("expected hosts only in error, got %v", err)
+	}
+	if Classify(err) != Permanent {
+		t.Fatalf("expected redirect loop to be permanent, got %v", Classify(err))
+	}
+}
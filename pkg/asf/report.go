@@ -0,0 +1,71 @@
+package asf
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// DownloadResult is the outcome of downloading one product, for batch jobs
+// that record a manifest of what they fetched.
+type DownloadResult struct {
+	Product Product
+	// Path is where the file was saved, or empty if the product was skipped
+	// by a DestResolver or failed before its destination was resolved.
+	Path string
+	// Bytes is the size of the saved file.
+	Bytes int64
+	// Duration is the time spent on the product, including retries.
+	Duration time.Duration
+	// Checksum is the hex MD5 of the saved file.
+	Checksum string
+	Err      error
+}
+
+// DownloadReport downloads products like Download and also returns the
+// outcome of each, in the order given, whether or not the batch failed.
+// Saved files are read once more to record their size and MD5.
+func (c *Client) DownloadReport(ctx context.Context, targetFolder string, products ...Product) ([]DownloadResult, error) {
+	results := make([]DownloadResult, len(products))
+	for i, product := range products {
+		results[i].Product = product
+	}
+	err := c.download(ctx, targetFolder, products, results)
+	var batchErr *BatchError
+	batchRan := err == nil || errors.As(err, &batchErr)
+	for i := range results {
+		result := &results[i]
+		switch {
+		case result.Err != nil:
+		case result.Path == "":
+			if !batchRan {
+				// Download failed before the product was attempted.
+				result.Err = err
+			}
+		case c.invalidFileRetries > 0 && !validDownload(result.Path):
+			result.Err = ErrInvalidDownload
+		default:
+			result.Bytes, result.Checksum, result.Err = fileMD5(result.Path)
+		}
+	}
+	return results, err
+}
+
+// fileMD5 returns the size and hex MD5 digest of the file at path.
+func fileMD5(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+	h := md5.New()
+	n, err := io.Copy(h, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,43 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	products := []Product{
+		{Properties: Properties{FileName: "ok.zip", URL: server.URL + "/ok.zip"}},
+		{Properties: Properties{FileName: "missing.zip", URL: server.URL + "/missing.zip"}},
+	}
+	results, err := NewClient().DownloadReport(context.Background(), dir, products...)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 {
+		t.Fatalf("expected one failure in a *BatchError, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	ok := results[0]
+	if ok.Err != nil || ok.Path != filepath.Join(dir, "ok.zip") || ok.Bytes != 4 ||
+		ok.Checksum != "8d777f385d3dfec8815d20f7496026dc" || ok.Product.Properties.FileName != "ok.zip" {
+		t.Fatalf("unexpected result for ok.zip: %+v", ok)
+	}
+	if results[1].Err == nil || results[1].Bytes != 0 || results[1].Checksum != "" {
+		t.Fatalf("unexpected result for missing.zip: %+v", results[1])
+	}
+}
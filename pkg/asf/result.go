@@ -0,0 +1,45 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+)
+
+// SearchResult is a page of search results together with warnings about its
+// completeness.
+type SearchResult struct {
+	Products []Product
+	// Truncated reports that the result hit MaxResults, so more products are
+	// likely to match than were returned.
+	Truncated bool
+	// Warnings explains any conditions, such as truncation, that make the
+	// result differ from the full set of matching products.
+	Warnings []string
+}
+
+// SearchDetailed runs the same single-page query as Search and reports
+// whether the result was truncated. Truncation is also logged as a warning.
+func (c *Client) SearchDetailed(ctx context.Context, opts SearchOptions) (SearchResult, error) {
+	products, err := c.searchPage(ctx, opts, 0)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	var result SearchResult
+	// A page filled to the requested size almost always means the API
+	// stopped early rather than that exactly MaxResults products match.
+	if opts.MaxResults > 0 && len(products) >= opts.MaxResults {
+		result.Truncated = true
+		result.Warnings = append(result.Warnings, c.warnTruncated(len(products)))
+	}
+	result.Products = filterProducts(products, opts)
+	sortProducts(result.Products, opts)
+	return result, nil
+}
+
+// warnTruncated logs and returns a warning about a truncated result.
+func (c *Client) warnTruncated(n int) string {
+	msg := fmt.Sprintf("results truncated at %d products; more may match (raise MaxResults or use SearchAll)", n)
+	c.log().Warn("asf: "+msg, "products", n)
+	return msg
+}
@@ -0,0 +1,79 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// countedServer serves min(maxResults, total) products per request.
+func countedServer(total int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := total
+		if size, err := strconv.Atoi(r.URL.Query().Get("maxResults")); err == nil && size < n {
+			n = size
+		}
+		features := make([]string, n)
+		for i := range features {
+			features[i] = fmt.Sprintf(`{"properties":{"sceneName":"S%d"}}`, i)
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+}
+
+func TestSearchDetailedTruncation(t *testing.T) {
+	server := countedServer(5)
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(WithBaseURL(server.URL), WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+	result, err := client.SearchDetailed(context.Background(), SearchOptions{MaxResults: 3})
+	if err != nil {
+		t.Fatalf("SearchDetailed returned error: %v", err)
+	}
+	if !result.Truncated || len(result.Warnings) != 1 || len(result.Products) != 3 {
+		t.Fatalf("expected a truncated result with a warning, got %+v", result)
+	}
+	if !strings.Contains(logs.String(), "results truncated") {
+		t.Fatalf("expected truncation to be logged, got %q", logs.String())
+	}
+
+	result, err = client.SearchDetailed(context.Background(), SearchOptions{MaxResults: 10})
+	if err != nil {
+		t.Fatalf("SearchDetailed returned error: %v", err)
+	}
+	if result.Truncated || len(result.Warnings) != 0 || len(result.Products) != 5 {
+		t.Fatalf("expected a complete result, got %+v", result)
+	}
+}
+
+func TestSearchAllLogsTruncation(t *testing.T) {
+	var pages []string
+	server := pagedServer(t, 10, &pages)
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(WithBaseURL(server.URL), WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+	if _, err := client.SearchAll(context.Background(), SearchOptions{PageSize: 4, MaxResults: 6}); err != nil {
+		t.Fatalf("SearchAll returned error: %v", err)
+	}
+	if !strings.Contains(logs.String(), "results truncated") {
+		t.Fatalf("expected truncation to be logged, got %q", logs.String())
+	}
+
+	logs.Reset()
+	if _, err := client.SearchAll(context.Background(), SearchOptions{PageSize: 4, MaxResults: 20}); err != nil {
+		t.Fatalf("SearchAll returned error: %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Fatalf("expected no warning for an exhausted search, got %q", logs.String())
+	}
+}
@@ -0,0 +1,188 @@
+package asf
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed search and download requests are retried.
+// Transport errors and 429 and 5xx responses are retried; the delay before
+// attempt n+1 is drawn uniformly from [0, min(MaxDelay, BaseDelay*2^(n-1))]
+// ("full jitter"), which keeps fleets of clients from retrying in lockstep.
+// When the response carries a Retry-After header, the client instead waits
+// at least that long, up to MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values
+	// below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff cap for the first retry; zero means 500ms.
+	BaseDelay time.Duration
+	// MaxDelay bounds the backoff cap; zero means 30s.
+	MaxDelay time.Duration
+	// OnRetry, if set, is called before each retry with the number of the
+	// attempt that failed, the delay about to be waited, and the failure.
+	OnRetry func(attempt int, delay time.Duration, cause error)
+}
+
+// WithRetryPolicy enables retries of failed requests. By default requests are
+// not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// nonIdempotentKey marks a request context whose request must not be sent
+// twice.
+type nonIdempotentKey struct{}
+
+// NonIdempotent returns a context for requests, such as job submissions, that
+// must not be repeated once the server may have acted on them. Such requests
+// are only retried after a 429 response, which the server rejected without
+// processing, and never after a transport error or 5xx response.
+func NonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentKey{}, true)
+}
+
+// backoff returns the jittered delay to wait after the given failed attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	base, maxDelay := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+	ceiling := maxDelay
+	if shift := attempt - 1; shift < 32 && base<<shift > 0 && base<<shift < maxDelay {
+		ceiling = base << shift
+	}
+	return rand.N(ceiling + 1)
+}
+
+// Delay returns how long to wait after the given failed attempt, for callers
+// retrying requests of their own under the policy: the jittered backoff,
+// stretched to the Retry-After header of resp when it has one.
+func (p RetryPolicy) Delay(attempt int, resp *http.Response) time.Duration {
+	delay := p.backoff(attempt)
+	if resp != nil {
+		delay = p.honorRetryAfter(delay, parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()))
+	}
+	return delay
+}
+
+// honorRetryAfter stretches a backoff delay to the wait requested by a
+// Retry-After header, bounded by MaxDelay.
+func (p RetryPolicy) honorRetryAfter(delay, retryAfter time.Duration) time.Duration {
+	if retryAfter <= delay {
+		return delay
+	}
+	return min(retryAfter, cmp.Or(p.MaxDelay, 30*time.Second))
+}
+
+// retryCause returns why an attempt should be retried, or nil if it should
+// not be.
+func retryCause(req *http.Request, resp *http.Response, err error) error {
+	if req.Context().Value(nonIdempotentKey{}) != nil {
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			return fmt.Errorf("asf: retryable status %d", resp.StatusCode)
+		}
+		return nil
+	}
+	if err != nil {
+		var redirectErr *RedirectError
+		if req.Context().Err() != nil || errors.As(err, &redirectErr) {
+			return nil
+		}
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return fmt.Errorf("asf: retryable status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendWithRetry sends req through send, retrying according to the client's
+// policy. A request body is replayed from GetBody when set, or buffered if it
+// is at most maxReplayBody bytes; a retry that would need to resend a larger,
+// non-replayable body fails with ErrBodyNotReplayable.
+func (c *Client) sendWithRetry(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	replayable := true
+	if c.retry.MaxAttempts >= 2 {
+		var err error
+		if replayable, err = makeReplayable(req); err != nil {
+			return nil, err
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("asf: replay request body: %w", err)
+			}
+			req.Body = body
+		}
+		resp, err := send(req)
+		cause := retryCause(req, resp, err)
+		if cause == nil || attempt >= c.retry.MaxAttempts {
+			return resp, err
+		}
+		delay := c.retry.backoff(attempt)
+		if resp != nil {
+			delay = c.retry.honorRetryAfter(delay, parseRetryAfter(resp.Header.Get("Retry-After"), c.now()))
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if !replayable {
+			return nil, fmt.Errorf("asf: cannot retry after %v: %w", cause, ErrBodyNotReplayable)
+		}
+
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(attempt, delay, cause)
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, context.Cause(req.Context())
+		case <-c.after(delay):
+		}
+	}
+}
+
+// maxReplayBody is the largest request body buffered in memory so that it can
+// be resent on retry.
+const maxReplayBody = 1 << 20
+
+// makeReplayable ensures req's body can be resent, buffering it when it has
+// no GetBody and fits within maxReplayBody. It reports false for larger
+// bodies, which are left streaming.
+func makeReplayable(req *http.Request) (bool, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return true, nil
+	}
+	buf, err := io.ReadAll(io.LimitReader(req.Body, maxReplayBody+1))
+	if err != nil {
+		req.Body.Close()
+		return false, fmt.Errorf("asf: read request body: %w", err)
+	}
+	if len(buf) > maxReplayBody {
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(buf), req.Body), req.Body}
+		return false, nil
+	}
+	req.Body.Close()
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf)), nil
+	}
+	req.Body, _ = req.GetBody()
+	req.ContentLength = int64(len(buf))
+	return true, nil
+}
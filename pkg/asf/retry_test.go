@@ -0,0 +1,192 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyRetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"features":[{"properties":{"sceneName":"ok"}}]}`))
+	}))
+	defer server.Close()
+
+	var attempts []int
+	client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		OnRetry: func(attempt int, delay time.Duration, cause error) {
+			if cause == nil {
+				t.Errorf("expected a retry cause")
+			}
+			if ceiling := time.Millisecond << (attempt - 1); delay < 0 || delay > ceiling {
+				t.Errorf("delay %s for attempt %d outside [0, %s]", delay, attempt, ceiling)
+			}
+			attempts = append(attempts, attempt)
+		},
+	}))
+
+	products, err := client.Search(context.Background(), SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(products) != 1 {
+		t.Fatalf("expected the third attempt's product, got %d", len(products))
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Fatalf("expected OnRetry for attempts 1 and 2, got %v", attempts)
+	}
+	if got := client.Stats().Requests; got != 3 {
+		t.Fatalf("expected 3 requests counted, got %d", got)
+	}
+}
+
+func TestRetryPolicyGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Query().Get("platform") == "missing" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	if _, err := client.Search(context.Background(), SearchOptions{}); err == nil {
+		t.Fatalf("expected error once attempts are exhausted")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+
+	calls.Store(0)
+	if _, err := client.Search(context.Background(), SearchOptions{Platforms: []Platform{"missing"}}); err == nil {
+		t.Fatalf("expected error for bad request")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected client errors not to be retried, got %d attempts", got)
+	}
+}
+
+func TestRetryPolicyBackoffIsBounded(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 4 * time.Second}
+	for attempt := 1; attempt <= 40; attempt++ {
+		ceiling := min(time.Second<<min(attempt-1, 10), 4*time.Second)
+		for range 20 {
+			if d := policy.backoff(attempt); d < 0 || d > ceiling {
+				t.Fatalf("backoff(%d) = %s, want within [0, %s]", attempt, d, ceiling)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyDelayHonorsRetryAfter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+	resp := &http.Response{Header: http.Header{"Retry-After": {"5"}}}
+	if d := policy.Delay(1, resp); d != 5*time.Second {
+		t.Fatalf("Delay = %s, want the 5s Retry-After", d)
+	}
+	if d := policy.Delay(1, nil); d > time.Millisecond {
+		t.Fatalf("Delay without a response = %s, want at most 1ms", d)
+	}
+}
+
+func TestRetryReplaysRequestBodies(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	post := func(body io.Reader) (*http.Response, error) {
+		// Hide the concrete reader so that NewRequest does not set GetBody.
+		req, err := http.NewRequest(http.MethodPost, server.URL, struct{ io.Reader }{body})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client.do(req)
+	}
+
+	resp, err := post(strings.NewReader("granule=S1A"))
+	if err != nil {
+		t.Fatalf("do returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(bodies) != 2 || bodies[1] != "granule=S1A" {
+		t.Fatalf("expected the body to be resent, got status %d and bodies %q", resp.StatusCode, bodies)
+	}
+
+	calls.Store(0)
+	_, err = post(strings.NewReader(strings.Repeat("x", maxReplayBody+1)))
+	if !errors.Is(err, ErrBodyNotReplayable) {
+		t.Fatalf("expected ErrBodyNotReplayable, got %v", err)
+	}
+	if got := len(bodies[2]); got != maxReplayBody+1 {
+		t.Fatalf("expected the large body to be streamed whole, got %d bytes", got)
+	}
+}
+
+// instantClock reports the real time but never waits.
+type instantClock struct{}
+
+func (instantClock) Now() time.Time { return time.Now() }
+
+func (instantClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "20")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("Retry-After", "120")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("payload"))
+		}
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	client := NewClient(WithClock(instantClock{}), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Minute,
+		OnRetry: func(attempt int, delay time.Duration, cause error) {
+			delays = append(delays, delay)
+		},
+	}))
+	product := Product{Properties: Properties{FileName: "f.zip", URL: server.URL}}
+	if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if len(delays) != 2 || delays[0] != 20*time.Second || delays[1] != time.Minute {
+		t.Fatalf("expected delays [20s 1m] from Retry-After capped by MaxDelay, got %v", delays)
+	}
+}
@@ -0,0 +1,158 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// defaultS3Region is the region of ASF's own buckets, assumed when a bucket's
+// region cannot be determined.
+const defaultS3Region = "us-west-2"
+
+// S3Signer signs a request for an object in an S3 bucket in the given
+// region, typically with AWS Signature Version 4 (for example v4.Signer from
+// the AWS SDK). The region is detected per bucket, so one signer serves
+// buckets in any region.
+type S3Signer func(req *http.Request, region string) error
+
+// WithS3Signer sets the signer applied to requests for s3:// product URLs
+// (from Properties.S3Urls), which are sent to the bucket's own regional
+// endpoint instead of the client's Authenticator. Without a signer such
+// requests are sent unsigned, which only works for public buckets.
+func WithS3Signer(sign S3Signer) Option {
+	return func(c *Client) {
+		c.s3Signer = sign
+	}
+}
+
+// WithS3Endpoint sends requests for s3:// URLs to a custom endpoint, such as
+// an in-region VPC endpoint or an S3-compatible mirror, instead of AWS's
+// public regional endpoints. Objects are addressed path-style, as
+// endpoint/bucket/key.
+func WithS3Endpoint(endpoint string) Option {
+	return func(c *Client) {
+		c.s3Endpoint = endpoint
+	}
+}
+
+// WithS3RequesterPays marks requests for s3:// URLs with
+// "x-amz-request-payer: requester", accepting the transfer charges needed
+// to read requester-pays buckets. The header is set before the S3Signer
+// runs, so it is covered by the signature.
+func WithS3RequesterPays() Option {
+	return func(c *Client) {
+		c.s3RequesterPays = true
+	}
+}
+
+// WithPreferS3 downloads products from their S3 URL (Properties.S3Urls)
+// instead of the HTTPS distribution URL when they have one for the product
+// file. In-region compute avoids egress and the Earthdata login redirect
+// this way; pair it with WithS3Signer.
+func WithPreferS3() Option {
+	return func(c *Client) {
+		c.preferS3 = true
+	}
+}
+
+// sourceURL returns the URL a product is downloaded from.
+func (c *Client) sourceURL(product Product) string {
+	if c.preferS3 {
+		for _, s3URL := range product.Properties.S3Urls {
+			if path.Base(s3URL) == product.Properties.FileName {
+				return s3URL
+			}
+		}
+	}
+	return product.Properties.URL
+}
+
+// s3RegionKey marks request contexts for S3 objects with their bucket's
+// region.
+type s3RegionKey struct{}
+
+// parseS3URL splits an s3://bucket/key URL.
+func parseS3URL(rawURL string) (bucket, key string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme != "s3" || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return "", "", fmt.Errorf("asf: invalid S3 URL %q", rawURL)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// BucketRegion returns the AWS region of an S3 bucket, detected from the
+// X-Amz-Bucket-Region header S3 returns to an anonymous HEAD request, even
+// when it answers 301 or 403. Reported regions are cached for the client's
+// lifetime. When the response carries no region, as with a transient 5xx or
+// a proxy error, the bucket is assumed to be in ASF's us-west-2 without
+// caching the guess, so the next request probes again.
+func (c *Client) BucketRegion(ctx context.Context, bucket string) (string, error) {
+	if region, ok := c.s3Regions.Load(bucket); ok {
+		return region.(string), nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.s3BucketURL(bucket, ""), nil)
+	if err != nil {
+		return "", fmt.Errorf("asf: create bucket region request: %w", err)
+	}
+	// Sent directly: redirects must not be followed, and the probe needs no
+	// credentials.
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("asf: detect region of bucket %q: %w", bucket, err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	region := resp.Header.Get("X-Amz-Bucket-Region")
+	if region == "" {
+		return defaultS3Region, nil
+	}
+	c.s3Regions.Store(bucket, region)
+	return region, nil
+}
+
+// s3BucketURL returns the URL of key in bucket, on the bucket's regional
+// endpoint when region is known.
+func (c *Client) s3BucketURL(bucket, region string) string {
+	if c.s3Endpoint != "" {
+		return strings.TrimSuffix(c.s3Endpoint, "/") + "/" + bucket
+	}
+	if region == "" {
+		return "https://" + bucket + ".s3.amazonaws.com"
+	}
+	return "https://" + bucket + ".s3." + region + ".amazonaws.com"
+}
+
+// s3ObjectRequest resolves an s3:// URL to an HTTPS request on its bucket's
+// regional endpoint, marked for signing with the S3Signer.
+func (c *Client) s3ObjectRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	bucket, key, err := parseS3URL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	region, err := c.BucketRegion(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	objectURL := c.s3BucketURL(bucket, region) + "/" + (&url.URL{Path: key}).EscapedPath()
+	req, err := c.newDownloadRequest(context.WithValue(ctx, s3RegionKey{}, region), objectURL)
+	if err != nil {
+		return nil, err
+	}
+	if c.s3RequesterPays {
+		req.Header.Set("X-Amz-Request-Payer", "requester")
+	}
+	return req, nil
+}
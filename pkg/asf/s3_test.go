@@ -0,0 +1,115 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestDownloadFromS3DetectsBucketRegion(t *testing.T) {
+	var heads int
+	var mu sync.Mutex
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/nisar-bucket":
+			heads++
+			w.Header().Set("X-Amz-Bucket-Region", "us-east-1")
+			w.WriteHeader(http.StatusMovedPermanently)
+		case r.URL.Path == "/nisar-bucket/L1/f.h5":
+			mu.Lock()
+			auth = append(auth, r.Header.Get("Authorization"))
+			mu.Unlock()
+			w.Write([]byte("data"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithS3Endpoint(server.URL),
+		WithPreferS3(),
+		WithAuthToken("earthdata-token"),
+		WithS3Signer(func(req *http.Request, region string) error {
+			req.Header.Set("Authorization", "signed "+region)
+			return nil
+		}),
+	)
+
+	dir := t.TempDir()
+	products := []Product{
+		{Properties: Properties{FileName: "f.h5", URL: server.URL + "/unused", S3Urls: []string{"s3://nisar-bucket/L1/f.h5"}}},
+		// Without a matching S3 URL the HTTPS URL and the client's
+		// authenticator are used.
+		{Properties: Properties{FileName: "g.h5", URL: server.URL + "/nisar-bucket/L1/f.h5"}},
+	}
+	if err := client.Download(context.Background(), dir, products...); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "f.h5")); err != nil || string(data) != "data" {
+		t.Fatalf("unexpected file contents %q, %v", data, err)
+	}
+	slices.Sort(auth)
+	if len(auth) != 2 || auth[0] != "Bearer earthdata-token" || auth[1] != "signed us-east-1" {
+		t.Fatalf("unexpected Authorization headers %q", auth)
+	}
+	if region, err := client.BucketRegion(context.Background(), "nisar-bucket"); err != nil || region != "us-east-1" || heads != 1 {
+		t.Fatalf("expected the cached region us-east-1 after one probe, got %q, %v, %d probes", region, err, heads)
+	}
+}
+
+func TestBucketRegionDoesNotCacheMissingRegion(t *testing.T) {
+	var heads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		heads++
+		if heads == 1 {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-Amz-Bucket-Region", "eu-central-1")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithS3Endpoint(server.URL))
+	if region, err := client.BucketRegion(context.Background(), "bucket"); err != nil || region != defaultS3Region {
+		t.Fatalf("expected the default region after a failed probe, got %q, %v", region, err)
+	}
+	for range 2 {
+		if region, err := client.BucketRegion(context.Background(), "bucket"); err != nil || region != "eu-central-1" {
+			t.Fatalf("expected the reported region, got %q, %v", region, err)
+		}
+	}
+	if heads != 2 {
+		t.Fatalf("expected a second probe and then the cached region, got %d probes", heads)
+	}
+}
+
+func TestS3RequesterPays(t *testing.T) {
+	var payer, signed string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			payer = r.Header.Get("X-Amz-Request-Payer")
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithS3Endpoint(server.URL+"/"), WithS3RequesterPays(), WithS3Signer(func(req *http.Request, region string) error {
+		signed = req.Header.Get("X-Amz-Request-Payer") + " " + region
+		return nil
+	}))
+	product := Product{Properties: Properties{FileName: "f.zip", URL: "s3://mirror/f.zip"}}
+	if err := client.Download(context.Background(), t.TempDir(), product); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if payer != "requester" || signed != "requester "+defaultS3Region {
+		t.Fatalf("expected a signed requester-pays request, got header %q and signed %q", payer, signed)
+	}
+}
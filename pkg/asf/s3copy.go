@@ -0,0 +1,235 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// S3 limits on server-side copies: CopyObject handles objects up to 5 GiB;
+// larger ones are copied in parts. Variables so tests can lower them.
+var (
+	maxS3CopyObject int64 = 5 << 30
+	s3CopyPartSize  int64 = 1 << 30
+)
+
+// CopyToS3 copies products from their S3 URLs (Properties.S3Urls) into the
+// bucket and prefix named by destURL, e.g. "s3://my-bucket/sentinel1/",
+// using S3 server-side copies (CopyObject, or multipart UploadPartCopy for
+// objects over 5 GiB) so the bytes never pass through this host. Each
+// product is stored as prefix + FileName. Requests are signed with the
+// S3Signer for each bucket's region; the credentials need read access to the
+// source and write access to the destination.
+//
+// Like Download, a failed product does not stop the others; failures are
+// returned together in a *BatchError.
+func (c *Client) CopyToS3(ctx context.Context, destURL string, products ...Product) error {
+	destBucket, prefix, err := parseS3Prefix(destURL)
+	if err != nil {
+		return err
+	}
+	batchErr := &BatchError{Total: len(products)}
+	for _, product := range products {
+		if err := c.copyProductToS3(ctx, product, destBucket, prefix+product.Properties.FileName); err != nil {
+			batchErr.Errors = append(batchErr.Errors, &ProductError{Product: product, Err: err})
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+// parseS3Prefix splits an s3://bucket/prefix URL, ensuring a non-empty
+// prefix ends with a slash.
+func parseS3Prefix(rawURL string) (bucket, prefix string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return "", "", fmt.Errorf("asf: invalid S3 destination %q", rawURL)
+	}
+	prefix = strings.TrimPrefix(u.Path, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return u.Host, prefix, nil
+}
+
+// copyProductToS3 copies one product's S3 object to destBucket/destKey.
+func (c *Client) copyProductToS3(ctx context.Context, product Product, destBucket, destKey string) error {
+	source := ""
+	for _, s3URL := range product.Properties.S3Urls {
+		if strings.HasSuffix(s3URL, "/"+product.Properties.FileName) {
+			source = s3URL
+		}
+	}
+	if source == "" {
+		return fmt.Errorf("asf: product %q has no S3 URL for %q", product.Properties.SceneName, product.Properties.FileName)
+	}
+	srcBucket, srcKey, err := parseS3URL(source)
+	if err != nil {
+		return err
+	}
+	copySource := "/" + srcBucket + "/" + (&url.URL{Path: srcKey}).EscapedPath()
+
+	size, err := c.s3ObjectSize(ctx, srcBucket, srcKey)
+	if err != nil {
+		return err
+	}
+	if size <= maxS3CopyObject {
+		req, err := c.s3Request(ctx, http.MethodPut, destBucket, destKey, "", nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Amz-Copy-Source", copySource)
+		_, err = c.s3Call(req)
+		return err
+	}
+	return c.s3MultipartCopy(ctx, copySource, size, destBucket, destKey)
+}
+
+// s3ObjectSize returns the size of an S3 object.
+func (c *Client) s3ObjectSize(ctx context.Context, bucket, key string) (int64, error) {
+	req, err := c.s3Request(ctx, http.MethodHead, bucket, key, "", nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, fmt.Errorf("asf: stat s3://%s/%s: %w", bucket, key, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("asf: stat s3://%s/%s: %w", bucket, key, c.statusError(resp, nil))
+	}
+	return resp.ContentLength, nil
+}
+
+// s3MultipartCopy copies an object larger than CopyObject allows in parts,
+// several at a time, aborting the upload if any part fails.
+func (c *Client) s3MultipartCopy(ctx context.Context, copySource string, size int64, bucket, key string) error {
+	req, err := c.s3Request(ctx, http.MethodPost, bucket, key, "uploads", nil)
+	if err != nil {
+		return err
+	}
+	var initiated struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := c.s3CallXML(req, &initiated); err != nil {
+		return err
+	}
+	uploadID := url.Values{"uploadId": {initiated.UploadID}}.Encode()
+
+	type part struct {
+		PartNumber int
+		ETag       string
+	}
+	parts := make([]part, (size+s3CopyPartSize-1)/s3CopyPartSize)
+	var g errgroup.Group
+	g.SetLimit(4)
+	for i := range parts {
+		g.Go(func() error {
+			start := int64(i) * s3CopyPartSize
+			end := min(start+s3CopyPartSize, size) - 1
+			query := url.Values{"partNumber": {strconv.Itoa(i + 1)}, "uploadId": {initiated.UploadID}}.Encode()
+			req, err := c.s3Request(ctx, http.MethodPut, bucket, key, query, nil)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("X-Amz-Copy-Source", copySource)
+			req.Header.Set("X-Amz-Copy-Source-Range", fmt.Sprintf("bytes=%d-%d", start, end))
+			var result struct {
+				ETag string `xml:"ETag"`
+			}
+			if err := c.s3CallXML(req, &result); err != nil {
+				return fmt.Errorf("asf: copy part %d: %w", i+1, err)
+			}
+			parts[i] = part{PartNumber: i + 1, ETag: result.ETag}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		if abort, reqErr := c.s3Request(context.WithoutCancel(ctx), http.MethodDelete, bucket, key, uploadID, nil); reqErr == nil {
+			c.s3Call(abort)
+		}
+		return err
+	}
+
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []part   `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	req, err = c.s3Request(ctx, http.MethodPost, bucket, key, uploadID, body)
+	if err != nil {
+		return err
+	}
+	_, err = c.s3Call(req)
+	return err
+}
+
+// s3Request creates a request for key in bucket on the bucket's regional
+// endpoint, marked for signing with the S3Signer.
+func (c *Client) s3Request(ctx context.Context, method, bucket, key, rawQuery string, body []byte) (*http.Request, error) {
+	region, err := c.BucketRegion(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := c.s3BucketURL(bucket, region) + "/" + (&url.URL{Path: key}).EscapedPath()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, s3RegionKey{}, region), method, endpoint, reader)
+	if err != nil {
+		return nil, fmt.Errorf("asf: create S3 request: %w", err)
+	}
+	req.URL.RawQuery = rawQuery
+	if c.s3RequesterPays {
+		req.Header.Set("X-Amz-Request-Payer", "requester")
+	}
+	return req, nil
+}
+
+// s3Call sends an S3 API request and returns its response body. S3 may
+// report a failed copy with 200 OK and an Error document, so the body is
+// checked as well as the status.
+func (c *Client) s3Call(req *http.Request) ([]byte, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("asf: S3 %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("asf: S3 %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	if resp.StatusCode/100 != 2 || bytes.Contains(body, []byte("<Error>")) {
+		return nil, fmt.Errorf("asf: S3 %s %s: %w", req.Method, req.URL.Path, c.statusError(resp, body))
+	}
+	return body, nil
+}
+
+// s3CallXML sends an S3 API request and decodes its XML response into out.
+func (c *Client) s3CallXML(req *http.Request, out any) error {
+	body, err := c.s3Call(req)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("asf: decode S3 response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCopyToS3(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		call := r.Method + " " + r.URL.Path
+		if r.URL.RawQuery != "" {
+			call += "?" + r.URL.RawQuery
+		}
+		if src := r.Header.Get("X-Amz-Copy-Source"); src != "" {
+			call += " from " + src + " " + r.Header.Get("X-Amz-Copy-Source-Range")
+		}
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/asf-bucket/small.zip":
+			w.Header().Set("Content-Length", "10")
+		case r.Method == http.MethodHead && r.URL.Path == "/asf-bucket/big.zip":
+			w.Header().Set("Content-Length", "25")
+		case r.Method == http.MethodHead:
+			// Bucket region probes.
+		case r.Method == http.MethodPost && r.URL.RawQuery == "uploads":
+			w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>u1</UploadId></InitiateMultipartUploadResult>`))
+		case r.Method == http.MethodPut && r.URL.Query().Has("partNumber"):
+			w.Write([]byte(`<CopyPartResult><ETag>"e` + r.URL.Query().Get("partNumber") + `"</ETag></CopyPartResult>`))
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			call += " " + string(body)
+			w.Write([]byte(`<CompleteMultipartUploadResult/>`))
+		case r.URL.Path == "/mine/s1/missing.zip":
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+		default:
+			w.Write([]byte(`<CopyObjectResult/>`))
+		}
+		calls = append(calls, call)
+	}))
+	defer server.Close()
+
+	maxObject, partSize := maxS3CopyObject, s3CopyPartSize
+	maxS3CopyObject, s3CopyPartSize = 20, 10
+	defer func() { maxS3CopyObject, s3CopyPartSize = maxObject, partSize }()
+
+	client := NewClient(WithS3Endpoint(server.URL))
+	products := []Product{
+		{Properties: Properties{FileName: "small.zip", S3Urls: []string{"s3://asf-bucket/small.zip"}}},
+		{Properties: Properties{FileName: "big.zip", S3Urls: []string{"s3://asf-bucket/big.zip"}}},
+		{Properties: Properties{FileName: "none.zip"}},
+	}
+	err := client.CopyToS3(context.Background(), "s3://mine/s1", products...)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || batchErr.Errors[0].Product.Properties.FileName != "none.zip" {
+		t.Fatalf("expected only none.zip to fail, got %v", err)
+	}
+
+	for _, want := range []string{
+		"PUT /mine/s1/small.zip from /asf-bucket/small.zip ",
+		"POST /mine/s1/big.zip?uploads",
+		"PUT /mine/s1/big.zip?partNumber=1&uploadId=u1 from /asf-bucket/big.zip bytes=0-9",
+		"PUT /mine/s1/big.zip?partNumber=3&uploadId=u1 from /asf-bucket/big.zip bytes=20-24",
+	} {
+		if !slices.Contains(calls, want) {
+			t.Errorf("missing call %q in %q", want, calls)
+		}
+	}
+	complete := calls[len(calls)-1]
+	if !strings.HasPrefix(complete, "POST /mine/s1/big.zip?uploadId=u1 <CompleteMultipartUpload>") ||
+		!strings.Contains(complete, "<PartNumber>3</PartNumber><ETag>&#34;e3&#34;</ETag>") {
+		t.Fatalf("unexpected completion %q", complete)
+	}
+
+	// A copy that S3 rejects inside a 200 response still fails.
+	products = []Product{{Properties: Properties{FileName: "missing.zip", S3Urls: []string{"s3://asf-bucket/missing.zip"}}}}
+	if err := client.CopyToS3(context.Background(), "s3://mine/s1/", products...); err == nil {
+		t.Fatalf("expected an error document to fail the copy")
+	}
+}
@@ -0,0 +1,84 @@
+package asf
+
+import (
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// WithLogger sets the logger used for diagnostics such as schema drift
+// warnings. By default the client does not log.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithSchemaValidation compares the property keys of every search response
+// against the fields known to Properties and logs any unknown keys at warning
+// level, so API schema drift is noticed before it breaks parsing.
+func WithSchemaValidation() Option {
+	return func(c *Client) {
+		c.validateSchema = true
+	}
+}
+
+// knownPropertyKeys returns the JSON keys decoded into Properties.
+var knownPropertyKeys = sync.OnceValue(func() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeFor[Properties]()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+})
+
+// unknownPropertyKeys returns the sorted set of property keys in a raw
+// FeatureCollection that Properties does not know about.
+func unknownPropertyKeys(data []byte) ([]string, error) {
+	var payload struct {
+		Features []struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	known := knownPropertyKeys()
+	seen := make(map[string]bool)
+	var unknown []string
+	for _, feature := range payload.Features {
+		for key := range feature.Properties {
+			if !known[key] && !seen[key] {
+				seen[key] = true
+				unknown = append(unknown, key)
+			}
+		}
+	}
+	slices.Sort(unknown)
+	return unknown, nil
+}
+
+// reportSchemaDrift logs property keys in data that Properties does not map.
+func (c *Client) reportSchemaDrift(data []byte) {
+	unknown, err := unknownPropertyKeys(data)
+	if err != nil || len(unknown) == 0 {
+		return
+	}
+	c.log().Warn("asf: search response contains unknown property keys", "keys", unknown)
+}
+
+// log returns the configured logger or one that discards everything.
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return c.logger
+}
@@ -0,0 +1,48 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSchemaValidationReportsUnknownKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"features":[
+			{"properties":{"sceneName":"A","brandNewKey":1}},
+			{"properties":{"sceneName":"B","anotherKey":"x","brandNewKey":2}}
+		]}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	client := NewClient(WithBaseURL(server.URL), WithLogger(logger), WithSchemaValidation())
+
+	products, err := client.Search(context.Background(), SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 products, got %d", len(products))
+	}
+
+	got := logs.String()
+	if !strings.Contains(got, "unknown property keys") || !strings.Contains(got, "keys=\"[anotherKey brandNewKey]\"") {
+		t.Fatalf("expected drift warning listing unknown keys, got %q", got)
+	}
+}
+
+func TestUnknownPropertyKeysIgnoresKnownFields(t *testing.T) {
+	unknown, err := unknownPropertyKeys([]byte(`{"features":[{"properties":{"sceneName":"A","fileName":"a.zip","md5sum":"x"}}]}`))
+	if err != nil {
+		t.Fatalf("unknownPropertyKeys returned error: %v", err)
+	}
+	if len(unknown) != 0 {
+		t.Fatalf("expected no unknown keys, got %v", unknown)
+	}
+}
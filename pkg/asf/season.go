@@ -0,0 +1,35 @@
+package asf
+
+import (
+	"fmt"
+	"time"
+)
+
+// Season restricts a search to acquisitions between two days of the year,
+// in every year of the time range. Start may be greater than End for
+// seasons that wrap around the new year, such as December to February.
+type Season struct {
+	// Start and End are inclusive days of the year, from 1 to 365.
+	Start, End int
+}
+
+// IsZero reports whether the season filter is unset.
+func (s Season) IsZero() bool {
+	return s.Start == 0 && s.End == 0
+}
+
+// SeasonOfMonths returns the season from the first day of the start month
+// to the last day of the end month, using non-leap-year day numbers.
+func SeasonOfMonths(start, end time.Month) Season {
+	first := time.Date(2001, start, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2001, end+1, 0, 0, 0, 0, 0, time.UTC)
+	return Season{Start: first.YearDay(), End: last.YearDay()}
+}
+
+// param renders the season as the API's season parameter, "start,end".
+func (s Season) param() string {
+	if s.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d,%d", s.Start, s.End)
+}
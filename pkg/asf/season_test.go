@@ -0,0 +1,23 @@
+package asf
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeason(t *testing.T) {
+	if got := SeasonOfMonths(time.June, time.August); got != (Season{Start: 152, End: 243}) {
+		t.Fatalf("unexpected summer season: %+v", got)
+	}
+	if got := SeasonOfMonths(time.December, time.February); got != (Season{Start: 335, End: 59}) {
+		t.Fatalf("unexpected winter season: %+v", got)
+	}
+
+	q := encodeSearchOptions(SearchOptions{Season: Season{Start: 152, End: 243}})
+	if got := q.Get("season"); got != "152,243" {
+		t.Fatalf("expected season=152,243, got %q", got)
+	}
+	if q := encodeSearchOptions(SearchOptions{}); q.Has("season") {
+		t.Fatalf("expected no season parameter, got %v", q)
+	}
+}
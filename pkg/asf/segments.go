@@ -0,0 +1,164 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// WithSegmentedDownloads fetches each file of at least minSize bytes as up
+// to parts byte ranges in parallel, written into place as they arrive, like
+// aria2 or s5cmd. A single stream from the ASF datapool is often slower than
+// the link, so this speeds up large products such as SLC zips. Files
+// smaller than minSize, or served by hosts without Range support, are
+// downloaded as one stream. Segmented downloads are not resumed: segments
+// are written to a separate ".segments.part" file that only becomes the
+// partial file once every segment has arrived, so an interrupted one
+// starts over rather than being mistaken for a complete partial file.
+func WithSegmentedDownloads(parts int, minSize int64) Option {
+	return func(c *Client) {
+		c.segmentParts = parts
+		c.segmentMinSize = minSize
+	}
+}
+
+// downloadSegments fetches a file of total bytes from downloadURL in
+// segments, written concurrently into a preallocated segments file, which
+// is renamed to partPath once complete, then verified and moved to
+// destPath. The segments file is removed if any segment fails. Every
+// segment after the first needs a spare download slot for host, so
+// segments never exceed a host's limit and files never wait on each
+// other's segments; without spare slots the file is fetched whole.
+func (c *Client) downloadSegments(ctx context.Context, product Product, host, downloadURL string, total int64, partPath, destPath string) error {
+	segmentsPath := segmentsPath(partPath)
+	file, err := os.OpenFile(segmentsPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("asf: create file %q: %w", segmentsPath, err)
+	}
+	if err := file.Truncate(total); err != nil {
+		file.Close()
+		os.Remove(segmentsPath)
+		return fmt.Errorf("asf: save file %q: %w", destPath, err)
+	}
+
+	parts := int64(c.segmentParts)
+	if total < c.segmentMinSize {
+		parts = 1
+	}
+	extra, release := c.tryAcquireHost(host, int(parts)-1)
+	defer release()
+	parts = int64(extra) + 1
+	size := max((total+parts-1)/parts, 1)
+
+	var progress *syncWriter
+	if c.progress != nil {
+		progress = &syncWriter{w: &progressWriter{report: c.progress, progress: Progress{
+			FileName: product.Properties.FileName,
+			Total:    total,
+		}}}
+		c.progress(progress.w.progress)
+	}
+
+	g, segmentCtx := errgroup.WithContext(ctx)
+	for start := int64(0); start < total; start += size {
+		end := min(start+size, total) - 1
+		g.Go(func() error {
+			return c.downloadSegment(segmentCtx, product, downloadURL, file, start, end, progress)
+		})
+	}
+	err = g.Wait()
+	if err == nil {
+		if err = file.Sync(); err != nil {
+			err = fmt.Errorf("asf: save file %q: %w", destPath, err)
+		}
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("asf: save file %q: %w", destPath, closeErr)
+	}
+	if err == nil {
+		if err = os.Rename(segmentsPath, partPath); err != nil {
+			err = fmt.Errorf("asf: save file %q: %w", destPath, err)
+		}
+	}
+	if err != nil {
+		os.Remove(segmentsPath)
+		return err
+	}
+	if err := c.finishDownload(ctx, product, partPath, destPath); err != nil {
+		return err
+	}
+	if progress != nil {
+		progress.done()
+	}
+	return nil
+}
+
+// segmentsPath returns the file segments of partPath are written to. Its
+// holes make it unfit for resuming, so it is kept apart from partPath.
+func segmentsPath(partPath string) string {
+	return strings.TrimSuffix(partPath, ".part") + ".segments.part"
+}
+
+// downloadSegment fetches the inclusive byte range start-end of a file into
+// the same range of file.
+func (c *Client) downloadSegment(ctx context.Context, product Product, downloadURL string, file *os.File, start, end int64, progress *syncWriter) error {
+	req, err := c.newFileRequest(ctx, downloadURL)
+	if err != nil {
+		return fmt.Errorf("asf: create download request for %q: %w", product.Properties.FileName, err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("asf: send download request for %q: %w", product.Properties.FileName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("asf: unexpected download status for %q: %w", product.Properties.FileName, c.statusError(resp, body))
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-%d/", start, end)) {
+		return fmt.Errorf("asf: unexpected content range for %q: %q", product.Properties.FileName, resp.Header.Get("Content-Range"))
+	}
+
+	dst := io.Writer(io.NewOffsetWriter(file, start))
+	if progress != nil {
+		dst = io.MultiWriter(dst, progress)
+	}
+	want := end - start + 1
+	n, err := io.Copy(dst, io.LimitReader(resp.Body, want))
+	c.usage.bytesDownloaded.Add(n)
+	if err == nil && n < want {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return fmt.Errorf("asf: download %q bytes %d-%d: %w", product.Properties.FileName, start, end, err)
+	}
+	return nil
+}
+
+// syncWriter serializes the progress reports of concurrent segments.
+type syncWriter struct {
+	mu sync.Mutex
+	w  *progressWriter
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// done reports the file as complete.
+func (w *syncWriter) done() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.w.progress.Done = true
+	w.w.report(w.w.progress)
+}
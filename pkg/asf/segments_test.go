@@ -0,0 +1,118 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSegmentedDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	var mu sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		if r.URL.Path == "/plain.zip" {
+			// A host without Range support sends the whole file.
+			w.Write(content)
+			return
+		}
+		http.ServeContent(w, r, "big.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	client := NewClient(WithSegmentedDownloads(4, 1024))
+	if err := client.DownloadURLs(context.Background(), targetDir, server.URL+"/big.zip", server.URL+"/plain.zip"); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	for _, name := range []string{"big.zip", "plain.zip"} {
+		got, err := os.ReadFile(filepath.Join(targetDir, name))
+		if err != nil || !bytes.Equal(got, content) {
+			t.Fatalf("unexpected content of %s: %d bytes (err %v)", name, len(got), err)
+		}
+	}
+
+	segments := 0
+	for _, r := range ranges {
+		if r != "bytes=0-0" {
+			segments++
+		}
+	}
+	if segments != 4 {
+		t.Fatalf("expected 4 segment requests, got %v", ranges)
+	}
+}
+
+func TestSegmentedDownloadFailureRemovesPartial(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Range"), "bytes=3072-") {
+			http.Error(w, "boom", http.StatusForbidden)
+			return
+		}
+		http.ServeContent(w, r, "f.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	targetDir := t.TempDir()
+	client := NewClient(WithSegmentedDownloads(4, 0))
+	if err := client.DownloadURLs(context.Background(), targetDir, server.URL+"/f.zip"); err == nil {
+		t.Fatalf("expected an error for the failed segment")
+	}
+	for _, name := range []string{"f.zip", "f.zip.part", "f.zip.segments.part"} {
+		if _, err := os.Stat(filepath.Join(targetDir, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be absent, got %v", name, err)
+		}
+	}
+}
+
+func TestSegmentedDownloadInterruptedIsNotResumed(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 256)
+	targetDir := t.TempDir()
+	partPath := filepath.Join(targetDir, "f.zip.part")
+	var sawPart atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=0-0" {
+			// A process killed now must not leave a full-size .part behind.
+			if _, err := os.Stat(partPath); err == nil {
+				sawPart.Store(true)
+			}
+		}
+		http.ServeContent(w, r, "f.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	// A run killed mid-transfer leaves a preallocated, zero-filled segments
+	// file behind.
+	if err := os.WriteFile(filepath.Join(targetDir, "f.zip.segments.part"), make([]byte, len(content)), 0644); err != nil {
+		t.Fatalf("write segments file: %v", err)
+	}
+
+	client := NewClient(WithSegmentedDownloads(4, 0))
+	if err := client.DownloadURLs(context.Background(), targetDir, server.URL+"/f.zip"); err != nil {
+		t.Fatalf("DownloadURLs returned error: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(targetDir, "f.zip"))
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("unexpected content: %d bytes (err %v)", len(got), err)
+	}
+	if sawPart.Load() {
+		t.Fatalf("expected no .part file while segments were in flight")
+	}
+	for _, name := range []string{"f.zip.part", "f.zip.segments.part"} {
+		if _, err := os.Stat(filepath.Join(targetDir, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be absent, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package asf
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Sink receives search results incrementally. Begin is called once before
+// the first product, Write once per product and End once after the last,
+// whether or not the search succeeded. End receives the error that stopped
+// the search or a Write, or nil if every product was written, so that a
+// transactional sink can discard a partial result.
+type Sink interface {
+	Begin() error
+	Write(Product) error
+	End(err error) error
+}
+
+// SearchToSink runs a search, paging through every result as SearchIter
+// does, and writes each resulting product to sink, returning the number of
+// products written.
+func (c *Client) SearchToSink(ctx context.Context, opts SearchOptions, sink Sink) (n int, err error) {
+	if err := sink.Begin(); err != nil {
+		return 0, fmt.Errorf("asf: begin sink: %w", err)
+	}
+	defer func() {
+		if endErr := sink.End(err); endErr != nil && err == nil {
+			err = fmt.Errorf("asf: end sink: %w", endErr)
+		}
+	}()
+
+	for product, err := range c.SearchIter(ctx, opts) {
+		if err != nil {
+			return n, err
+		}
+		if err := sink.Write(product); err != nil {
+			return n, fmt.Errorf("asf: write %q to sink: %w", product.Properties.SceneName, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// JSONLSink writes one JSON-encoded product per line.
+type JSONLSink struct {
+	enc *json.Encoder
+}
+
+// NewJSONLSink returns a sink writing JSON Lines to w.
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{enc: json.NewEncoder(w)}
+}
+
+func (s *JSONLSink) Begin() error { return nil }
+
+func (s *JSONLSink) Write(p Product) error { return s.enc.Encode(p) }
+
+func (s *JSONLSink) End(error) error { return nil }
+
+// csvColumns lists the CSV header and how each value is derived; see
+// FormatCSV.
+var csvColumns = []struct {
+	name  string
+	value func(Properties) string
+}{
+	{"Granule Name", func(p Properties) string { return p.SceneName }},
+	{"Platform", func(p Properties) string { return p.Platform }},
+	{"Sensor", func(p Properties) string { return p.Sensor }},
+	{"Beam Mode", func(p Properties) string { return p.BeamModeType }},
+	{"Orbit", func(p Properties) string { return strconv.Itoa(p.Orbit) }},
+	{"Path Number", func(p Properties) string { return strconv.Itoa(p.PathNumber) }},
+	{"Frame Number", func(p Properties) string { return strconv.Itoa(p.FrameNumber) }},
+	{"Processing Date", func(p Properties) string { return formatCSVTime(p.ProcessingDate) }},
+	{"Processing Level", func(p Properties) string { return p.ProcessingLevel }},
+	{"Start Time", func(p Properties) string { return formatCSVTime(p.StartTime) }},
+	{"End Time", func(p Properties) string { return formatCSVTime(p.StopTime) }},
+	{"Center Lat", func(p Properties) string { return strconv.FormatFloat(p.CenterLat, 'f', -1, 64) }},
+	{"Center Lon", func(p Properties) string { return strconv.FormatFloat(p.CenterLon, 'f', -1, 64) }},
+	{"Ascending or Descending?", func(p Properties) string { return p.FlightDirection }},
+	{"URL", func(p Properties) string { return p.URL }},
+	{"Size (MB)", func(p Properties) string { return strconv.FormatFloat(float64(p.Bytes)/(1<<20), 'f', 2, 64) }},
+	{"GroupID", func(p Properties) string { return p.GroupID }},
+	{"MD5 Sum", func(p Properties) string { return p.Md5sum }},
+}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// CSVSink writes products as CSV rows with a header line, using the columns
+// described for FormatCSV.
+type CSVSink struct {
+	w *csv.Writer
+}
+
+// NewCSVSink returns a sink writing CSV to w.
+func NewCSVSink(w io.Writer) *CSVSink {
+	return &CSVSink{w: csv.NewWriter(w)}
+}
+
+func (s *CSVSink) Begin() error {
+	header := make([]string, len(csvColumns))
+	for i, col := range csvColumns {
+		header[i] = col.name
+	}
+	return s.w.Write(header)
+}
+
+func (s *CSVSink) Write(p Product) error {
+	row := make([]string, len(csvColumns))
+	for i, col := range csvColumns {
+		row[i] = col.value(p.Properties)
+	}
+	return s.w.Write(row)
+}
+
+// End flushes the rows written; a failed search leaves the rows before the
+// failure in place.
+func (s *CSVSink) End(error) error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// FormatCSV writes products to w as CSV with a header line. Its 18 columns
+// are a subset of the ASF API's CSV output, named as there: granule name,
+// platform, sensor, beam mode, orbit, path and frame numbers, processing date
+// and level, start and end times, center coordinates, flight direction, URL,
+// size, group ID and MD5 sum. The API's corner coordinates, off-nadir angle,
+// Doppler, stack size and baseline columns are not included.
+func FormatCSV(w io.Writer, products []Product) error {
+	sink := NewCSVSink(w)
+	if err := sink.Begin(); err != nil {
+		return err
+	}
+	for _, p := range products {
+		if err := sink.Write(p); err != nil {
+			return err
+		}
+	}
+	return sink.End(nil)
+}
+
+// SQLSink inserts products into a database table inside one transaction. It
+// works with SQLite and MySQL, or any database/sql driver that accepts "?"
+// placeholders and REPLACE INTO; the caller registers the driver and opens
+// the database. Rows are keyed by file ID and replaced when written again, so
+// a search can be re-run into an existing table. If the search or any Write
+// fails, End rolls the transaction back instead of committing, leaving the
+// table as it was.
+type SQLSink struct {
+	db     *sql.DB
+	table  string
+	tx     *sql.Tx
+	stmt   *sql.Stmt
+	failed bool
+}
+
+// NewSQLSink returns a sink writing to table, which Begin creates if needed.
+// The table name is an identifier, optionally qualified by a schema, such as
+// "products" or "archive.products"; Begin rejects anything else, since it is
+// interpolated into the SQL statements.
+func NewSQLSink(db *sql.DB, table string) *SQLSink {
+	return &SQLSink{db: db, table: table}
+}
+
+// sqlIdentifier matches the table names SQLSink accepts.
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+func (s *SQLSink) Begin() error {
+	if !sqlIdentifier.MatchString(s.table) {
+		return fmt.Errorf("asf: invalid table name %q", s.table)
+	}
+	create := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	file_id VARCHAR(255) PRIMARY KEY,
+	scene_name TEXT,
+	platform TEXT,
+	processing_level TEXT,
+	start_time TEXT,
+	stop_time TEXT,
+	processing_date TEXT,
+	url TEXT,
+	bytes BIGINT,
+	md5sum TEXT,
+	product TEXT
+)`, s.table)
+	if _, err := s.db.Exec(create); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(fmt.Sprintf(`REPLACE INTO %s
+	(file_id, scene_name, platform, processing_level, start_time, stop_time, processing_date, url, bytes, md5sum, product)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, s.table))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	s.tx, s.stmt, s.failed = tx, stmt, false
+	return nil
+}
+
+func (s *SQLSink) Write(p Product) (err error) {
+	defer func() {
+		if err != nil {
+			s.failed = true
+		}
+	}()
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	props := p.Properties
+	_, err = s.stmt.Exec(
+		props.FileID,
+		props.SceneName,
+		props.Platform,
+		props.ProcessingLevel,
+		formatCSVTime(props.StartTime),
+		formatCSVTime(props.StopTime),
+		formatCSVTime(props.ProcessingDate),
+		props.URL,
+		props.Bytes,
+		props.Md5sum,
+		string(data),
+	)
+	return err
+}
+
+func (s *SQLSink) End(err error) error {
+	if s.tx == nil {
+		return nil
+	}
+	tx := s.tx
+	s.stmt.Close()
+	s.tx, s.stmt = nil, nil
+	if s.failed || err != nil {
+		return tx.Rollback()
+	}
+	return tx.Commit()
+}
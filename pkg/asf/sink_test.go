@@ -0,0 +1,251 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSearchToSink(t *testing.T) {
+	payloadBytes, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("failed to read asf_response.json: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payloadBytes)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+
+	t.Run("JSONL", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := client.SearchToSink(context.Background(), SearchOptions{}, NewJSONLSink(&buf))
+		if err != nil {
+			t.Fatalf("SearchToSink returned error: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if n != 2 || len(lines) != 2 {
+			t.Fatalf("expected 2 products and lines, got %d and %d", n, len(lines))
+		}
+		var p Product
+		if err := json.Unmarshal([]byte(lines[0]), &p); err != nil {
+			t.Fatalf("decode line: %v", err)
+		}
+		if p.Properties.SceneName != "S1C_IW_SLC__1SDV_20251028T021014_20251028T021042_004756_00963D_8B2E" {
+			t.Fatalf("unexpected scene name: %s", p.Properties.SceneName)
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := client.SearchToSink(context.Background(), SearchOptions{}, NewCSVSink(&buf)); err != nil {
+			t.Fatalf("SearchToSink returned error: %v", err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("parse csv: %v", err)
+		}
+		if len(records) != 3 {
+			t.Fatalf("expected header and 2 rows, got %d records", len(records))
+		}
+		if records[0][0] != "Granule Name" || records[1][0] != "S1C_IW_SLC__1SDV_20251028T021014_20251028T021042_004756_00963D_8B2E" {
+			t.Fatalf("unexpected csv content: %v", records[:2])
+		}
+	})
+}
+
+// recordingDB is a database/sql connector that records executed statements
+// and how transactions end, for SQLSink tests.
+type recordingDB struct {
+	statements         []string
+	commits, rollbacks int
+	failInserts        bool
+	// rows counts committed rows; pending counts those of the open
+	// transaction.
+	rows, pending int
+}
+
+func (d *recordingDB) Connect(context.Context) (driver.Conn, error) { return recordingConn{d}, nil }
+func (d *recordingDB) Driver() driver.Driver                        { return nil }
+
+type recordingConn struct{ db *recordingDB }
+
+func (c recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return recordingStmt{c.db, query}, nil
+}
+func (c recordingConn) Close() error              { return nil }
+func (c recordingConn) Begin() (driver.Tx, error) { return recordingTx{c.db}, nil }
+
+type recordingTx struct{ db *recordingDB }
+
+func (tx recordingTx) Commit() error {
+	tx.db.commits++
+	tx.db.rows, tx.db.pending = tx.db.rows+tx.db.pending, 0
+	return nil
+}
+
+func (tx recordingTx) Rollback() error {
+	tx.db.rollbacks++
+	tx.db.pending = 0
+	return nil
+}
+
+type recordingStmt struct {
+	db    *recordingDB
+	query string
+}
+
+func (s recordingStmt) Close() error  { return nil }
+func (s recordingStmt) NumInput() int { return -1 }
+
+func (s recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	verb := strings.Fields(s.query)[0]
+	if verb == "REPLACE" {
+		if s.db.failInserts {
+			return nil, errors.New("constraint failed")
+		}
+		s.db.pending++
+	}
+	s.db.statements = append(s.db.statements, verb)
+	return driver.RowsAffected(1), nil
+}
+
+func (s recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestSQLSink(t *testing.T) {
+	payloadBytes, err := os.ReadFile("asf_response.json")
+	if err != nil {
+		t.Fatalf("failed to read asf_response.json: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payloadBytes)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+
+	t.Run("Commit", func(t *testing.T) {
+		rec := &recordingDB{}
+		n, err := client.SearchToSink(context.Background(), SearchOptions{}, NewSQLSink(sql.OpenDB(rec), "archive.products"))
+		if err != nil {
+			t.Fatalf("SearchToSink returned error: %v", err)
+		}
+		if n != 2 || strings.Join(rec.statements, ",") != "CREATE,REPLACE,REPLACE" || rec.commits != 1 || rec.rows != 2 {
+			t.Fatalf("unexpected database activity: %d written, %+v", n, rec)
+		}
+	})
+
+	t.Run("RollbackAfterWriteError", func(t *testing.T) {
+		rec := &recordingDB{failInserts: true}
+		if _, err := client.SearchToSink(context.Background(), SearchOptions{}, NewSQLSink(sql.OpenDB(rec), "products")); err == nil {
+			t.Fatal("expected the failed insert to be reported")
+		}
+		if rec.commits != 0 || rec.rollbacks != 1 {
+			t.Fatalf("expected a rollback and no commit, got %+v", rec)
+		}
+	})
+
+	t.Run("RollbackAfterSearchError", func(t *testing.T) {
+		var pages []string
+		paged := pagedServer(t, 5, &pages)
+		defer paged.Close()
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "2" {
+				http.Error(w, "boom", http.StatusInternalServerError)
+				return
+			}
+			paged.Config.Handler.ServeHTTP(w, r)
+		}))
+		defer failing.Close()
+
+		rec := &recordingDB{}
+		_, err := NewClient(WithBaseURL(failing.URL)).SearchToSink(context.Background(), SearchOptions{PageSize: 2}, NewSQLSink(sql.OpenDB(rec), "products"))
+		if err == nil {
+			t.Fatal("expected the failed page to be reported")
+		}
+		if rec.commits != 0 || rec.rollbacks != 1 || rec.rows != 0 {
+			t.Fatalf("expected the first page to be rolled back, got %+v", rec)
+		}
+	})
+
+	t.Run("InvalidTableName", func(t *testing.T) {
+		rec := &recordingDB{}
+		_, err := client.SearchToSink(context.Background(), SearchOptions{}, NewSQLSink(sql.OpenDB(rec), "products; DROP TABLE users"))
+		if err == nil || len(rec.statements) != 0 {
+			t.Fatalf("expected the table name to be rejected before any statement, got %v and %v", err, rec.statements)
+		}
+	})
+}
+
+func TestSearchToSinkPagesThroughResults(t *testing.T) {
+	var pages []string
+	server := pagedServer(t, 5, &pages)
+	defer server.Close()
+
+	sink := &recordingSink{}
+	n, err := NewClient(WithBaseURL(server.URL)).SearchToSink(context.Background(), SearchOptions{PageSize: 2}, sink)
+	if err != nil {
+		t.Fatalf("SearchToSink returned error: %v", err)
+	}
+	if n != 5 || sink.written != 5 || len(pages) != 3 {
+		t.Fatalf("expected 5 products from 3 pages, got %d from %v", n, pages)
+	}
+}
+
+type recordingSink struct {
+	began, ended bool
+	written      int
+}
+
+func (s *recordingSink) Begin() error          { s.began = true; return nil }
+func (s *recordingSink) Write(p Product) error { s.written++; return nil }
+func (s *recordingSink) End(error) error       { s.ended = true; return nil }
+
+func TestSearchToSinkEndsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := &recordingSink{}
+	_, err := NewClient(WithBaseURL(server.URL)).SearchToSink(context.Background(), SearchOptions{}, sink)
+	if err == nil {
+		t.Fatalf("expected search error")
+	}
+	if !sink.began || !sink.ended || sink.written != 0 {
+		t.Fatalf("unexpected sink lifecycle: %+v", sink)
+	}
+}
+
+func TestFormatCSV(t *testing.T) {
+	products := []Product{{Properties: Properties{
+		SceneName: "S1A_TEST",
+		Platform:  "Sentinel-1A",
+		Bytes:     3 << 20,
+	}}}
+
+	var buf bytes.Buffer
+	if err := FormatCSV(&buf, products); err != nil {
+		t.Fatalf("FormatCSV returned error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != 2 || len(records[0]) != len(csvColumns) {
+		t.Fatalf("unexpected csv shape: %v", records)
+	}
+	if records[1][0] != "S1A_TEST" || records[1][1] != "Sentinel-1A" || records[1][15] != "3.00" {
+		t.Fatalf("unexpected csv row: %v", records[1])
+	}
+}
@@ -0,0 +1,111 @@
+package asf
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// AcquisitionMidpoint returns the middle of the acquisition window, or the
+// start time when no stop time is known.
+func (p Product) AcquisitionMidpoint() time.Time {
+	start, stop := p.Properties.StartTime, p.Properties.StopTime
+	if stop.IsZero() || stop.Before(start) {
+		return start
+	}
+	return start.Add(stop.Sub(start) / 2)
+}
+
+// LocalSolarTime returns the apparent solar time of day at the scene center
+// during acquisition, as an offset from local solar midnight in [0, 24h). It
+// accounts for the center longitude and the equation of time, which is what
+// analysts compare against sun-synchronous optical overpasses. It returns
+// zero when the product has no start time. The center longitude comes from
+// the centerLon property, or from the footprint when the product has no
+// center coordinates.
+func (p Product) LocalSolarTime() time.Duration {
+	mid := p.AcquisitionMidpoint()
+	if mid.IsZero() {
+		return 0
+	}
+	mid = mid.UTC()
+
+	utc := time.Duration(mid.Hour())*time.Hour +
+		time.Duration(mid.Minute())*time.Minute +
+		time.Duration(mid.Second())*time.Second +
+		time.Duration(mid.Nanosecond())
+	offset := time.Duration(p.centerLon() / 15 * float64(time.Hour))
+	solar := utc + offset + equationOfTime(mid.YearDay())
+
+	const day = 24 * time.Hour
+	solar %= day
+	if solar < 0 {
+		solar += day
+	}
+	return solar
+}
+
+// equationOfTime approximates the difference between apparent and mean solar
+// time for a day of the year, to within about a minute.
+func equationOfTime(yearDay int) time.Duration {
+	b := 2 * math.Pi * float64(yearDay-81) / 365
+	minutes := 9.87*math.Sin(2*b) - 7.53*math.Cos(b) - 1.5*math.Sin(b)
+	return time.Duration(minutes * float64(time.Minute))
+}
+
+// centerLon returns the scene center longitude, falling back to the centroid
+// of the footprint when the center coordinates are missing.
+func (p Product) centerLon() float64 {
+	if p.Properties.CenterLon != 0 || p.Properties.CenterLat != 0 {
+		return p.Properties.CenterLon
+	}
+	if g, err := p.ParseGeometry(); err == nil {
+		lon, _ := g.Centroid()
+		return lon
+	}
+	return 0
+}
+
+// ascendingNodeTimes lists the local solar time of the ascending node of the
+// sun-synchronous missions. Their passes over any latitude they reach occur
+// within six hours of the node time when ascending and more than six hours
+// from it when descending.
+var ascendingNodeTimes = map[string]time.Duration{
+	"Sentinel-1": 18 * time.Hour,
+	"RADARSAT-1": 18 * time.Hour,
+	"SMAP":       18 * time.Hour,
+	"ALOS":       22*time.Hour + 30*time.Minute,
+	"ERS-1":      22*time.Hour + 30*time.Minute,
+	"ERS-2":      22*time.Hour + 30*time.Minute,
+	"JERS-1":     22*time.Hour + 45*time.Minute,
+}
+
+// OrbitDirection reports whether the scene was acquired on the ascending or
+// descending part of the orbit. For sun-synchronous missions it is derived
+// from the local solar time at the scene center (see LocalSolarTime) and the
+// mission's ascending node time; for other platforms, or products without a
+// start time, it falls back to the flightDirection property. It returns an
+// empty value when the direction is unknown.
+func (p Product) OrbitDirection() FlightDirection {
+	platform := p.Properties.Platform
+	if strings.HasPrefix(platform, "Sentinel-1") {
+		platform = "Sentinel-1"
+	}
+	if node, ok := ascendingNodeTimes[platform]; ok && !p.Properties.StartTime.IsZero() {
+		const day = 24 * time.Hour
+		since := (p.LocalSolarTime() - node + day) % day
+		if since < 6*time.Hour || since >= 18*time.Hour {
+			return FlightDirectionAscending
+		}
+		return FlightDirectionDescending
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(p.Properties.FlightDirection)) {
+	case string(FlightDirectionAscending):
+		return FlightDirectionAscending
+	case string(FlightDirectionDescending):
+		return FlightDirectionDescending
+	default:
+		return ""
+	}
+}
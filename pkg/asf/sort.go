@@ -0,0 +1,77 @@
+package asf
+
+import (
+	"cmp"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SortKey names a product property that search results can be ordered by.
+type SortKey string
+
+const (
+	SortByStartTime      SortKey = "startTime"
+	SortByStopTime       SortKey = "stopTime"
+	SortByProcessingDate SortKey = "processingDate"
+	SortBySceneName      SortKey = "sceneName"
+	SortByPathNumber     SortKey = "pathNumber"
+	SortByFrameNumber    SortKey = "frameNumber"
+)
+
+// SortOrder is the direction of a sort; the zero value sorts ascending.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// setSortQuery adds the sort parameters for opts to q. Endpoints that do not
+// support sorting ignore them, so results are also sorted client-side.
+func setSortQuery(q url.Values, opts SearchOptions) {
+	if opts.SortBy == "" {
+		return
+	}
+	q.Set("sort", string(opts.SortBy))
+	if opts.SortOrder != "" {
+		q.Set("sortOrder", string(opts.SortOrder))
+	}
+}
+
+// sortProducts orders products in place by the sort key in opts. Products
+// with equal keys keep their relative order.
+func sortProducts(products []Product, opts SearchOptions) {
+	compare := productComparer(opts.SortBy)
+	if compare == nil {
+		return
+	}
+	descending := strings.EqualFold(string(opts.SortOrder), string(SortDescending))
+	slices.SortStableFunc(products, func(a, b Product) int {
+		if descending {
+			return compare(b.Properties, a.Properties)
+		}
+		return compare(a.Properties, b.Properties)
+	})
+}
+
+// productComparer returns the comparison for a sort key, or nil for keys
+// that cannot be sorted client-side.
+func productComparer(key SortKey) func(a, b Properties) int {
+	switch key {
+	case SortByStartTime:
+		return func(a, b Properties) int { return a.StartTime.Compare(b.StartTime) }
+	case SortByStopTime:
+		return func(a, b Properties) int { return a.StopTime.Compare(b.StopTime) }
+	case SortByProcessingDate:
+		return func(a, b Properties) int { return a.ProcessingDate.Compare(b.ProcessingDate) }
+	case SortBySceneName:
+		return func(a, b Properties) int { return cmp.Compare(a.SceneName, b.SceneName) }
+	case SortByPathNumber:
+		return func(a, b Properties) int { return cmp.Compare(a.PathNumber, b.PathNumber) }
+	case SortByFrameNumber:
+		return func(a, b Properties) int { return cmp.Compare(a.FrameNumber, b.FrameNumber) }
+	default:
+		return nil
+	}
+}
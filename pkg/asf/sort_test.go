@@ -0,0 +1,61 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchSortBy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("sort"); got != "startTime" {
+			t.Errorf("expected sort=startTime, got %q", got)
+		}
+		if got := r.URL.Query().Get("sortOrder"); got != "desc" {
+			t.Errorf("expected sortOrder=desc, got %q", got)
+		}
+		// Ignore the sort parameters, as endpoints without support do.
+		w.Write([]byte(`{"features":[
+			{"properties":{"sceneName":"A","startTime":"2024-01-01T00:00:00Z"}},
+			{"properties":{"sceneName":"C","startTime":"2024-03-01T00:00:00Z"}},
+			{"properties":{"sceneName":"B","startTime":"2024-02-01T00:00:00Z"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	opts := SearchOptions{SortBy: SortByStartTime, SortOrder: SortDescending}
+	for name, search := range map[string]func(context.Context, SearchOptions) ([]Product, error){
+		"Search":    client.Search,
+		"SearchAll": client.SearchAll,
+	} {
+		products, err := search(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+		var names string
+		for _, p := range products {
+			names += p.Properties.SceneName
+		}
+		if names != "CBA" {
+			t.Fatalf("%s: expected newest first, got %s", name, names)
+		}
+	}
+}
+
+func TestSortProductsAscendingByDefault(t *testing.T) {
+	products := []Product{
+		{Properties: Properties{SceneName: "b", PathNumber: 2}},
+		{Properties: Properties{SceneName: "a", PathNumber: 1}},
+	}
+	sortProducts(products, SearchOptions{SortBy: SortByPathNumber})
+	if products[0].Properties.SceneName != "a" {
+		t.Fatalf("expected ascending order, got %+v", products)
+	}
+
+	sortProducts(products, SearchOptions{SortBy: "unknown", SortOrder: SortDescending})
+	if products[0].Properties.SceneName != "a" {
+		t.Fatalf("expected unknown key to leave order unchanged, got %+v", products)
+	}
+}
@@ -0,0 +1,20 @@
+package asf
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Stack returns the InSAR baseline stack for a reference granule. Each
+// product carries its temporal and perpendicular baseline relative to the
+// reference, which itself appears with zero baselines.
+func (c *Client) Stack(ctx context.Context, reference string) ([]Product, error) {
+	if reference == "" {
+		return nil, fmt.Errorf("asf: stack reference is empty")
+	}
+	q := url.Values{}
+	q.Set("reference", reference)
+	q.Set("output", string(OutputGeoJSON))
+	return c.fetchProducts(ctx, q, OutputGeoJSON, false, "services", "search", "baseline")
+}
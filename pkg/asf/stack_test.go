@@ -0,0 +1,49 @@
+package asf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/search/baseline" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("reference"); got != "REF" {
+			t.Errorf("expected reference REF, got %q", got)
+		}
+		w.Write([]byte(`{"features":[
+			{"properties":{"sceneName":"REF","temporalBaseline":0,"perpendicularBaseline":0}},
+			{"properties":{"sceneName":"SEC","temporalBaseline":-12,"perpendicularBaseline":85.5}},
+			{"properties":{"sceneName":"UNKNOWN","temporalBaseline":24,"perpendicularBaseline":null}}
+		]}`))
+	}))
+	defer server.Close()
+
+	products, err := NewClient(WithBaseURL(server.URL)).Stack(context.Background(), "REF")
+	if err != nil {
+		t.Fatalf("Stack returned error: %v", err)
+	}
+	if len(products) != 3 {
+		t.Fatalf("expected 3 products, got %d", len(products))
+	}
+	sec := products[1].Properties
+	if sec.TemporalBaseline == nil || *sec.TemporalBaseline != -12 {
+		t.Fatalf("unexpected temporal baseline: %v", sec.TemporalBaseline)
+	}
+	if sec.PerpendicularBaseline == nil || *sec.PerpendicularBaseline != 85.5 {
+		t.Fatalf("unexpected perpendicular baseline: %v", sec.PerpendicularBaseline)
+	}
+	if products[2].Properties.PerpendicularBaseline != nil {
+		t.Fatalf("expected missing perpendicular baseline to stay nil")
+	}
+}
+
+func TestStackRequiresReference(t *testing.T) {
+	if _, err := NewClient().Stack(context.Background(), ""); err == nil {
+		t.Fatalf("expected error for empty reference")
+	}
+}
@@ -0,0 +1,66 @@
+package asf
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats summarizes a client's cumulative usage.
+type Stats struct {
+	// Requests counts every HTTP request sent, including searches, downloads,
+	// hedged duplicates and retries.
+	Requests int64
+	// Downloads counts files fully written to disk.
+	Downloads int64
+	// BytesDownloaded counts product bytes written to disk.
+	BytesDownloaded int64
+	// Throttled counts responses of 429 Too Many Requests or 503 Service
+	// Unavailable, including those that were retried successfully.
+	Throttled int64
+}
+
+// usage holds the live counters behind Stats.
+type usage struct {
+	requests        atomic.Int64
+	downloads       atomic.Int64
+	bytesDownloaded atomic.Int64
+	throttled       atomic.Int64
+	lastLog         atomic.Int64 // unix nanoseconds of the last stats log line
+}
+
+// WithStatsLogInterval logs the client's Stats at info level through the
+// configured logger at most once per interval, piggybacking on request
+// activity rather than running a background goroutine.
+func WithStatsLogInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.statsLogInterval = interval
+	}
+}
+
+// Stats returns the client's cumulative request and download counters.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests:        c.usage.requests.Load(),
+		Downloads:       c.usage.downloads.Load(),
+		BytesDownloaded: c.usage.bytesDownloaded.Load(),
+		Throttled:       c.usage.throttled.Load(),
+	}
+}
+
+// maybeLogStats emits a stats log line if the log interval has elapsed.
+func (c *Client) maybeLogStats() {
+	if c.statsLogInterval <= 0 {
+		return
+	}
+	now := c.now().UnixNano()
+	last := c.usage.lastLog.Load()
+	if now-last < int64(c.statsLogInterval) || !c.usage.lastLog.CompareAndSwap(last, now) {
+		return
+	}
+	stats := c.Stats()
+	c.log().Info("asf: client usage",
+		"requests", stats.Requests,
+		"downloads", stats.Downloads,
+		"bytes_downloaded", stats.BytesDownloaded,
+	)
+}
@@ -0,0 +1,48 @@
+package asf
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/search/param" {
+			w.Write([]byte(`{"features":[]}`))
+			return
+		}
+		w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithStatsLogInterval(time.Nanosecond),
+	)
+	if _, err := client.Search(context.Background(), SearchOptions{}); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	products := []Product{
+		{Properties: Properties{SceneName: "a", FileName: "a.zip", URL: server.URL + "/a.zip"}},
+		{Properties: Properties{SceneName: "b", FileName: "b.zip", URL: server.URL + "/b.zip"}},
+	}
+	if err := client.Download(context.Background(), t.TempDir(), products...); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+
+	want := Stats{Requests: 3, Downloads: 2, BytesDownloaded: 20}
+	if got := client.Stats(); got != want {
+		t.Fatalf("Stats() = %+v, want %+v", got, want)
+	}
+	if !strings.Contains(logs.String(), "asf: client usage") {
+		t.Fatalf("expected periodic usage log line, got %q", logs.String())
+	}
+}
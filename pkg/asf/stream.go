@@ -0,0 +1,162 @@
+package asf
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"sync"
+)
+
+// WithDownloadQueueSize sets how many products DownloadSeq pulls ahead of
+// the downloads in progress. Zero means the download concurrency. Raising it
+// lets a slow search page load without starving the workers, at the cost of
+// holding more products in memory.
+func WithDownloadQueueSize(n int) Option {
+	return func(c *Client) {
+		c.downloadQueueSize = n
+	}
+}
+
+// DownloadSeq downloads products as products yields them, such as the
+// results of SearchIter, so that downloads begin while later search pages
+// are still loading. Products are pulled only as the bounded queue (see
+// WithDownloadQueueSize) drains, so memory stays flat however many products
+// the sequence holds. Concurrency, batch mode and validation follow
+// Download; the folders are prepared and WithAuthWarmup runs once, for the
+// first product.
+//
+// If onResult is not nil it is called as each download finishes, from the
+// worker that ran it. Unlike DownloadReport, results do not carry a
+// Checksum, so saved files are not read back; use WithChecksumVerification
+// to check them. An error yielded by products stops the sequence; it is
+// returned once the queued downloads finish, joined with a *BatchError
+// listing the failed downloads, if any.
+func (c *Client) DownloadSeq(ctx context.Context, targetFolder string, products iter.Seq2[Product, error], onResult func(DownloadResult)) error {
+	ctx, done, err := c.beginDownload(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if err := c.createFolders(targetFolder); err != nil {
+		return err
+	}
+
+	workers := c.downloadWorkers()
+	queue := make(chan Product, cmp.Or(c.downloadQueueSize, workers))
+
+	batchCtx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+
+	var (
+		mu       sync.Mutex
+		failures []*ProductError
+		wg       sync.WaitGroup
+	)
+	fail := func(product Product, err error) {
+		mu.Lock()
+		failures = append(failures, &ProductError{Product: product, Err: err})
+		mu.Unlock()
+	}
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for product := range queue {
+				result := DownloadResult{Product: product, Err: context.Cause(batchCtx)}
+				if result.Err == nil {
+					result = c.downloadOne(batchCtx, targetFolder, product)
+				}
+				if onResult != nil {
+					onResult(result)
+				}
+				if result.Err != nil {
+					fail(product, result.Err)
+					if c.batchMode == FailFast {
+						abort(errBatchAborted)
+					}
+				}
+			}
+		}()
+	}
+
+	var seqErr error
+	total := 0
+	warmedUp := !c.authWarmup
+	for product, err := range products {
+		if err != nil {
+			seqErr = err
+			break
+		}
+		if !warmedUp && product.Properties.URL != "" {
+			warmedUp = true
+			if seqErr = c.WarmUp(batchCtx, product.Properties.URL); seqErr != nil {
+				break
+			}
+		}
+		total++
+		select {
+		case queue <- product:
+			continue
+		case <-batchCtx.Done():
+			fail(product, context.Cause(batchCtx))
+		}
+		break
+	}
+	close(queue)
+	wg.Wait()
+
+	batchErr := &BatchError{Total: total}
+	for _, failure := range failures {
+		// Products cut short by FailFast are not failures of their own.
+		aborted := errors.Is(context.Cause(batchCtx), errBatchAborted) && ctx.Err() == nil &&
+			(errors.Is(failure.Err, errBatchAborted) || errors.Is(failure.Err, context.Canceled))
+		if !aborted {
+			batchErr.Errors = append(batchErr.Errors, failure)
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return errors.Join(seqErr, batchErr)
+	}
+	return seqErr
+}
+
+// downloadOne downloads a single product for DownloadSeq, taking an adaptive
+// slot unless the product's host has a cap of its own, and re-downloading an
+// invalid file as WithInvalidFileRetries allows.
+func (c *Client) downloadOne(ctx context.Context, targetFolder string, product Product) DownloadResult {
+	result := DownloadResult{Product: product}
+	if c.adaptive != nil && (c.hostLimits == nil || c.hostLimits.semaphore(c.downloadHost(ctx, product)) == c.hostLimits.other) {
+		if err := c.adaptive.acquire(ctx); err != nil {
+			result.Err = err
+			return result
+		}
+		defer c.adaptive.release()
+	}
+
+	start := c.now()
+	result.Err = c.downloadProduct(ctx, targetFolder, product, &result)
+	for attempt := 0; result.Err == nil && result.Path != "" && attempt < c.invalidFileRetries && !validDownload(result.Path); attempt++ {
+		c.log().Warn("asf: re-downloading invalid file", "file", product.Properties.FileName, "attempt", attempt+1)
+		result.Err = c.downloadProduct(ctx, targetFolder, product, &result)
+	}
+	result.Duration = c.now().Sub(start)
+
+	switch {
+	case result.Err != nil:
+		if errors.Is(context.Cause(ctx), ErrClientClosed) {
+			result.Err = fmt.Errorf("%w: %w", ErrClientClosed, result.Err)
+		}
+	case result.Path == "":
+	case c.invalidFileRetries > 0 && !validDownload(result.Path):
+		result.Err = fmt.Errorf("asf: %q still invalid after %d re-download(s): %w",
+			product.Properties.FileName, c.invalidFileRetries, ErrInvalidDownload)
+	default:
+		if info, err := os.Stat(result.Path); err == nil {
+			result.Bytes = info.Size()
+		}
+	}
+	return result
+}
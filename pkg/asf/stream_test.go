@@ -0,0 +1,129 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDownloadSeqBackPressure(t *testing.T) {
+	var completed atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	const workers, queueSize, total = 2, 3, 20
+	var pulled atomic.Int32
+	products := func(yield func(Product, error) bool) {
+		for i := range total {
+			n := pulled.Add(1)
+			// One product may be held by the producer while the queue is full.
+			if ahead := n - completed.Load(); ahead > workers+queueSize+1 {
+				t.Errorf("pulled %d products ahead of finished downloads", ahead)
+			}
+			name := fmt.Sprintf("p%d.zip", i)
+			if !yield(Product{Properties: Properties{FileName: name, URL: server.URL + "/" + name}}, nil) {
+				return
+			}
+		}
+	}
+	go func() {
+		for range total {
+			release <- struct{}{}
+		}
+	}()
+
+	targetDir := t.TempDir()
+	client := NewClient(WithDownloadConcurrency(workers), WithDownloadQueueSize(queueSize))
+	err := client.DownloadSeq(context.Background(), targetDir, products, func(result DownloadResult) {
+		if result.Err != nil {
+			t.Errorf("download of %s failed: %v", result.Product.Properties.FileName, result.Err)
+		}
+		completed.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("DownloadSeq returned error: %v", err)
+	}
+	if got := completed.Load(); got != total {
+		t.Fatalf("expected %d results, got %d", total, got)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "p19.zip")); err != nil {
+		t.Fatalf("expected last product to be downloaded: %v", err)
+	}
+}
+
+func TestDownloadSeqErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	searchErr := errors.New("page 2 failed")
+	products := func(yield func(Product, error) bool) {
+		for _, name := range []string{"ok.zip", "missing.zip"} {
+			if !yield(Product{Properties: Properties{FileName: name, URL: server.URL + "/" + name}}, nil) {
+				return
+			}
+		}
+		yield(Product{}, searchErr)
+	}
+
+	err := NewClient().DownloadSeq(context.Background(), t.TempDir(), products, nil)
+	if !errors.Is(err, searchErr) {
+		t.Fatalf("expected the search error, got %v", err)
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || batchErr.Total != 2 || len(batchErr.Errors) != 1 {
+		t.Fatalf("expected one of two downloads to fail, got %v", err)
+	}
+	if name := batchErr.Errors[0].Product.Properties.FileName; name != "missing.zip" {
+		t.Fatalf("unexpected failed product %q", name)
+	}
+}
+
+func TestDownloadSeqWarmsUpOnce(t *testing.T) {
+	var warmUps atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") == "bytes=0-0" {
+			warmUps.Add(1)
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	products := func(yield func(Product, error) bool) {
+		for i := range 5 {
+			name := fmt.Sprintf("p%d.zip", i)
+			if !yield(Product{Properties: Properties{FileName: name, URL: server.URL + "/" + name}}, nil) {
+				return
+			}
+		}
+	}
+	var sizes []int64
+	client := NewClient(WithAuthWarmup(), WithDownloadConcurrency(1))
+	err := client.DownloadSeq(context.Background(), t.TempDir(), products, func(result DownloadResult) {
+		sizes = append(sizes, result.Bytes)
+	})
+	if err != nil {
+		t.Fatalf("DownloadSeq returned error: %v", err)
+	}
+	if got := warmUps.Load(); got != 1 {
+		t.Fatalf("expected a single warm-up request, got %d", got)
+	}
+	if len(sizes) != 5 || sizes[0] != 4 {
+		t.Fatalf("unexpected result sizes %v", sizes)
+	}
+}
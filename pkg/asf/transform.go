@@ -0,0 +1,28 @@
+package asf
+
+import "fmt"
+
+// Transformer rewrites a product in place as search results are decoded, for
+// example to rename fields or augment metadata. Returning an error fails the
+// search.
+type Transformer func(*Product) error
+
+// WithTransformers registers transformers applied, in order, to every product
+// returned by searches on the client.
+func WithTransformers(transformers ...Transformer) Option {
+	return func(c *Client) {
+		c.transformers = append(c.transformers, transformers...)
+	}
+}
+
+// transform applies the client's transformers to each product.
+func (c *Client) transform(products []Product) error {
+	for _, transformer := range c.transformers {
+		for i := range products {
+			if err := transformer(&products[i]); err != nil {
+				return fmt.Errorf("asf: transform %q: %w", products[i].Properties.SceneName, err)
+			}
+		}
+	}
+	return nil
+}
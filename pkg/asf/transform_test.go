@@ -0,0 +1,42 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransformers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"features":[{"properties":{"sceneName":"a","platform":"Sentinel-1A"}}]}`))
+	}))
+	defer server.Close()
+
+	upper := func(p *Product) error {
+		p.Properties.SceneName = strings.ToUpper(p.Properties.SceneName)
+		return nil
+	}
+	tag := func(p *Product) error {
+		p.Properties.GroupID = "tenant-42/" + p.Properties.SceneName
+		return nil
+	}
+	client := NewClient(WithBaseURL(server.URL), WithTransformers(upper, tag))
+
+	products, err := client.Search(context.Background(), SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	props := products[0].Properties
+	if props.SceneName != "A" || props.GroupID != "tenant-42/A" {
+		t.Fatalf("transformers not applied in order: %+v", props)
+	}
+
+	boom := errors.New("boom")
+	failing := NewClient(WithBaseURL(server.URL), WithTransformers(func(*Product) error { return boom }))
+	if _, err := failing.Search(context.Background(), SearchOptions{}); !errors.Is(err, boom) {
+		t.Fatalf("expected transformer error, got %v", err)
+	}
+}
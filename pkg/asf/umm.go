@@ -0,0 +1,169 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const defaultCMRURL = "https://cmr.earthdata.nasa.gov"
+
+// WithCMRURL overrides the NASA CMR host used by FetchUMM, for example to use
+// the UAT environment.
+func WithCMRURL(u string) Option {
+	return func(c *Client) {
+		c.cmrURL = u
+	}
+}
+
+// ErrGranuleNotFound reports that CMR has no record of the requested granule.
+var ErrGranuleNotFound = errors.New("asf: granule not found in CMR")
+
+// UMMGranule is the subset of a CMR UMM-G record that the search API's
+// GeoJSON output leaves out.
+type UMMGranule struct {
+	// ConceptID is CMR's identifier, such as G1234567890-ASF.
+	ConceptID           string                   `json:"-"`
+	GranuleUR           string                   `json:"GranuleUR"`
+	CollectionReference UMMCollectionReference   `json:"CollectionReference"`
+	TemporalExtent      UMMTemporalExtent        `json:"TemporalExtent"`
+	DataGranule         UMMDataGranule           `json:"DataGranule"`
+	RelatedURLs         []UMMRelatedURL          `json:"RelatedUrls"`
+	OrbitDomains        []UMMOrbitDomain         `json:"OrbitCalculatedSpatialDomains"`
+	Platforms           []UMMPlatform            `json:"Platforms"`
+	AdditionalAttribute []UMMAdditionalAttribute `json:"AdditionalAttributes"`
+}
+
+type UMMCollectionReference struct {
+	ShortName  string `json:"ShortName"`
+	Version    string `json:"Version"`
+	EntryTitle string `json:"EntryTitle"`
+}
+
+type UMMTemporalExtent struct {
+	RangeDateTime struct {
+		BeginningDateTime time.Time `json:"BeginningDateTime"`
+		EndingDateTime    time.Time `json:"EndingDateTime"`
+	} `json:"RangeDateTime"`
+}
+
+type UMMDataGranule struct {
+	ProductionDateTime time.Time     `json:"ProductionDateTime"`
+	DayNightFlag       string        `json:"DayNightFlag"`
+	Files              []UMMFileInfo `json:"ArchiveAndDistributionInformation"`
+}
+
+// UMMFileInfo describes one file of the granule, with its checksum.
+type UMMFileInfo struct {
+	Name     string      `json:"Name"`
+	Size     float64     `json:"Size"`
+	SizeUnit string      `json:"SizeUnit"`
+	Checksum UMMChecksum `json:"Checksum"`
+}
+
+// UMMChecksum is a file checksum; Algorithm is for example "SHA-256" or
+// "MD5".
+type UMMChecksum struct {
+	Value     string `json:"Value"`
+	Algorithm string `json:"Algorithm"`
+}
+
+type UMMRelatedURL struct {
+	URL         string `json:"URL"`
+	Type        string `json:"Type"`
+	Subtype     string `json:"Subtype"`
+	Description string `json:"Description"`
+}
+
+type UMMOrbitDomain struct {
+	OrbitNumber      int `json:"OrbitNumber"`
+	BeginOrbitNumber int `json:"BeginOrbitNumber"`
+	EndOrbitNumber   int `json:"EndOrbitNumber"`
+}
+
+type UMMPlatform struct {
+	ShortName   string `json:"ShortName"`
+	Instruments []struct {
+		ShortName string `json:"ShortName"`
+	} `json:"Instruments"`
+}
+
+type UMMAdditionalAttribute struct {
+	Name   string   `json:"Name"`
+	Values []string `json:"Values"`
+}
+
+// Attribute returns the first value of the named additional attribute, or ""
+// if it is absent.
+func (g UMMGranule) Attribute(name string) string {
+	for _, attr := range g.AdditionalAttribute {
+		if strings.EqualFold(attr.Name, name) && len(attr.Values) > 0 {
+			return attr.Values[0]
+		}
+	}
+	return ""
+}
+
+// Checksum returns the checksum of the named file using the given algorithm,
+// such as "SHA-256", or "" if CMR does not publish one.
+func (g UMMGranule) Checksum(fileName, algorithm string) string {
+	for _, f := range g.DataGranule.Files {
+		if f.Name == fileName && strings.EqualFold(f.Checksum.Algorithm, algorithm) {
+			return f.Checksum.Value
+		}
+	}
+	return ""
+}
+
+var conceptIDPattern = regexp.MustCompile(`^G\d+-[A-Z0-9_]+$`)
+
+// FetchUMM queries NASA CMR for the full UMM-G record of a granule, given
+// either its concept ID (G1234567890-ASF) or its ASF granule name. If a name
+// matches several records, the first is returned.
+func (c *Client) FetchUMM(ctx context.Context, id string) (UMMGranule, error) {
+	q := url.Values{}
+	if conceptIDPattern.MatchString(id) {
+		q.Set("concept_id", id)
+	} else {
+		q.Set("provider", "ASF")
+		q.Set("readable_granule_name", id)
+	}
+	return c.fetchUMM(ctx, q, id)
+}
+
+// productUMM fetches the UMM-G record of a product's own file, matching CMR's
+// granule UR against the file ID when it is known.
+func (c *Client) productUMM(ctx context.Context, p Product) (UMMGranule, error) {
+	if p.Properties.FileID == "" {
+		return c.FetchUMM(ctx, p.Properties.SceneName)
+	}
+	q := url.Values{}
+	q.Set("provider", "ASF")
+	q.Set("granule_ur", p.Properties.FileID)
+	return c.fetchUMM(ctx, q, p.Properties.FileID)
+}
+
+// fetchUMM runs a CMR granule query and returns its first record.
+func (c *Client) fetchUMM(ctx context.Context, q url.Values, id string) (UMMGranule, error) {
+	var payload struct {
+		Items []struct {
+			Meta struct {
+				ConceptID string `json:"concept-id"`
+			} `json:"meta"`
+			UMM UMMGranule `json:"umm"`
+		} `json:"items"`
+	}
+	if err := c.getJSONFrom(ctx, c.cmrURL, q, &payload, "search", "granules.umm_json"); err != nil {
+		return UMMGranule{}, err
+	}
+	if len(payload.Items) == 0 {
+		return UMMGranule{}, fmt.Errorf("%w: %s", ErrGranuleNotFound, id)
+	}
+	granule := payload.Items[0].UMM
+	granule.ConceptID = payload.Items[0].Meta.ConceptID
+	return granule, nil
+}
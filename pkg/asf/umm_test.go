@@ -0,0 +1,61 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchUMM(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/granules.umm_json" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("concept_id") == "G1-ASF" || q.Get("readable_granule_name") == "S1A_SCENE" {
+			w.Write([]byte(`{"hits":1,"items":[{"meta":{"concept-id":"G1-ASF"},"umm":{
+				"GranuleUR": "S1A_SCENE-SLC",
+				"CollectionReference": {"ShortName": "SENTINEL-1A_SLC", "Version": "1"},
+				"TemporalExtent": {"RangeDateTime": {"BeginningDateTime": "2024-05-01T10:00:00.000Z", "EndingDateTime": "2024-05-01T10:00:30.000Z"}},
+				"DataGranule": {"ProductionDateTime": "2024-05-01T12:00:00Z", "ArchiveAndDistributionInformation": [
+					{"Name": "S1A_SCENE.zip", "Size": 4.2, "SizeUnit": "GB", "Checksum": {"Value": "abc123", "Algorithm": "SHA-256"}}
+				]},
+				"RelatedUrls": [{"URL": "https://datapool.asf.alaska.edu/SLC/SA/S1A_SCENE.zip", "Type": "GET DATA"}],
+				"OrbitCalculatedSpatialDomains": [{"OrbitNumber": 53000}],
+				"AdditionalAttributes": [{"Name": "PATH_NUMBER", "Values": ["35"]}]
+			}}]}`))
+			return
+		}
+		w.Write([]byte(`{"hits":0,"items":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithCMRURL(server.URL))
+	for _, id := range []string{"G1-ASF", "S1A_SCENE"} {
+		granule, err := client.FetchUMM(context.Background(), id)
+		if err != nil {
+			t.Fatalf("FetchUMM(%q) returned error: %v", id, err)
+		}
+		if granule.ConceptID != "G1-ASF" || granule.GranuleUR != "S1A_SCENE-SLC" {
+			t.Fatalf("unexpected identity %q %q", granule.ConceptID, granule.GranuleUR)
+		}
+		if got := granule.Checksum("S1A_SCENE.zip", "sha-256"); got != "abc123" {
+			t.Fatalf("Checksum = %q, want abc123", got)
+		}
+		if got := granule.Attribute("PATH_NUMBER"); got != "35" {
+			t.Fatalf("Attribute = %q, want 35", got)
+		}
+		if len(granule.OrbitDomains) != 1 || granule.OrbitDomains[0].OrbitNumber != 53000 || len(granule.RelatedURLs) != 1 {
+			t.Fatalf("unexpected orbit or URLs: %+v", granule)
+		}
+		if granule.TemporalExtent.RangeDateTime.BeginningDateTime.IsZero() {
+			t.Fatalf("expected temporal extent to be parsed")
+		}
+	}
+
+	if _, err := client.FetchUMM(context.Background(), "S1A_MISSING"); !errors.Is(err, ErrGranuleNotFound) {
+		t.Fatalf("expected ErrGranuleNotFound, got %v", err)
+	}
+}
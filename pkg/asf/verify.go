@@ -0,0 +1,38 @@
+package asf
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+)
+
+// VerifyZip reads every entry of the zip archive at path and checks it
+// against the CRC-32 recorded in the archive, without extracting anything to
+// disk. It catches corruption in files downloaded before checksum
+// verification was enabled. Failures wrap ErrCorruptArchive.
+func VerifyZip(path string) error {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCorruptArchive, err)
+	}
+	defer archive.Close()
+
+	for _, entry := range archive.File {
+		if err := verifyZipEntry(entry); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrCorruptArchive, entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// verifyZipEntry decompresses an entry, letting archive/zip compare its
+// CRC-32 once the end of the entry is reached.
+func verifyZipEntry(entry *zip.File) error {
+	r, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = io.Copy(io.Discard, r)
+	return err
+}
@@ -0,0 +1,50 @@
+package asf
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	// Store the entry uncompressed so its bytes can be corrupted in place.
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "measurement.tiff", Method: zip.Store})
+	if err != nil {
+		t.Fatalf("create entry: %v", err)
+	}
+	w.Write([]byte("radar backscatter"))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.zip")
+	if err := os.WriteFile(good, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("write zip: %v", err)
+	}
+	if err := VerifyZip(good); err != nil {
+		t.Fatalf("expected valid archive, got %v", err)
+	}
+
+	corrupt := bytes.Replace(buf.Bytes(), []byte("backscatter"), []byte("backscattex"), 1)
+	bad := filepath.Join(dir, "bad.zip")
+	if err := os.WriteFile(bad, corrupt, 0644); err != nil {
+		t.Fatalf("write zip: %v", err)
+	}
+	if err := VerifyZip(bad); !errors.Is(err, ErrCorruptArchive) || !errors.Is(err, zip.ErrChecksum) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+
+	truncated := filepath.Join(dir, "truncated.zip")
+	if err := os.WriteFile(truncated, buf.Bytes()[:buf.Len()/2], 0644); err != nil {
+		t.Fatalf("write zip: %v", err)
+	}
+	if err := VerifyZip(truncated); !errors.Is(err, ErrCorruptArchive) {
+		t.Fatalf("expected corrupt archive error, got %v", err)
+	}
+}
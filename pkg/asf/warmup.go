@@ -0,0 +1,73 @@
+package asf
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WithAuthWarmup makes Download authenticate once, against the first
+// product's URL, before starting its workers. The Earthdata login redirects
+// then happen a single time and the resulting cookies are shared by every
+// worker, and bad credentials fail the batch up front with one clear error
+// instead of once per product.
+func WithAuthWarmup() Option {
+	return func(c *Client) {
+		c.authWarmup = true
+	}
+}
+
+// WarmUp performs the login exchange for a download URL by requesting its
+// first byte, following redirects and storing any session cookies in the
+// client's jar. It reports ErrAuthFailed when the server rejects the
+// credentials and ErrAuthRedirect when it answers with a login page.
+func (c *Client) WarmUp(ctx context.Context, rawURL string) error {
+	if c.urlRewriter != nil {
+		rewritten, err := c.urlRewriter(rawURL)
+		if err != nil {
+			return fmt.Errorf("asf: rewrite URL %q: %w", rawURL, err)
+		}
+		rawURL = rewritten
+	}
+	req, err := c.newDownloadRequest(ctx, rawURL)
+	if err != nil {
+		return fmt.Errorf("asf: create warm-up request for %q: %w", rawURL, err)
+	}
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("asf: warm-up request for %q: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	body := bufio.NewReader(resp.Body)
+	defer io.Copy(io.Discard, io.LimitReader(body, 1<<10))
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent, http.StatusRequestedRangeNotSatisfiable:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("asf: warm-up for %q: status %d: %w", rawURL, resp.StatusCode, ErrAuthFailed)
+	default:
+		return fmt.Errorf("asf: warm-up for %q: %w", rawURL, c.statusError(resp, nil))
+	}
+	if isHTMLResponse(resp, body) {
+		return fmt.Errorf("asf: warm-up for %q: %w", rawURL, ErrAuthRedirect)
+	}
+	return nil
+}
+
+// warmUp authenticates against the first product with a URL, if the client
+// was configured with WithAuthWarmup.
+func (c *Client) warmUp(ctx context.Context, products []Product) error {
+	if !c.authWarmup {
+		return nil
+	}
+	for _, product := range products {
+		if product.Properties.URL != "" {
+			return c.WarmUp(ctx, product.Properties.URL)
+		}
+	}
+	return nil
+}
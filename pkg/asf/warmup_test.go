@@ -0,0 +1,67 @@
+package asf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDownloadAuthWarmupLogsInOnce(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			logins.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "ok", Path: "/"})
+			http.Redirect(w, r, r.URL.Query().Get("next"), http.StatusFound)
+			return
+		}
+		if _, err := r.Cookie("session"); err != nil {
+			http.Redirect(w, r, "/login?next="+r.URL.Path, http.StatusFound)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	var products []Product
+	for i := range 4 {
+		name := fmt.Sprintf("file-%d.zip", i)
+		products = append(products, Product{Properties: Properties{URL: server.URL + "/" + name, FileName: name}})
+	}
+	client := NewClient(WithAuthWarmup(), WithDownloadConcurrency(4))
+	if err := client.Download(context.Background(), t.TempDir(), products...); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if got := logins.Load(); got != 1 {
+		t.Fatalf("logins = %d, want 1", got)
+	}
+}
+
+func TestDownloadAuthWarmupFailsFast(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "bad credentials", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	products := []Product{
+		{Properties: Properties{URL: server.URL + "/a.zip", FileName: "a.zip"}},
+		{Properties: Properties{URL: server.URL + "/b.zip", FileName: "b.zip"}},
+	}
+	err := NewClient(WithAuthWarmup()).Download(context.Background(), t.TempDir(), products...)
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("expected ErrAuthFailed, got %v", err)
+	}
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		t.Fatalf("expected a single warm-up error, got batch error %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("requests = %d, want 1", got)
+	}
+}
@@ -0,0 +1,147 @@
+package asf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseWKT parses a WKT POLYGON or MULTIPOLYGON, the forms accepted by
+// SearchOptions.IntersectsWith for areas. Z and M values are dropped.
+func ParseWKT(s string) (Geometry, error) {
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+	var geometry Geometry
+	var depth int
+	switch {
+	case strings.HasPrefix(upper, "MULTIPOLYGON"):
+		geometry.Type, depth, s = "MultiPolygon", 3, s[len("MULTIPOLYGON"):]
+	case strings.HasPrefix(upper, "POLYGON"):
+		geometry.Type, depth, s = "Polygon", 2, s[len("POLYGON"):]
+	default:
+		return Geometry{}, fmt.Errorf("asf: unsupported WKT geometry %q", truncateWKT(s))
+	}
+	s = strings.TrimSpace(s)
+	for _, suffix := range []string{"ZM", "Z", "M"} {
+		if len(s) > len(suffix) && strings.EqualFold(s[:len(suffix)], suffix) && (s[len(suffix)] == ' ' || s[len(suffix)] == '(') {
+			s = strings.TrimSpace(s[len(suffix):])
+			break
+		}
+	}
+	if strings.EqualFold(s, "EMPTY") {
+		return geometry, nil
+	}
+
+	p := &wktParser{s: s}
+	list, err := p.parseList(depth)
+	if err != nil {
+		return Geometry{}, fmt.Errorf("asf: invalid WKT: %w", err)
+	}
+	if p.skipSpace(); p.pos < len(p.s) {
+		return Geometry{}, fmt.Errorf("asf: invalid WKT: unexpected %q at offset %d", p.s[p.pos:], p.pos)
+	}
+	if depth == 2 {
+		geometry.Polygons = []Polygon{toPolygon(list)}
+	} else {
+		for _, polygon := range list.children {
+			geometry.Polygons = append(geometry.Polygons, toPolygon(polygon))
+		}
+	}
+	return geometry, nil
+}
+
+// wktList is a parenthesized WKT list; leaves hold positions.
+type wktList struct {
+	children  []wktList
+	positions []Position
+}
+
+func toPolygon(list wktList) Polygon {
+	polygon := make(Polygon, len(list.children))
+	for i, ring := range list.children {
+		polygon[i] = Ring(ring.positions)
+	}
+	return polygon
+}
+
+// wktParser reads nested coordinate lists.
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\r\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *wktParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.s) || p.s[p.pos] != c {
+		return fmt.Errorf("expected %q at offset %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// parseList parses a list nested depth levels deep; depth 1 is a list of
+// positions.
+func (p *wktParser) parseList(depth int) (wktList, error) {
+	var list wktList
+	if err := p.expect('('); err != nil {
+		return list, err
+	}
+	for {
+		if depth > 1 {
+			child, err := p.parseList(depth - 1)
+			if err != nil {
+				return list, err
+			}
+			list.children = append(list.children, child)
+		} else {
+			position, err := p.parsePosition()
+			if err != nil {
+				return list, err
+			}
+			list.positions = append(list.positions, position)
+		}
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		return list, p.expect(')')
+	}
+}
+
+// parsePosition reads "x y" followed by optional further ordinates.
+func (p *wktParser) parsePosition() (Position, error) {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.s) && p.s[end] != ',' && p.s[end] != ')' {
+		end++
+	}
+	fields := strings.Fields(p.s[p.pos:end])
+	if len(fields) < 2 || len(fields) > 4 {
+		return Position{}, fmt.Errorf("invalid coordinate %q", strings.TrimSpace(p.s[p.pos:end]))
+	}
+	x, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Position{}, fmt.Errorf("invalid coordinate %q: %w", fields[0], err)
+	}
+	y, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return Position{}, fmt.Errorf("invalid coordinate %q: %w", fields[1], err)
+	}
+	p.pos = end
+	return Position{x, y}, nil
+}
+
+// truncateWKT shortens WKT for error messages.
+func truncateWKT(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
@@ -0,0 +1,173 @@
+// Package asfsync fetches only the ASF products processed since the previous
+// run of a search, tracking a processing-date cursor in a state file. It
+// suits cron-driven ingest jobs that should not re-scan the whole archive.
+package asfsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// Cursor is the persisted position of an incremental sync.
+type Cursor struct {
+	// QueryHash identifies the search the cursor belongs to; a cursor saved
+	// for a different search is discarded.
+	QueryHash string `json:"queryHash"`
+	// LastProcessingDate is the newest processing date returned so far.
+	LastProcessingDate time.Time `json:"lastProcessingDate"`
+	// Boundary lists the file IDs processed exactly at LastProcessingDate,
+	// sorted. The API treats the processing date bound as inclusive, so
+	// these products are returned again and must be skipped.
+	Boundary []string `json:"boundary,omitempty"`
+}
+
+// SyncSince runs the search described by opts for products processed after
+// the cursor stored in stateFile and returns those not returned by earlier
+// runs, together with the advanced cursor. The first run, or a run whose
+// search differs from the one the cursor was saved for, returns every
+// matching product.
+//
+// SyncSince does not save the cursor: commit next with SaveCursor once the
+// products have been processed, so that a run that fails part-way is
+// repeated rather than skipped.
+func SyncSince(ctx context.Context, client *asf.Client, opts asf.SearchOptions, stateFile string) (fresh []asf.Product, next *Cursor, err error) {
+	cursor, err := LoadCursor(stateFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Since(ctx, client, opts, cursor)
+}
+
+// Since runs the search described by opts for products processed after
+// cursor, which may be nil, and returns those the cursor has not covered
+// together with the advanced cursor. A cursor saved for a different search
+// is discarded.
+//
+// opts.MaxResults must be zero: a truncated result would move the cursor
+// past products that were never returned. The search always uses GeoJSON
+// output, and an explicit opts.Output of asf.OutputJSONLite is rejected,
+// since jsonlite results carry no processing date to advance the cursor.
+func Since(ctx context.Context, client *asf.Client, opts asf.SearchOptions, cursor *Cursor) (fresh []asf.Product, next *Cursor, err error) {
+	if opts.MaxResults > 0 {
+		return nil, nil, errors.New("asfsync: MaxResults cannot be combined with a cursor")
+	}
+	if opts.Output == asf.OutputJSONLite {
+		return nil, nil, errors.New("asfsync: jsonlite output has no processing dates to track")
+	}
+	hash, err := QueryHash(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cursor == nil || cursor.QueryHash != hash {
+		cursor = &Cursor{QueryHash: hash}
+	}
+	if cursor.LastProcessingDate.After(opts.ProcessedAfter) {
+		opts.ProcessedAfter = cursor.LastProcessingDate
+	}
+	// Override a client-wide jsonlite default.
+	opts.Output = asf.OutputGeoJSON
+
+	products, err := client.SearchAll(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("asfsync: search: %w", err)
+	}
+
+	next = &Cursor{
+		QueryHash:          cursor.QueryHash,
+		LastProcessingDate: cursor.LastProcessingDate,
+		Boundary:           slices.Clone(cursor.Boundary),
+	}
+	for _, product := range products {
+		pd := product.Properties.ProcessingDate
+		id := product.ID()
+		if pd.Before(cursor.LastProcessingDate) ||
+			(pd.Equal(cursor.LastProcessingDate) && slices.Contains(cursor.Boundary, id)) {
+			continue
+		}
+		fresh = append(fresh, product)
+		switch {
+		case pd.After(next.LastProcessingDate):
+			next.LastProcessingDate = pd
+			next.Boundary = []string{id}
+		case pd.Equal(next.LastProcessingDate) && !slices.Contains(next.Boundary, id):
+			next.Boundary = append(next.Boundary, id)
+		}
+	}
+	slices.Sort(next.Boundary)
+	return fresh, next, nil
+}
+
+// QueryHash returns a stable identifier for the query described by opts.
+// Settings that do not change which products match, such as timeouts, page
+// size and wire format, are excluded.
+func QueryHash(opts asf.SearchOptions) (string, error) {
+	opts.Timeout = 0
+	opts.PageSize = 0
+	opts.Output = ""
+	opts.OmitGeometry = false
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return "", fmt.Errorf("asfsync: hash query: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// LoadCursor reads the cursor saved in path, returning nil if the file does
+// not exist yet.
+func LoadCursor(path string) (*Cursor, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("asfsync: read cursor: %w", err)
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("asfsync: decode cursor: %w", err)
+	}
+	return &cursor, nil
+}
+
+// SaveCursor writes the cursor to path atomically.
+func SaveCursor(path string, cursor *Cursor) error {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return fmt.Errorf("asfsync: encode cursor: %w", err)
+	}
+	if err := WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("asfsync: save cursor: %w", err)
+	}
+	return nil
+}
+
+// WriteFileAtomic writes data to path via a temporary file in the same
+// directory and a rename, so readers see either the old or the new contents,
+// never a partial file.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,144 @@
+package asfsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+type scene struct {
+	id        string
+	processed string
+}
+
+// archiveServer serves scenes processed at or after the processingDate
+// parameter, recording the bound it was sent.
+func archiveServer(t *testing.T, scenes *[]scene, bounds *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bound := r.URL.Query().Get("processingDate")
+		*bounds = append(*bounds, bound)
+		var features []string
+		for _, s := range *scenes {
+			if bound != "" && s.processed < bound {
+				continue
+			}
+			features = append(features, fmt.Sprintf(
+				`{"properties":{"fileID":%q,"sceneName":%q,"processingDate":%q}}`, s.id, s.id, s.processed))
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+}
+
+func TestSyncSince(t *testing.T) {
+	scenes := []scene{
+		{"a", "2024-01-01T00:00:00Z"},
+		{"b", "2024-01-02T00:00:00Z"},
+	}
+	var bounds []string
+	server := archiveServer(t, &scenes, &bounds)
+	defer server.Close()
+
+	client := asf.NewClient(asf.WithBaseURL(server.URL))
+	stateFile := filepath.Join(t.TempDir(), "cursor.json")
+	opts := asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1}}
+
+	sync := func() []string {
+		t.Helper()
+		products, next, err := SyncSince(context.Background(), client, opts, stateFile)
+		if err != nil {
+			t.Fatalf("SyncSince returned error: %v", err)
+		}
+		if err := SaveCursor(stateFile, next); err != nil {
+			t.Fatalf("SaveCursor returned error: %v", err)
+		}
+		var ids []string
+		for _, p := range products {
+			ids = append(ids, p.Properties.FileID)
+		}
+		return ids
+	}
+
+	if got := sync(); strings.Join(got, ",") != "a,b" {
+		t.Fatalf("first run = %v, want [a b]", got)
+	}
+	if got := sync(); len(got) != 0 {
+		t.Fatalf("second run = %v, want nothing new", got)
+	}
+	scenes = append(scenes, scene{"c", "2024-01-02T00:00:00Z"}, scene{"d", "2024-01-03T00:00:00Z"})
+	if got := sync(); strings.Join(got, ",") != "c,d" {
+		t.Fatalf("third run = %v, want [c d]", got)
+	}
+	if bounds[0] != "" || bounds[1] != "2024-01-02T00:00:00Z" || bounds[2] != "2024-01-02T00:00:00Z" {
+		t.Fatalf("unexpected processingDate bounds %v", bounds)
+	}
+
+	cursor, err := LoadCursor(stateFile)
+	if err != nil {
+		t.Fatalf("LoadCursor returned error: %v", err)
+	}
+	if !cursor.LastProcessingDate.Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)) || strings.Join(cursor.Boundary, ",") != "d" {
+		t.Fatalf("unexpected cursor %+v", cursor)
+	}
+
+	opts.Platforms = []asf.Platform{asf.PlatformSentinel1A}
+	if got := sync(); len(got) != 4 {
+		t.Fatalf("run for a changed search = %v, want every product", got)
+	}
+}
+
+func TestSyncSinceDoesNotCommitCursor(t *testing.T) {
+	scenes := []scene{{"a", "2024-01-01T00:00:00Z"}}
+	var bounds []string
+	server := archiveServer(t, &scenes, &bounds)
+	defer server.Close()
+
+	client := asf.NewClient(asf.WithBaseURL(server.URL))
+	stateFile := filepath.Join(t.TempDir(), "cursor.json")
+	opts := asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1}}
+
+	for run := 0; run < 2; run++ {
+		products, _, err := SyncSince(context.Background(), client, opts, stateFile)
+		if err != nil {
+			t.Fatalf("SyncSince returned error: %v", err)
+		}
+		if len(products) != 1 {
+			t.Fatalf("run %d = %d products, want the uncommitted product again", run, len(products))
+		}
+	}
+
+	opts.MaxResults = 10
+	if _, _, err := SyncSince(context.Background(), client, opts, stateFile); err == nil {
+		t.Fatal("SyncSince accepted MaxResults")
+	}
+}
+
+func TestSinceRequiresProcessingDates(t *testing.T) {
+	scenes := []scene{{"a", "2024-01-01T00:00:00Z"}}
+	var bounds []string
+	server := archiveServer(t, &scenes, &bounds)
+	defer server.Close()
+
+	// A client-wide jsonlite default is overridden for the cursor search.
+	client := asf.NewClient(asf.WithBaseURL(server.URL), asf.WithOutputFormat(asf.OutputJSONLite))
+	opts := asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1}}
+	products, next, err := Since(context.Background(), client, opts, nil)
+	if err != nil {
+		t.Fatalf("Since returned error: %v", err)
+	}
+	if len(products) != 1 || next.LastProcessingDate.IsZero() {
+		t.Fatalf("expected the product and an advanced cursor, got %d products and %+v", len(products), next)
+	}
+
+	opts.Output = asf.OutputJSONLite
+	if _, _, err := Since(context.Background(), client, opts, nil); err == nil {
+		t.Fatal("Since accepted jsonlite output")
+	}
+}
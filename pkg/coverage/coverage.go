@@ -0,0 +1,429 @@
+// Package coverage draws the footprints of search results as a PNG or SVG
+// map, for a quick look at coverage without a GIS.
+package coverage
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// Options configures PNG and SVG.
+type Options struct {
+	// Width and Height are the image size in pixels; zero means 800 by 600.
+	Width, Height int
+	// Background holds lines drawn beneath the footprints, such as
+	// coastlines (see LoadBackground) or an area of interest. It does not
+	// widen the map, which is fitted to the footprints, unless there are
+	// none. No coastline data is bundled; the map always shows a
+	// latitude/longitude grid.
+	Background [][]asf.Position
+}
+
+func (o Options) size() (int, int) {
+	width, height := o.Width, o.Height
+	if width <= 0 {
+		width = 800
+	}
+	if height <= 0 {
+		height = 600
+	}
+	return width, height
+}
+
+var (
+	mapBackgroundColor = color.NRGBA{0xf4, 0xf6, 0xf8, 0xff}
+	mapGridColor       = color.NRGBA{0xd0, 0xd6, 0xdc, 0xff}
+	mapOutlineColor    = color.NRGBA{0x6b, 0x75, 0x80, 0xff}
+	mapFootprintColor  = color.NRGBA{0x1f, 0x77, 0xb4, 0xff}
+	mapFootprintFill   = color.NRGBA{0x1f, 0x77, 0xb4, 0x40}
+)
+
+// PNG draws the footprints of products onto a latitude/longitude grid and
+// writes the map to w as a PNG. Footprints are filled translucently, so
+// overlapping scenes show darker. Products without a footprint are marked at
+// their center point.
+func PNG(w io.Writer, products []asf.Product, opts Options) error {
+	m, err := newFootprintMap(products, opts)
+	if err != nil {
+		return err
+	}
+	img := image.NewRGBA(image.Rect(0, 0, m.width, m.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(mapBackgroundColor), image.Point{}, draw.Src)
+	for _, line := range m.grid() {
+		drawLine(img, line[0], line[1], mapGridColor)
+	}
+	for _, line := range opts.Background {
+		drawRing(img, m.project(line), mapOutlineColor)
+	}
+	for _, footprint := range m.footprints {
+		for _, polygon := range footprint.polygons {
+			// The mask only covers the polygon's bounding box, so that
+			// many small footprints do not each cost a full image.
+			bounds := pixelBounds(polygon).Intersect(img.Bounds())
+			if !bounds.Empty() {
+				mask := image.NewAlpha(bounds)
+				fillPolygon(mask, polygon)
+				draw.DrawMask(img, bounds, image.NewUniform(mapFootprintFill), image.Point{}, mask, bounds.Min, draw.Over)
+			}
+			for _, ring := range polygon {
+				drawRing(img, ring, mapFootprintColor)
+			}
+		}
+		if footprint.point != nil {
+			x, y := int(footprint.point[0]), int(footprint.point[1])
+			draw.Draw(img, image.Rect(x-2, y-2, x+3, y+3), image.NewUniform(mapFootprintColor), image.Point{}, draw.Src)
+		}
+	}
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("coverage: encode map: %w", err)
+	}
+	return nil
+}
+
+// SVG writes the map drawn by PNG to w as an SVG document, with each
+// footprint titled by its scene name.
+func SVG(w io.Writer, products []asf.Product, opts Options) error {
+	m, err := newFootprintMap(products, opts)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		m.width, m.height, m.width, m.height)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColor(mapBackgroundColor))
+
+	var grid strings.Builder
+	for _, line := range m.grid() {
+		fmt.Fprintf(&grid, "M%.1f %.1fL%.1f %.1f", line[0][0], line[0][1], line[1][0], line[1][1])
+	}
+	fmt.Fprintf(bw, `<path d="%s" stroke="%s" stroke-width="1" fill="none"/>`+"\n", grid.String(), svgColor(mapGridColor))
+	if len(opts.Background) > 0 {
+		var background strings.Builder
+		for _, line := range opts.Background {
+			for i, p := range m.project(line) {
+				command := 'L'
+				if i == 0 {
+					command = 'M'
+				}
+				fmt.Fprintf(&background, "%c%.1f %.1f", command, p[0], p[1])
+			}
+		}
+		fmt.Fprintf(bw, `<path d="%s" stroke="%s" stroke-width="1" fill="none"/>`+"\n", background.String(), svgColor(mapOutlineColor))
+	}
+
+	for _, footprint := range m.footprints {
+		bw.WriteString("<g>")
+		bw.WriteString("<title>")
+		xml.EscapeText(bw, []byte(footprint.name))
+		bw.WriteString("</title>")
+		for _, polygon := range footprint.polygons {
+			fmt.Fprintf(bw, `<path d="%s" fill="%s" fill-opacity="0.25" fill-rule="evenodd" stroke="%s" stroke-width="1"/>`,
+				svgPath(polygon), svgColor(mapFootprintColor), svgColor(mapFootprintColor))
+		}
+		if footprint.point != nil {
+			fmt.Fprintf(bw, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"/>`,
+				footprint.point[0], footprint.point[1], svgColor(mapFootprintColor))
+		}
+		bw.WriteString("</g>\n")
+	}
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+// footprintMap holds products projected to pixel coordinates.
+type footprintMap struct {
+	width, height int
+	// Projection: pixels per degree of latitude and longitude, and the
+	// position drawn at the center of the image.
+	scaleLat, scaleLon float64
+	centerLon          float64
+	centerLat          float64
+
+	footprints []mapFootprint
+}
+
+// mapFootprint is one product in pixel coordinates: its polygons, or the
+// point marking its center when it has no footprint.
+type mapFootprint struct {
+	name     string
+	polygons [][][][2]float64
+	point    *[2]float64
+}
+
+// newFootprintMap fits a projection around the products' footprints,
+// scaling longitude by the cosine of the central latitude so that shapes
+// are not stretched far from the equator.
+func newFootprintMap(products []asf.Product, opts Options) (*footprintMap, error) {
+	m := &footprintMap{}
+	m.width, m.height = opts.size()
+
+	geometries := make([]asf.Geometry, len(products))
+	bounds := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	extend := func(lon, lat float64) {
+		bounds[0], bounds[1] = min(bounds[0], lon), min(bounds[1], lat)
+		bounds[2], bounds[3] = max(bounds[2], lon), max(bounds[3], lat)
+	}
+	for i, p := range products {
+		geometry, err := p.ParseGeometry()
+		if err != nil {
+			return nil, fmt.Errorf("coverage: footprint of %q: %w", p.Properties.SceneName, err)
+		}
+		geometries[i] = geometry
+		if geometry.IsEmpty() {
+			extend(p.Properties.CenterLon, p.Properties.CenterLat)
+			continue
+		}
+		b := geometry.Bounds()
+		extend(b[0], b[1])
+		extend(b[2], b[3])
+	}
+	if len(products) == 0 {
+		for _, line := range opts.Background {
+			for _, pos := range line {
+				extend(pos[0], pos[1])
+			}
+		}
+	}
+	if math.IsInf(bounds[0], 1) {
+		bounds = [4]float64{-180, -90, 180, 90}
+	}
+
+	// Leave a margin of a tenth of the extent, and at least half a degree.
+	padLon := max((bounds[2]-bounds[0])*0.1, 0.5)
+	padLat := max((bounds[3]-bounds[1])*0.1, 0.5)
+	west, east := bounds[0]-padLon, bounds[2]+padLon
+	south, north := max(bounds[1]-padLat, -90), min(bounds[3]+padLat, 90)
+	m.centerLon, m.centerLat = (west+east)/2, (south+north)/2
+	k := max(math.Cos(m.centerLat*math.Pi/180), 0.2)
+	m.scaleLat = min(float64(m.width)/((east-west)*k), float64(m.height)/(north-south))
+	m.scaleLon = m.scaleLat * k
+
+	for i, p := range products {
+		footprint := mapFootprint{name: p.Properties.SceneName}
+		if geometries[i].IsEmpty() {
+			x, y := m.point(asf.Position{p.Properties.CenterLon, p.Properties.CenterLat})
+			footprint.point = &[2]float64{x, y}
+		}
+		for _, polygon := range geometries[i].Polygons {
+			rings := make([][][2]float64, 0, len(polygon))
+			for _, ring := range polygon {
+				rings = append(rings, m.project(ring))
+			}
+			footprint.polygons = append(footprint.polygons, rings)
+		}
+		m.footprints = append(m.footprints, footprint)
+	}
+	return m, nil
+}
+
+// point projects a position to pixel coordinates.
+func (m *footprintMap) point(pos asf.Position) (x, y float64) {
+	return float64(m.width)/2 + (pos[0]-m.centerLon)*m.scaleLon,
+		float64(m.height)/2 - (pos[1]-m.centerLat)*m.scaleLat
+}
+
+// project projects a ring or line to pixel coordinates.
+func (m *footprintMap) project(ring []asf.Position) [][2]float64 {
+	points := make([][2]float64, len(ring))
+	for i, pos := range ring {
+		points[i][0], points[i][1] = m.point(pos)
+	}
+	return points
+}
+
+// grid returns the lines of a latitude/longitude grid covering the map,
+// spaced so that about four to eight lines cross it each way.
+func (m *footprintMap) grid() [][2][2]float64 {
+	halfLon := float64(m.width) / 2 / m.scaleLon
+	halfLat := float64(m.height) / 2 / m.scaleLat
+	west, east := m.centerLon-halfLon, m.centerLon+halfLon
+	south, north := max(m.centerLat-halfLat, -90), min(m.centerLat+halfLat, 90)
+
+	step := 30.0
+	for _, s := range []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 15, 30} {
+		if max(east-west, north-south)/s <= 8 {
+			step = s
+			break
+		}
+	}
+	var lines [][2][2]float64
+	for lon := math.Ceil(west/step) * step; lon <= east; lon += step {
+		x0, y0 := m.point(asf.Position{lon, south})
+		x1, y1 := m.point(asf.Position{lon, north})
+		lines = append(lines, [2][2]float64{{x0, y0}, {x1, y1}})
+	}
+	for lat := math.Ceil(south/step) * step; lat <= north; lat += step {
+		x0, y0 := m.point(asf.Position{west, lat})
+		x1, y1 := m.point(asf.Position{east, lat})
+		lines = append(lines, [2][2]float64{{x0, y0}, {x1, y1}})
+	}
+	return lines
+}
+
+// pixelBounds returns the smallest rectangle of pixels covering the rings.
+func pixelBounds(rings [][][2]float64) image.Rectangle {
+	var r image.Rectangle
+	for _, ring := range rings {
+		for _, p := range ring {
+			pt := image.Rect(int(math.Floor(p[0])), int(math.Floor(p[1])), int(math.Floor(p[0]))+1, int(math.Floor(p[1]))+1)
+			r = r.Union(pt)
+		}
+	}
+	return r
+}
+
+// fillPolygon sets the pixels of mask inside the polygon's rings, using the
+// even-odd rule so that holes stay empty. Only the rows and columns within
+// the mask's bounds are scanned.
+func fillPolygon(mask *image.Alpha, rings [][][2]float64) {
+	b := mask.Bounds()
+	var xs []float64
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		cy := float64(y) + 0.5
+		xs = xs[:0]
+		for _, ring := range rings {
+			for i := range ring {
+				p, q := ring[i], ring[(i+1)%len(ring)]
+				if (p[1] <= cy) != (q[1] <= cy) {
+					xs = append(xs, p[0]+(cy-p[1])/(q[1]-p[1])*(q[0]-p[0]))
+				}
+			}
+		}
+		slices.Sort(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			start := max(int(math.Ceil(xs[i]-0.5)), b.Min.X)
+			end := min(int(math.Floor(xs[i+1]-0.5)), b.Max.X-1)
+			for x := start; x <= end; x++ {
+				mask.SetAlpha(x, y, color.Alpha{A: 0xff})
+			}
+		}
+	}
+}
+
+// drawRing outlines a ring in pixel coordinates.
+func drawRing(img draw.Image, ring [][2]float64, c color.Color) {
+	for i := 1; i < len(ring); i++ {
+		drawLine(img, ring[i-1], ring[i], c)
+	}
+}
+
+// drawLine draws a one-pixel line between two points, clipped to img.
+func drawLine(img draw.Image, from, to [2]float64, c color.Color) {
+	b := img.Bounds()
+	if max(from[0], to[0]) < float64(b.Min.X) || min(from[0], to[0]) >= float64(b.Max.X) ||
+		max(from[1], to[1]) < float64(b.Min.Y) || min(from[1], to[1]) >= float64(b.Max.Y) {
+		return
+	}
+	// Step in half pixels so that rounding never leaves gaps.
+	steps := int(math.Ceil(2 * max(math.Abs(to[0]-from[0]), math.Abs(to[1]-from[1]))))
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		pt := image.Pt(int(math.Floor(from[0]+t*(to[0]-from[0]))), int(math.Floor(from[1]+t*(to[1]-from[1]))))
+		if pt.In(b) {
+			img.Set(pt.X, pt.Y, c)
+		}
+	}
+}
+
+// svgPath renders rings in pixel coordinates as SVG path data.
+func svgPath(rings [][][2]float64) string {
+	var sb strings.Builder
+	for _, ring := range rings {
+		for i, p := range ring {
+			if i == 0 {
+				sb.WriteByte('M')
+			} else {
+				sb.WriteByte('L')
+			}
+			fmt.Fprintf(&sb, "%.1f %.1f", p[0], p[1])
+		}
+		sb.WriteByte('Z')
+	}
+	return sb.String()
+}
+
+func svgColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// LoadBackground reads lines for Options.Background from GeoJSON, such as a
+// Natural Earth coastline file: a FeatureCollection, Feature or bare
+// geometry whose LineStrings, Polygons and their Multi forms are drawn in
+// outline. Other geometry types are ignored.
+func LoadBackground(r io.Reader) ([][]asf.Position, error) {
+	var doc geoJSON
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("coverage: decode background: %w", err)
+	}
+	var lines [][]asf.Position
+	if err := doc.appendLines(&lines); err != nil {
+		return nil, fmt.Errorf("coverage: decode background: %w", err)
+	}
+	return lines, nil
+}
+
+// geoJSON is the subset of a GeoJSON object read by LoadBackground.
+type geoJSON struct {
+	Type        string          `json:"type"`
+	Features    []geoJSON       `json:"features"`
+	Geometry    *geoJSON        `json:"geometry"`
+	Geometries  []geoJSON       `json:"geometries"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+func (g *geoJSON) appendLines(lines *[][]asf.Position) error {
+	switch g.Type {
+	case "FeatureCollection":
+		for i := range g.Features {
+			if err := g.Features[i].appendLines(lines); err != nil {
+				return err
+			}
+		}
+	case "Feature":
+		if g.Geometry != nil {
+			return g.Geometry.appendLines(lines)
+		}
+	case "GeometryCollection":
+		for i := range g.Geometries {
+			if err := g.Geometries[i].appendLines(lines); err != nil {
+				return err
+			}
+		}
+	case "LineString":
+		var line []asf.Position
+		if err := json.Unmarshal(g.Coordinates, &line); err != nil {
+			return err
+		}
+		*lines = append(*lines, line)
+	case "MultiLineString", "Polygon":
+		var parts [][]asf.Position
+		if err := json.Unmarshal(g.Coordinates, &parts); err != nil {
+			return err
+		}
+		*lines = append(*lines, parts...)
+	case "MultiPolygon":
+		var polygons [][][]asf.Position
+		if err := json.Unmarshal(g.Coordinates, &polygons); err != nil {
+			return err
+		}
+		for _, polygon := range polygons {
+			*lines = append(*lines, polygon...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package coverage
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func mapTestProducts() []asf.Product {
+	return []asf.Product{
+		{
+			Geometry:   json.RawMessage(`{"type":"Polygon","coordinates":[[[-150,60],[-148,60],[-148,62],[-150,62],[-150,60]]]}`),
+			Properties: asf.Properties{SceneName: "S1A_<WEST>"},
+		},
+		{
+			Geometry:   json.RawMessage(`{"type":"Polygon","coordinates":[[[-149,61],[-147,61],[-147,63],[-149,63],[-149,61]]]}`),
+			Properties: asf.Properties{SceneName: "S1A_EAST"},
+		},
+		{Properties: asf.Properties{SceneName: "S1A_POINT", CenterLon: -146, CenterLat: 60}},
+	}
+}
+
+func TestPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PNG(&buf, mapTestProducts(), Options{Width: 400, Height: 300}); err != nil {
+		t.Fatalf("PNG returned error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 400 || b.Dy() != 300 {
+		t.Fatalf("unexpected image size %v", b)
+	}
+
+	m, err := newFootprintMap(mapTestProducts(), Options{Width: 400, Height: 300})
+	if err != nil {
+		t.Fatalf("newFootprintMap returned error: %v", err)
+	}
+	pixel := func(lon, lat float64) color.NRGBA {
+		x, y := m.point(asf.Position{lon, lat})
+		return color.NRGBAModel.Convert(img.At(int(x), int(y))).(color.NRGBA)
+	}
+	single, overlap, outside := pixel(-149.7, 60.3), pixel(-148.5, 61.5), pixel(-147.5, 60.3)
+	if outside != mapBackgroundColor {
+		t.Fatalf("expected background outside footprints, got %v", outside)
+	}
+	if single == mapBackgroundColor || overlap.B >= single.B && overlap.R >= single.R {
+		t.Fatalf("expected footprints to be filled and overlaps darker, got single %v overlap %v", single, overlap)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	background := [][]asf.Position{{{-151, 59}, {-145, 59}, {-145, 64}, {-151, 64}, {-151, 59}}}
+	if err := SVG(&buf, mapTestProducts(), Options{Background: background}); err != nil {
+		t.Fatalf("SVG returned error: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{`width="800" height="600"`, "<title>S1A_&lt;WEST&gt;</title>", "<title>S1A_EAST</title>", "<circle"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected SVG to contain %q", want)
+		}
+	}
+	if got := strings.Count(svg, `fill-rule="evenodd"`); got != 2 {
+		t.Errorf("expected 2 footprint paths, got %d", got)
+	}
+	if got := strings.Count(svg, `stroke="#6b7580"`); got != 1 {
+		t.Errorf("expected 1 background path, got %d", got)
+	}
+}
+
+func TestLoadBackground(t *testing.T) {
+	lines, err := LoadBackground(strings.NewReader(`{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"LineString","coordinates":[[-151,59],[-145,59]]}},
+		{"type":"Feature","geometry":{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[2,2],[3,2],[3,3],[2,2]]]]}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[5,5]}},
+		{"type":"Feature","geometry":null}
+	]}`))
+	if err != nil {
+		t.Fatalf("LoadBackground returned error: %v", err)
+	}
+	if len(lines) != 3 || len(lines[0]) != 2 || lines[2][1] != (asf.Position{3, 2}) {
+		t.Fatalf("unexpected background lines %v", lines)
+	}
+}
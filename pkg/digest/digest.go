@@ -0,0 +1,263 @@
+// Package digest summarizes recent acquisitions matching a saved search as a
+// short Markdown or HTML report, suitable for email or chat notifications.
+package digest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// Options controls the reporting window of Generate.
+type Options struct {
+	// Days is the length of the reporting window, ending at Now. Zero means
+	// one day.
+	Days int
+	// Now is the end of the window; zero means the current time.
+	Now time.Time
+	// BaselineDays is how far before the window to look for tracks that
+	// were already being acquired; tracks absent from it are reported as
+	// new. Zero means the same length as the window.
+	BaselineDays int
+}
+
+// Track identifies a repeat-pass ground track.
+type Track struct {
+	Platform        string
+	Path            int
+	FlightDirection string
+}
+
+func (t Track) String() string {
+	s := fmt.Sprintf("%s path %d", t.Platform, t.Path)
+	if t.FlightDirection != "" {
+		s += " " + strings.ToLower(t.FlightDirection)
+	}
+	return s
+}
+
+// Group counts the acquisitions sharing a platform or a track.
+type Group struct {
+	Name  string
+	Count int
+	Bytes int64
+	// New is set for tracks not acquired during the baseline period.
+	New bool
+}
+
+// Day counts the acquisitions started on one UTC day.
+type Day struct {
+	Date  time.Time
+	Count int
+}
+
+// Digest summarizes the acquisitions in a reporting window.
+type Digest struct {
+	Start, End time.Time
+	Count      int
+	Bytes      int64
+	// Platforms and Tracks are ordered by descending count, then name.
+	Platforms []Group
+	Tracks    []Group
+	// Days covers every day of the window, in order, including empty ones.
+	Days []Day
+}
+
+// NewTracks returns the names of the tracks flagged as new.
+func (d *Digest) NewTracks() []string {
+	var names []string
+	for _, track := range d.Tracks {
+		if track.New {
+			names = append(names, track.Name)
+		}
+	}
+	return names
+}
+
+// Generate searches for the products matching search acquired in the
+// reporting window, and in the baseline period before it, and summarizes
+// them. The search's own Start and End are replaced by the window.
+func Generate(ctx context.Context, client *asf.Client, search asf.SearchOptions, opts Options) (*Digest, error) {
+	days := cmp.Or(opts.Days, 1)
+	if days < 0 || opts.BaselineDays < 0 {
+		return nil, fmt.Errorf("digest: days must not be negative")
+	}
+	end := opts.Now
+	if end.IsZero() {
+		end = time.Now()
+	}
+	end = end.UTC()
+	start := end.AddDate(0, 0, -days)
+
+	search.OmitGeometry = true
+	search.Start, search.End = start, end
+	products, err := client.SearchAll(ctx, search)
+	if err != nil {
+		return nil, fmt.Errorf("digest: search window: %w", err)
+	}
+	search.Start, search.End = start.AddDate(0, 0, -cmp.Or(opts.BaselineDays, days)), start
+	baseline, err := client.SearchAll(ctx, search)
+	if err != nil {
+		return nil, fmt.Errorf("digest: search baseline: %w", err)
+	}
+	return Summarize(products, baseline, start, end), nil
+}
+
+// Summarize builds the digest of products acquired between start and end.
+// Tracks of products that do not appear in baseline are flagged as new;
+// passing a nil baseline flags none.
+func Summarize(products, baseline []asf.Product, start, end time.Time) *Digest {
+	d := &Digest{Start: start, End: end}
+
+	known := make(map[Track]bool, len(baseline))
+	for _, p := range baseline {
+		known[trackOf(p)] = true
+	}
+
+	platforms := map[string]*Group{}
+	tracks := map[Track]*Group{}
+	perDay := map[time.Time]int{}
+	for _, p := range products {
+		d.Count++
+		d.Bytes += p.Properties.Bytes
+		add(platforms, p.Properties.Platform, p, func() *Group { return &Group{Name: p.Properties.Platform} })
+		track := trackOf(p)
+		add(tracks, track, p, func() *Group {
+			return &Group{Name: track.String(), New: baseline != nil && !known[track]}
+		})
+		perDay[truncateDay(p.Properties.StartTime)]++
+	}
+	d.Platforms = sortedGroups(platforms)
+	d.Tracks = sortedGroups(tracks)
+
+	for day := truncateDay(start); day.Before(end); day = day.AddDate(0, 0, 1) {
+		d.Days = append(d.Days, Day{Date: day, Count: perDay[day]})
+	}
+	return d
+}
+
+func trackOf(p asf.Product) Track {
+	return Track{Platform: p.Properties.Platform, Path: p.Properties.PathNumber, FlightDirection: p.Properties.FlightDirection}
+}
+
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func add[K comparable](groups map[K]*Group, key K, p asf.Product, create func() *Group) {
+	group, ok := groups[key]
+	if !ok {
+		group = create()
+		groups[key] = group
+	}
+	group.Count++
+	group.Bytes += p.Properties.Bytes
+}
+
+func sortedGroups[K comparable](groups map[K]*Group) []Group {
+	sorted := make([]Group, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, *group)
+	}
+	slices.SortFunc(sorted, func(a, b Group) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Name, b.Name))
+	})
+	return sorted
+}
+
+var funcs = map[string]any{
+	"bytes": formatBytes,
+	"date":  func(t time.Time) string { return t.UTC().Format("2006-01-02") },
+	"time":  func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(funcs).Parse(
+	`## ASF acquisitions {{time .Start}} to {{time .End}}
+
+**{{.Count}}** products, **{{bytes .Bytes}}** in total.
+{{- with .NewTracks}}
+
+New tracks: {{range $i, $t := .}}{{if $i}}, {{end}}{{$t}}{{end}}
+{{- end}}
+{{- if .Platforms}}
+
+| Platform | Products | Volume |
+| --- | ---: | ---: |
+{{- range .Platforms}}
+| {{.Name}} | {{.Count}} | {{bytes .Bytes}} |
+{{- end}}
+
+| Track | Products | Volume |
+| --- | ---: | ---: |
+{{- range .Tracks}}
+| {{.Name}}{{if .New}} (new){{end}} | {{.Count}} | {{bytes .Bytes}} |
+{{- end}}
+{{- end}}
+
+| Day | Products |
+| --- | ---: |
+{{- range .Days}}
+| {{date .Date}} | {{.Count}} |
+{{- end}}
+`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(
+	`<h2>ASF acquisitions {{time .Start}} to {{time .End}}</h2>
+<p><strong>{{.Count}}</strong> products, <strong>{{bytes .Bytes}}</strong> in total.</p>
+{{- with .NewTracks}}
+<p>New tracks: {{range $i, $t := .}}{{if $i}}, {{end}}{{$t}}{{end}}</p>
+{{- end}}
+{{- if .Platforms}}
+<table>
+<tr><th>Platform</th><th>Products</th><th>Volume</th></tr>
+{{- range .Platforms}}
+<tr><td>{{.Name}}</td><td>{{.Count}}</td><td>{{bytes .Bytes}}</td></tr>
+{{- end}}
+</table>
+<table>
+<tr><th>Track</th><th>Products</th><th>Volume</th></tr>
+{{- range .Tracks}}
+<tr><td>{{.Name}}{{if .New}} (new){{end}}</td><td>{{.Count}}</td><td>{{bytes .Bytes}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<table>
+<tr><th>Day</th><th>Products</th></tr>
+{{- range .Days}}
+<tr><td>{{date .Date}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+`))
+
+// WriteMarkdown renders the digest as Markdown to w.
+func (d *Digest) WriteMarkdown(w io.Writer) error {
+	return markdownTemplate.Execute(w, d)
+}
+
+// WriteHTML renders the digest as an HTML fragment to w, escaping product
+// metadata.
+func (d *Digest) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, d)
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,72 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestGenerate(t *testing.T) {
+	type scene struct {
+		start string
+		path  int
+	}
+	scenes := []scene{
+		{"2024-05-01T06:00:00Z", 15}, // baseline
+		{"2024-05-02T06:00:00Z", 15},
+		{"2024-05-02T18:00:00Z", 44},
+		{"2024-05-03T06:00:00Z", 15},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var features []string
+		for _, s := range scenes {
+			if s.start < q.Get("start") || s.start >= q.Get("end") {
+				continue
+			}
+			features = append(features, fmt.Sprintf(
+				`{"properties":{"platform":"Sentinel-1A","pathNumber":%d,"flightDirection":"ASCENDING","bytes":1073741824,"startTime":%q}}`,
+				s.path, s.start))
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+	defer server.Close()
+
+	client := asf.NewClient(asf.WithBaseURL(server.URL))
+	now := time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)
+	d, err := Generate(context.Background(), client, asf.SearchOptions{}, Options{Days: 2, Now: now})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if d.Count != 3 || d.Bytes != 3<<30 || len(d.Days) != 2 || d.Days[0].Count != 2 || d.Days[1].Count != 1 {
+		t.Fatalf("unexpected digest %+v", d)
+	}
+	if got := d.NewTracks(); len(got) != 1 || got[0] != "Sentinel-1A path 44 ascending" {
+		t.Fatalf("unexpected new tracks %v", got)
+	}
+	if len(d.Tracks) != 2 || d.Tracks[0].Name != "Sentinel-1A path 15 ascending" || d.Tracks[0].Count != 2 {
+		t.Fatalf("unexpected tracks %+v", d.Tracks)
+	}
+
+	var md, html strings.Builder
+	if err := d.WriteMarkdown(&md); err != nil {
+		t.Fatalf("WriteMarkdown returned error: %v", err)
+	}
+	if err := d.WriteHTML(&html); err != nil {
+		t.Fatalf("WriteHTML returned error: %v", err)
+	}
+	for _, want := range []string{"**3** products, **3.0 GiB**", "New tracks: Sentinel-1A path 44 ascending", "| 2024-05-02 | 2 |"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md.String())
+		}
+	}
+	if !strings.Contains(html.String(), "<td>Sentinel-1A path 44 ascending (new)</td>") {
+		t.Errorf("unexpected HTML:\n%s", html.String())
+	}
+}
@@ -0,0 +1,324 @@
+// Package harvest runs continent-scale archive inventories by splitting one
+// search into many smaller ones: the area of interest is tiled, the time
+// range is cut into calendar partitions, and the resulting queries run
+// concurrently, with deduplication and checkpointing so an interrupted
+// harvest can be resumed.
+package harvest
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+	"github.com/robert-malhotra/go-asf/pkg/asfsync"
+)
+
+// Options configures a harvest.
+type Options struct {
+	// Search is the base query. Its area, IntersectsWith or a BBox not
+	// crossing the antimeridian, must be a WKT POLYGON when TileSize is
+	// set, and Start and End must be set when Months is. MaxResults is
+	// ignored; every partition is paged to exhaustion.
+	Search asf.SearchOptions
+	// TileSize splits the search polygon into cells of this many degrees;
+	// zero searches the whole area at once.
+	TileSize float64
+	// Months splits the time range into calendar partitions of this many
+	// months; zero searches the whole range at once.
+	Months int
+	// Concurrency is the number of partitions queried at once; zero means 4.
+	Concurrency int
+	// Checkpoint is a file recording completed partitions and their results.
+	// An existing checkpoint is resumed, and must have been written for the
+	// same Search, TileSize and Months; empty disables checkpointing.
+	Checkpoint string
+	// BatchMode selects whether a failed partition cancels the others
+	// (asf.FailFast) or lets them finish and be checkpointed before the
+	// failures are returned together (asf.ContinueOnError, the default).
+	BatchMode asf.BatchMode
+}
+
+// partition is one sub-query of a harvest.
+type partition struct {
+	key  string
+	opts asf.SearchOptions
+}
+
+// checkpoint is the progress of a harvest. It is persisted as JSON Lines: a
+// checkpointHeader followed by one checkpointEntry per completed partition,
+// appended as each partition finishes.
+type checkpoint struct {
+	Done     []string
+	Products []asf.Product
+	file     *os.File
+}
+
+// checkpointHeader identifies the harvest configuration a checkpoint belongs
+// to, since partition keys alone do not capture the query.
+type checkpointHeader struct {
+	Config string `json:"config"`
+}
+
+// checkpointEntry records one completed partition and the products it added.
+type checkpointEntry struct {
+	Partition string        `json:"partition"`
+	Products  []asf.Product `json:"products"`
+}
+
+// Harvest runs the partitioned search described by opts and returns the
+// deduplicated products of all partitions.
+func Harvest(ctx context.Context, client *asf.Client, opts Options) ([]asf.Product, error) {
+	partitions, err := plan(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := configHash(opts)
+	if err != nil {
+		return nil, err
+	}
+	state, err := openCheckpoint(opts.Checkpoint, config)
+	if err != nil {
+		return nil, err
+	}
+	defer state.close()
+	done := make(map[string]bool, len(state.Done))
+	for _, key := range state.Done {
+		done[key] = true
+	}
+	seen := make(map[string]bool, len(state.Products))
+	for _, p := range state.Products {
+		seen[p.ID()] = true
+	}
+
+	var mu sync.Mutex
+	g, gctx := &errgroup.Group{}, ctx
+	if opts.BatchMode == asf.FailFast {
+		g, gctx = errgroup.WithContext(ctx)
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	g.SetLimit(concurrency)
+
+	var failures []error
+	for _, part := range partitions {
+		if done[part.key] {
+			continue
+		}
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			err := harvestPartition(gctx, client, part, &mu, state, seen)
+			if err != nil && opts.BatchMode != asf.FailFast {
+				mu.Lock()
+				failures = append(failures, err)
+				mu.Unlock()
+				return nil
+			}
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if len(failures) > 0 {
+		return nil, errors.Join(failures...)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return state.Products, nil
+}
+
+// harvestPartition queries one partition and merges its new products into
+// state, checkpointing the result.
+func harvestPartition(ctx context.Context, client *asf.Client, part partition,
+	mu *sync.Mutex, state *checkpoint, seen map[string]bool) error {
+	products, err := client.SearchAll(ctx, part.opts)
+	if err != nil {
+		return fmt.Errorf("harvest: partition %s: %w", part.key, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var fresh []asf.Product
+	for _, p := range products {
+		if id := p.ID(); !seen[id] {
+			seen[id] = true
+			fresh = append(fresh, p)
+		}
+	}
+	state.Products = append(state.Products, fresh...)
+	state.Done = append(state.Done, part.key)
+	return state.append(checkpointEntry{Partition: part.key, Products: fresh})
+}
+
+// plan splits the harvest into partitions, one per tile and time period.
+func plan(opts Options) ([]partition, error) {
+	base := opts.Search
+	base.MaxResults = 0
+	if base.IntersectsWith == "" && base.BBox != ([4]float64{}) {
+		base.IntersectsWith = asf.BBoxWKT(base.BBox)
+	}
+	base.BBox = [4]float64{}
+
+	tiles := []string{base.IntersectsWith}
+	if opts.TileSize > 0 {
+		ring, err := parsePolygon(base.IntersectsWith)
+		if err != nil {
+			return nil, err
+		}
+		tiles = tilePolygon(ring, opts.TileSize)
+	}
+
+	type period struct{ start, end time.Time }
+	periods := []period{{base.Start, base.End}}
+	if opts.Months > 0 {
+		if base.Start.IsZero() || base.End.IsZero() {
+			return nil, errors.New("harvest: calendar partitioning needs Start and End")
+		}
+		periods = nil
+		for start := base.Start; start.Before(base.End); {
+			end := start.AddDate(0, opts.Months, 0)
+			if end.After(base.End) {
+				end = base.End
+			}
+			periods = append(periods, period{start, end})
+			start = end
+		}
+	}
+
+	var partitions []partition
+	for i, tile := range tiles {
+		for _, p := range periods {
+			part := base
+			part.IntersectsWith = tile
+			part.Start, part.End = p.start, p.end
+			partitions = append(partitions, partition{
+				key:  fmt.Sprintf("tile%d/%s", i, p.start.UTC().Format(time.RFC3339)),
+				opts: part,
+			})
+		}
+	}
+	return partitions, nil
+}
+
+// configHash identifies the query, tiling and partitioning of a harvest.
+func configHash(opts Options) (string, error) {
+	search := opts.Search
+	search.MaxResults = 0
+	query, err := asfsync.QueryHash(search)
+	if err != nil {
+		return "", fmt.Errorf("harvest: %w", err)
+	}
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s/%g/%d", query, opts.TileSize, opts.Months))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// openCheckpoint loads the checkpoint at path and opens it for appending,
+// starting a new one for config if it does not exist yet. A partially
+// written last entry, left by an interrupted run, is dropped.
+func openCheckpoint(path, config string) (*checkpoint, error) {
+	state := &checkpoint{}
+	if path == "" {
+		return state, nil
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("harvest: open checkpoint: %w", err)
+	}
+	state.file = file
+	if err := state.load(path, config); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *checkpoint) load(path, config string) error {
+	r := bufio.NewReader(s.file)
+	var size int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if size == 0 && len(line) > 0 {
+				return fmt.Errorf("harvest: %s is not a harvest checkpoint", path)
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("harvest: read checkpoint: %w", err)
+		}
+		if size == 0 {
+			var header checkpointHeader
+			if err := json.Unmarshal(line, &header); err != nil {
+				return fmt.Errorf("harvest: decode checkpoint: %w", err)
+			}
+			if header.Config != config {
+				return fmt.Errorf("harvest: checkpoint %s was written for a different query, tile size or partitioning", path)
+			}
+		} else {
+			var entry checkpointEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return fmt.Errorf("harvest: decode checkpoint: %w", err)
+			}
+			s.Done = append(s.Done, entry.Partition)
+			s.Products = append(s.Products, entry.Products...)
+		}
+		size += int64(len(line))
+	}
+	if err := s.file.Truncate(size); err != nil {
+		return fmt.Errorf("harvest: read checkpoint: %w", err)
+	}
+	if _, err := s.file.Seek(size, io.SeekStart); err != nil {
+		return fmt.Errorf("harvest: read checkpoint: %w", err)
+	}
+	if size == 0 {
+		return s.write(checkpointHeader{Config: config})
+	}
+	return nil
+}
+
+// append records a completed partition.
+func (s *checkpoint) append(entry checkpointEntry) error {
+	if s.file == nil {
+		return nil
+	}
+	return s.write(entry)
+}
+
+// write appends one JSON line and syncs it, so a crash loses at most the
+// line being written.
+func (s *checkpoint) write(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("harvest: encode checkpoint: %w", err)
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("harvest: save checkpoint: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("harvest: save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (s *checkpoint) close() {
+	if s.file != nil {
+		s.file.Close()
+	}
+}
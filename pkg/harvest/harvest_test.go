@@ -0,0 +1,207 @@
+package harvest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestTilePolygon(t *testing.T) {
+	square, err := parsePolygon("POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))")
+	if err != nil {
+		t.Fatalf("parsePolygon returned error: %v", err)
+	}
+	if tiles := tilePolygon(square, 1); len(tiles) != 4 {
+		t.Fatalf("expected 4 tiles for a 2x2 square, got %d: %v", len(tiles), tiles)
+	}
+
+	// The triangle only touches the top-right cell at a single corner.
+	triangle, err := parsePolygon("POLYGON ((0 0, 2 0, 0 2, 0 0))")
+	if err != nil {
+		t.Fatalf("parsePolygon returned error: %v", err)
+	}
+	tiles := tilePolygon(triangle, 1)
+	if len(tiles) != 3 {
+		t.Fatalf("expected 3 tiles for the triangle, got %d: %v", len(tiles), tiles)
+	}
+	if want := "POLYGON ((1 0, 2 0, 1 1, 1 0))"; tiles[1] != want {
+		t.Fatalf("unexpected clipped tile %q, want %q", tiles[1], want)
+	}
+
+	if _, err := parsePolygon("POINT (1 2)"); err == nil {
+		t.Fatalf("expected error for non-polygon geometry")
+	}
+}
+
+func TestPlanCalendarPartitions(t *testing.T) {
+	partitions, err := plan(Options{
+		Search: asf.SearchOptions{
+			Start: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Months: 1,
+	})
+	if err != nil {
+		t.Fatalf("plan returned error: %v", err)
+	}
+	var ranges []string
+	for _, p := range partitions {
+		ranges = append(ranges, p.opts.Start.Format("01-02")+".."+p.opts.End.Format("01-02"))
+	}
+	if want := "01-15..02-15 02-15..03-15 03-15..04-01"; strings.Join(ranges, " ") != want {
+		t.Fatalf("unexpected partitions %v, want %s", ranges, want)
+	}
+
+	if _, err := plan(Options{Months: 1}); err == nil {
+		t.Fatalf("expected error when partitioning without a time range")
+	}
+}
+
+func TestHarvestDeduplicatesAndResumes(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Every partition returns a shared product plus one of its own.
+		own := r.URL.Query().Get("intersectsWith") + r.URL.Query().Get("start")
+		fmt.Fprintf(w, `{"features":[{"properties":{"fileID":"shared"}},{"properties":{"fileID":%q}}]}`, own)
+	}))
+	defer server.Close()
+
+	client := asf.NewClient(asf.WithBaseURL(server.URL))
+	opts := Options{
+		Search: asf.SearchOptions{
+			IntersectsWith: "POLYGON ((0 0, 2 0, 2 1, 0 1, 0 0))",
+			Start:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:            time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		TileSize:   1,
+		Months:     1,
+		Checkpoint: filepath.Join(t.TempDir(), "harvest.jsonl"),
+	}
+
+	products, err := Harvest(context.Background(), client, opts)
+	if err != nil {
+		t.Fatalf("Harvest returned error: %v", err)
+	}
+	// 2 tiles x 2 months = 4 partitions, each with its own product, plus
+	// the shared product once.
+	if len(products) != 5 {
+		t.Fatalf("expected 5 deduplicated products, got %d", len(products))
+	}
+	if got := requests.Load(); got != 4 {
+		t.Fatalf("expected 4 partition queries, got %d", got)
+	}
+
+	requests.Store(0)
+	products, err = Harvest(context.Background(), client, opts)
+	if err != nil {
+		t.Fatalf("resumed Harvest returned error: %v", err)
+	}
+	if len(products) != 5 || requests.Load() != 0 {
+		t.Fatalf("expected resumed harvest to reuse the checkpoint, got %d products and %d requests", len(products), requests.Load())
+	}
+}
+
+func TestHarvestContinuesPastFailedPartition(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if strings.HasPrefix(r.URL.Query().Get("start"), "2024-01") {
+			http.Error(w, "bad partition", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"features":[{"properties":{"fileID":%q}}]}`, r.URL.Query().Get("start"))
+	}))
+	defer server.Close()
+
+	checkpoint := filepath.Join(t.TempDir(), "harvest.jsonl")
+	opts := Options{
+		Search: asf.SearchOptions{
+			Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Months:      1,
+		Concurrency: 1,
+		Checkpoint:  checkpoint,
+	}
+	client := asf.NewClient(asf.WithBaseURL(server.URL))
+
+	if _, err := Harvest(context.Background(), client, opts); err == nil || !strings.Contains(err.Error(), "bad partition") {
+		t.Fatalf("expected partition error, got %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Fatalf("expected all 3 partitions to be queried, got %d", got)
+	}
+	config, err := configHash(opts)
+	if err != nil {
+		t.Fatalf("configHash returned error: %v", err)
+	}
+	state, err := openCheckpoint(checkpoint, config)
+	if err != nil {
+		t.Fatalf("openCheckpoint returned error: %v", err)
+	}
+	state.close()
+	if len(state.Done) != 2 {
+		t.Fatalf("expected the 2 healthy partitions to be checkpointed, got %v", state.Done)
+	}
+
+	requests.Store(0)
+	opts.Checkpoint = ""
+	opts.BatchMode = asf.FailFast
+	if _, err := Harvest(context.Background(), client, opts); err == nil {
+		t.Fatalf("expected partition error in fail-fast mode")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected fail-fast harvest to stop after the first partition, got %d requests", got)
+	}
+}
+
+func TestHarvestCheckpointIsBoundToConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"features":[{"properties":{"fileID":%q}}]}`, r.URL.Query().Get("start"))
+	}))
+	defer server.Close()
+
+	checkpoint := filepath.Join(t.TempDir(), "harvest.jsonl")
+	opts := Options{
+		Search: asf.SearchOptions{
+			Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Months:     1,
+		Checkpoint: checkpoint,
+	}
+	client := asf.NewClient(asf.WithBaseURL(server.URL))
+	if _, err := Harvest(context.Background(), client, opts); err != nil {
+		t.Fatalf("Harvest returned error: %v", err)
+	}
+
+	// An entry cut short by a crash is dropped rather than failing the resume.
+	f, err := os.OpenFile(checkpoint, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"partition":"tile0/2024-03`)
+	f.Close()
+	products, err := Harvest(context.Background(), client, opts)
+	if err != nil {
+		t.Fatalf("resumed Harvest returned error: %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("expected 2 checkpointed products, got %d", len(products))
+	}
+
+	opts.Months = 2
+	if _, err := Harvest(context.Background(), client, opts); err == nil || !strings.Contains(err.Error(), "different") {
+		t.Fatalf("expected error resuming a checkpoint with another partitioning, got %v", err)
+	}
+}
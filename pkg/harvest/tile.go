@@ -0,0 +1,151 @@
+package harvest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// point is a longitude/latitude pair.
+type point struct{ x, y float64 }
+
+// parsePolygon reads the outer ring of a WKT POLYGON. Holes are ignored, so
+// tiles cover a superset of the polygon.
+func parsePolygon(wkt string) ([]point, error) {
+	s := strings.TrimSpace(wkt)
+	if !strings.HasPrefix(strings.ToUpper(s), "POLYGON") {
+		return nil, fmt.Errorf("harvest: tiling needs a WKT POLYGON, got %q", s)
+	}
+	s = strings.TrimSpace(s[len("POLYGON"):])
+	s = strings.TrimPrefix(s, "(")
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "(")
+	end := strings.Index(s, ")")
+	if end < 0 {
+		return nil, fmt.Errorf("harvest: unterminated polygon ring")
+	}
+
+	var ring []point
+	for pair := range strings.SplitSeq(s[:end], ",") {
+		fields := strings.Fields(pair)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("harvest: invalid coordinate %q", pair)
+		}
+		x, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("harvest: invalid coordinate %q: %w", pair, err)
+		}
+		y, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("harvest: invalid coordinate %q: %w", pair, err)
+		}
+		ring = append(ring, point{x, y})
+	}
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return nil, fmt.Errorf("harvest: polygon needs at least 3 distinct points")
+	}
+	return ring, nil
+}
+
+// tilePolygon splits ring into the non-empty intersections of the polygon
+// with a grid of size-degree cells, returned as WKT polygons.
+func tilePolygon(ring []point, size float64) []string {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range ring {
+		minX, maxX = min(minX, p.x), max(maxX, p.x)
+		minY, maxY = min(minY, p.y), max(maxY, p.y)
+	}
+
+	var tiles []string
+	for y := minY; y < maxY; y += size {
+		for x := minX; x < maxX; x += size {
+			clipped := clean(clipToBox(ring, x, y, min(x+size, maxX), min(y+size, maxY)))
+			if len(clipped) >= 3 && math.Abs(area(clipped)) > 1e-12 {
+				tiles = append(tiles, formatPolygon(clipped))
+			}
+		}
+	}
+	return tiles
+}
+
+// clipToBox clips a polygon ring to an axis-aligned box using the
+// Sutherland-Hodgman algorithm.
+func clipToBox(ring []point, x0, y0, x1, y1 float64) []point {
+	edges := []struct {
+		inside    func(point) bool
+		intersect func(a, b point) point
+	}{
+		{func(p point) bool { return p.x >= x0 }, func(a, b point) point { return atX(a, b, x0) }},
+		{func(p point) bool { return p.x <= x1 }, func(a, b point) point { return atX(a, b, x1) }},
+		{func(p point) bool { return p.y >= y0 }, func(a, b point) point { return atY(a, b, y0) }},
+		{func(p point) bool { return p.y <= y1 }, func(a, b point) point { return atY(a, b, y1) }},
+	}
+
+	out := ring
+	for _, edge := range edges {
+		in := out
+		out = nil
+		for i, cur := range in {
+			prev := in[(i+len(in)-1)%len(in)]
+			switch {
+			case edge.inside(cur) && !edge.inside(prev):
+				out = append(out, edge.intersect(prev, cur), cur)
+			case edge.inside(cur):
+				out = append(out, cur)
+			case edge.inside(prev):
+				out = append(out, edge.intersect(prev, cur))
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+	}
+	return out
+}
+
+// clean drops repeated consecutive points, which clipping produces where the
+// polygon touches a cell edge.
+func clean(ring []point) []point {
+	var out []point
+	for i, p := range ring {
+		if i == 0 || p != ring[i-1] {
+			out = append(out, p)
+		}
+	}
+	for len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// area returns the signed area of a ring (shoelace formula).
+func area(ring []point) float64 {
+	var sum float64
+	for i, p := range ring {
+		q := ring[(i+1)%len(ring)]
+		sum += p.x*q.y - q.x*p.y
+	}
+	return sum / 2
+}
+
+func atX(a, b point, x float64) point {
+	return point{x, a.y + (b.y-a.y)*(x-a.x)/(b.x-a.x)}
+}
+
+func atY(a, b point, y float64) point {
+	return point{a.x + (b.x-a.x)*(y-a.y)/(b.y-a.y), y}
+}
+
+// formatPolygon renders a ring as a closed WKT polygon.
+func formatPolygon(ring []point) string {
+	coords := make([]string, 0, len(ring)+1)
+	for _, p := range append(ring, ring[0]) {
+		coords = append(coords, strconv.FormatFloat(p.x, 'f', -1, 64)+" "+strconv.FormatFloat(p.y, 'f', -1, 64))
+	}
+	return "POLYGON ((" + strings.Join(coords, ", ") + "))"
+}
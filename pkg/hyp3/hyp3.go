@@ -0,0 +1,218 @@
+// Package hyp3 is a client for ASF's HyP3 on-demand processing API: it
+// submits RTC, InSAR and autoRIFT jobs for search results, polls their status
+// and downloads the finished products.
+package hyp3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// DefaultBaseURL is the production HyP3 API.
+const DefaultBaseURL = "https://hyp3-api.asf.alaska.edu"
+
+// JobType names a HyP3 processing workflow.
+type JobType string
+
+const (
+	RTC      JobType = "RTC_GAMMA"
+	InSAR    JobType = "INSAR_GAMMA"
+	AutoRIFT JobType = "AUTORIFT"
+)
+
+// StatusCode is the processing state of a job.
+type StatusCode string
+
+const (
+	Pending   StatusCode = "PENDING"
+	Running   StatusCode = "RUNNING"
+	Succeeded StatusCode = "SUCCEEDED"
+	Failed    StatusCode = "FAILED"
+)
+
+// Done reports whether the job has finished, successfully or not.
+func (s StatusCode) Done() bool {
+	return s == Succeeded || s == Failed
+}
+
+// ErrJobFailed reports that a job finished with status FAILED.
+var ErrJobFailed = errors.New("hyp3: job failed")
+
+// JobRequest describes a job to submit.
+type JobRequest struct {
+	JobType       JobType        `json:"job_type"`
+	Name          string         `json:"name,omitempty"`
+	JobParameters map[string]any `json:"job_parameters"`
+}
+
+// RTCJob requests radiometric terrain correction of a product.
+func RTCJob(product asf.Product, name string) JobRequest {
+	return JobRequest{JobType: RTC, Name: name, JobParameters: map[string]any{
+		"granules": []string{product.Properties.SceneName},
+	}}
+}
+
+// InSARJob requests an interferogram from a reference and secondary product.
+func InSARJob(reference, secondary asf.Product, name string) JobRequest {
+	return pairJob(InSAR, reference, secondary, name)
+}
+
+// AutoRIFTJob requests feature tracking between a reference and secondary
+// product.
+func AutoRIFTJob(reference, secondary asf.Product, name string) JobRequest {
+	return pairJob(AutoRIFT, reference, secondary, name)
+}
+
+func pairJob(jobType JobType, reference, secondary asf.Product, name string) JobRequest {
+	return JobRequest{JobType: jobType, Name: name, JobParameters: map[string]any{
+		"granules": []string{reference.Properties.SceneName, secondary.Properties.SceneName},
+	}}
+}
+
+// Job is a submitted job as reported by the API.
+type Job struct {
+	JobID          string         `json:"job_id"`
+	JobType        JobType        `json:"job_type"`
+	Name           string         `json:"name"`
+	UserID         string         `json:"user_id"`
+	StatusCode     StatusCode     `json:"status_code"`
+	RequestTime    time.Time      `json:"request_time"`
+	ExpirationTime *time.Time     `json:"expiration_time"`
+	JobParameters  map[string]any `json:"job_parameters"`
+	Files          []File         `json:"files"`
+	BrowseImages   []string       `json:"browse_images"`
+	CreditCost     float64        `json:"credit_cost"`
+}
+
+// File is a product file of a finished job.
+type File struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	URL      string `json:"url"`
+}
+
+// Client talks to the HyP3 API, authenticating through an asf.Client.
+type Client struct {
+	asf     *asf.Client
+	baseURL string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at another HyP3 deployment, such as the test
+// API.
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		c.baseURL = u
+	}
+}
+
+// NewClient returns a HyP3 client that sends its requests, and downloads, with
+// client's credentials, retry policy and logging.
+func NewClient(client *asf.Client, opts ...Option) *Client {
+	c := &Client{asf: client, baseURL: DefaultBaseURL}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Submit submits jobs in a single request and returns them as accepted. To
+// avoid submitting jobs twice, the request is only retried after a 429
+// response, not after a timeout or 5xx response that HyP3 may have acted on.
+func (c *Client) Submit(ctx context.Context, jobs ...JobRequest) ([]Job, error) {
+	body, err := json.Marshal(struct {
+		Jobs []JobRequest `json:"jobs"`
+	}{jobs})
+	if err != nil {
+		return nil, fmt.Errorf("hyp3: encode jobs: %w", err)
+	}
+	var payload struct {
+		Jobs []Job `json:"jobs"`
+	}
+	if err := c.call(asf.NonIdempotent(ctx), http.MethodPost, body, &payload, "jobs"); err != nil {
+		return nil, err
+	}
+	return payload.Jobs, nil
+}
+
+// Job fetches the current state of a job.
+func (c *Client) Job(ctx context.Context, id string) (Job, error) {
+	var job Job
+	err := c.call(ctx, http.MethodGet, nil, &job, "jobs", id)
+	return job, err
+}
+
+// Wait polls a job every interval until it finishes. It returns the final
+// job, with an error wrapping ErrJobFailed if it failed.
+func (c *Client) Wait(ctx context.Context, id string, interval time.Duration) (Job, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job, err := c.Job(ctx, id)
+		if err != nil {
+			return job, err
+		}
+		if job.StatusCode == Failed {
+			return job, fmt.Errorf("%w: %s", ErrJobFailed, id)
+		}
+		if job.StatusCode.Done() {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Download saves the files of a finished job into dir.
+func (c *Client) Download(ctx context.Context, dir string, job Job) error {
+	if job.StatusCode != Succeeded {
+		return fmt.Errorf("hyp3: job %s has status %s, not %s", job.JobID, job.StatusCode, Succeeded)
+	}
+	urls := make([]string, 0, len(job.Files))
+	for _, f := range job.Files {
+		urls = append(urls, f.URL)
+	}
+	return c.asf.DownloadURLs(ctx, dir, urls...)
+}
+
+// call sends a request to an API path below the base URL and decodes the JSON
+// response into out. An unexpected status is returned as an *asf.APIError.
+func (c *Client) call(ctx context.Context, method string, body []byte, out any, path ...string) error {
+	endpoint, err := url.JoinPath(c.baseURL, path...)
+	if err != nil {
+		return fmt.Errorf("hyp3: invalid base URL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("hyp3: create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.asf.Do(req)
+	if err != nil {
+		return fmt.Errorf("hyp3: send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("hyp3: %s %s: %w", method, req.URL.Path, c.asf.StatusError(resp))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("hyp3: decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package hyp3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestSubmitWaitDownload(t *testing.T) {
+	var polls atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/jobs":
+			var req struct {
+				Jobs []JobRequest `json:"jobs"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode submission: %v", err)
+			}
+			if len(req.Jobs) != 1 || req.Jobs[0].JobType != InSAR || req.Jobs[0].Name != "pair" {
+				t.Errorf("unexpected submission %+v", req.Jobs)
+			}
+			fmt.Fprint(w, `{"jobs":[{"job_id":"j1","job_type":"INSAR_GAMMA","name":"pair","status_code":"PENDING"}]}`)
+		case r.URL.Path == "/jobs/j1":
+			status := "RUNNING"
+			if polls.Add(1) >= 2 {
+				status = "SUCCEEDED"
+			}
+			fmt.Fprintf(w, `{"job_id":"j1","status_code":%q,"files":[{"filename":"ifg.zip","size":4,"url":%q}]}`,
+				status, server.URL+"/products/ifg.zip")
+		case r.URL.Path == "/products/ifg.zip":
+			w.Header().Set("Content-Type", "application/zip")
+			w.Write([]byte("data"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(asf.NewClient(asf.WithAuthToken("token")), WithBaseURL(server.URL))
+	reference := asf.Product{Properties: asf.Properties{SceneName: "S1A_REF"}}
+	secondary := asf.Product{Properties: asf.Properties{SceneName: "S1A_SEC"}}
+	jobs, err := client.Submit(context.Background(), InSARJob(reference, secondary, "pair"))
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].JobID != "j1" || jobs[0].StatusCode != Pending {
+		t.Fatalf("unexpected jobs %+v", jobs)
+	}
+
+	job, err := client.Wait(context.Background(), "j1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if job.StatusCode != Succeeded || polls.Load() != 2 {
+		t.Fatalf("unexpected job %+v after %d polls", job, polls.Load())
+	}
+
+	dir := t.TempDir()
+	if err := client.Download(context.Background(), dir, job); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "ifg.zip")); err != nil || string(data) != "data" {
+		t.Fatalf("unexpected download %q (err %v)", data, err)
+	}
+}
+
+func TestWaitFailedJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"job_id":"j2","status_code":"FAILED"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(asf.NewClient(), WithBaseURL(server.URL))
+	job, err := client.Wait(context.Background(), "j2", time.Millisecond)
+	if !errors.Is(err, ErrJobFailed) || job.StatusCode != Failed {
+		t.Fatalf("expected ErrJobFailed, got %v (%+v)", err, job)
+	}
+	if err := client.Download(context.Background(), t.TempDir(), job); err == nil {
+		t.Fatalf("expected Download of a failed job to fail")
+	}
+}
+
+func TestSubmitIsNotRetriedAfterServerErrors(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch posts.Add(1) {
+		case 1:
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		default:
+			http.Error(w, "gateway timeout", http.StatusGatewayTimeout)
+		}
+	}))
+	defer server.Close()
+
+	retry := asf.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond}
+	client := NewClient(asf.NewClient(asf.WithRetryPolicy(retry)), WithBaseURL(server.URL))
+	_, err := client.Submit(context.Background(), RTCJob(asf.Product{Properties: asf.Properties{SceneName: "S1A"}}, "rtc"))
+	var apiErr *asf.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected an APIError for status 504, got %v", err)
+	}
+	if got := posts.Load(); got != 2 {
+		t.Fatalf("expected the 429 to be retried and the 504 not, got %d submissions", got)
+	}
+}
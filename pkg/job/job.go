@@ -0,0 +1,234 @@
+// Package job runs declarative ingest jobs: a YAML file naming a search,
+// filters, a destination, a concurrency and a schedule, so that recurring
+// downloads are reproducible artifacts rather than shell scripts.
+//
+// A job file looks like:
+//
+//	name: alaska-s1
+//	query:
+//	  platforms: [Sentinel-1]
+//	  processingLevels: [SLC]
+//	  intersectsWith: POLYGON((-150 60, -145 60, -145 65, -150 65, -150 60))
+//	filters:
+//	  lookback: 72h
+//	  minCoverage: 0.5
+//	destination: ./data
+//	concurrency: 4
+//	schedule: 6h
+package job
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+	"github.com/robert-malhotra/go-asf/pkg/pipeline"
+)
+
+// Job is a declarative ingest job.
+type Job struct {
+	Name    string  `yaml:"name"`
+	Query   Query   `yaml:"query"`
+	Filters Filters `yaml:"filters"`
+	// Destination is the directory products are downloaded into; relative
+	// paths are resolved against the job file's directory by Load.
+	Destination string `yaml:"destination"`
+	// Concurrency, if positive, limits the number of concurrent downloads
+	// below the client's download concurrency.
+	Concurrency int `yaml:"concurrency"`
+	// Schedule is the interval between runs for Runner.RunScheduled; zero
+	// runs the job once.
+	Schedule time.Duration `yaml:"schedule"`
+}
+
+// Query is the search a job runs. Fields map to asf.SearchOptions.
+type Query struct {
+	Platforms        []asf.Platform        `yaml:"platforms"`
+	BeamModes        []asf.BeamMode        `yaml:"beamModes"`
+	Polarizations    []asf.Polarization    `yaml:"polarizations"`
+	ProductTypes     []asf.ProductType     `yaml:"productTypes"`
+	ProcessingLevels []asf.ProcessingLevel `yaml:"processingLevels"`
+	Datasets         []asf.Dataset         `yaml:"datasets"`
+	FlightDirection  asf.FlightDirection   `yaml:"flightDirection"`
+	RelativeOrbits   []int                 `yaml:"relativeOrbits"`
+	IntersectsWith   string                `yaml:"intersectsWith"`
+	GranuleIDs       []string              `yaml:"granules"`
+	Start            time.Time             `yaml:"start"`
+	End              time.Time             `yaml:"end"`
+	MaxResults       int                   `yaml:"maxResults"`
+}
+
+// Filters narrow the products a job downloads beyond what the search
+// expresses.
+type Filters struct {
+	// Lookback limits a recurring job to products acquired in this window
+	// before each run, overriding Query.Start.
+	Lookback time.Duration `yaml:"lookback"`
+	// MinCoverage drops products covering less than this fraction (0 to 1)
+	// of Query.IntersectsWith; see asf.FilterByCoverage.
+	MinCoverage float64 `yaml:"minCoverage"`
+	// Redownload fetches products again even when their file already
+	// exists in the destination. By default existing files are skipped, so
+	// repeated runs only download what is new.
+	Redownload bool `yaml:"redownload"`
+}
+
+// Load reads and validates a job file.
+func Load(path string) (*Job, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("job: %w", err)
+	}
+	job, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("job: %s: %w", path, err)
+	}
+	if !filepath.IsAbs(job.Destination) {
+		job.Destination = filepath.Join(filepath.Dir(path), job.Destination)
+	}
+	return job, nil
+}
+
+// Parse decodes and validates a job from YAML. Unknown keys are rejected so
+// that typos do not silently widen a query.
+func Parse(data []byte) (*Job, error) {
+	var job Job
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&job); err != nil {
+		return nil, fmt.Errorf("parse job: %w", err)
+	}
+	if err := job.validate(); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (j *Job) validate() error {
+	switch {
+	case j.Destination == "":
+		return errors.New("destination is required")
+	case j.Concurrency < 0 || j.Schedule < 0 || j.Filters.Lookback < 0:
+		return errors.New("concurrency, schedule and lookback must not be negative")
+	case j.Filters.MinCoverage < 0 || j.Filters.MinCoverage > 1:
+		return errors.New("minCoverage must be between 0 and 1")
+	case j.Filters.MinCoverage > 0 && j.Query.IntersectsWith == "":
+		return errors.New("minCoverage requires query.intersectsWith")
+	case j.Query.MaxResults < 0:
+		return errors.New("maxResults must not be negative")
+	case !j.Query.constrained() && j.Filters.Lookback == 0 && j.Query.MaxResults == 0:
+		// An empty query would download the whole archive.
+		return errors.New("query needs at least one constraint, a lookback or maxResults")
+	}
+	return nil
+}
+
+// constrained reports whether the query sets any search constraint.
+func (q Query) constrained() bool {
+	return len(q.Platforms) > 0 || len(q.BeamModes) > 0 || len(q.Polarizations) > 0 ||
+		len(q.ProductTypes) > 0 || len(q.ProcessingLevels) > 0 || len(q.Datasets) > 0 ||
+		q.FlightDirection != "" || len(q.RelativeOrbits) > 0 || q.IntersectsWith != "" ||
+		len(q.GranuleIDs) > 0 || !q.Start.IsZero() || !q.End.IsZero()
+}
+
+// SearchOptions returns the search the job runs at time now.
+func (j *Job) SearchOptions(now time.Time) asf.SearchOptions {
+	q := j.Query
+	opts := asf.SearchOptions{
+		Platforms:       q.Platforms,
+		BeamModes:       q.BeamModes,
+		Polarizations:   q.Polarizations,
+		ProductTypes:    q.ProductTypes,
+		ProcessingLevel: q.ProcessingLevels,
+		Datasets:        q.Datasets,
+		FlightDirection: q.FlightDirection,
+		RelativeOrbits:  q.RelativeOrbits,
+		IntersectsWith:  q.IntersectsWith,
+		GranuleIDs:      q.GranuleIDs,
+		Start:           q.Start,
+		End:             q.End,
+		MaxResults:      q.MaxResults,
+	}
+	if j.Filters.Lookback > 0 {
+		opts.Start = now.Add(-j.Filters.Lookback)
+	}
+	return opts
+}
+
+// Runner executes jobs with a configured client.
+type Runner struct {
+	Client *asf.Client
+	// OnResult, if set, is called as each download of a run finishes.
+	OnResult func(asf.DownloadResult)
+}
+
+// Run executes one run of job and returns its report.
+func (r *Runner) Run(ctx context.Context, job *Job) (*pipeline.Report, error) {
+	filter, err := job.filter()
+	if err != nil {
+		return nil, err
+	}
+	p := &pipeline.Pipeline{
+		Client:   r.Client,
+		Search:   job.SearchOptions(time.Now()),
+		Filter:   filter,
+		Dir:      job.Destination,
+		Workers:  job.Concurrency,
+		OnResult: r.OnResult,
+	}
+	return p.Run(ctx)
+}
+
+// RunScheduled runs job, then again every job.Schedule, until ctx is done.
+// A failed run is reported through onRun and does not stop the schedule.
+// Jobs without a schedule run once.
+func (r *Runner) RunScheduled(ctx context.Context, job *Job, onRun func(*pipeline.Report, error)) error {
+	for {
+		report, err := r.Run(ctx, job)
+		if onRun != nil {
+			onRun(report, err)
+		}
+		if job.Schedule == 0 {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(job.Schedule):
+		}
+	}
+}
+
+// filter returns the product filter implementing job.Filters.
+func (j *Job) filter() (func(asf.Product) bool, error) {
+	var aoi asf.Geometry
+	if j.Filters.MinCoverage > 0 {
+		var err error
+		if aoi, err = asf.ParseWKT(j.Query.IntersectsWith); err != nil {
+			return nil, fmt.Errorf("job: intersectsWith: %w", err)
+		}
+	}
+	return func(p asf.Product) bool {
+		if j.Filters.MinCoverage > 0 {
+			footprint, err := p.ParseGeometry()
+			if err == nil && !footprint.IsEmpty() && asf.Coverage(footprint, aoi) < j.Filters.MinCoverage {
+				return false
+			}
+		}
+		if !j.Filters.Redownload {
+			_, err := os.Stat(filepath.Join(j.Destination, p.Properties.FileName))
+			if !errors.Is(err, fs.ErrNotExist) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
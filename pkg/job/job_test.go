@@ -0,0 +1,98 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestParse(t *testing.T) {
+	job, err := Parse([]byte(`
+name: alaska
+query:
+  platforms: [Sentinel-1]
+  processingLevels: [SLC]
+  relativeOrbits: [15, 16]
+  start: 2024-01-01T00:00:00Z
+filters:
+  lookback: 72h
+destination: ./data
+concurrency: 2
+schedule: 6h
+`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if job.Name != "alaska" || job.Concurrency != 2 || job.Schedule != 6*time.Hour || job.Filters.Lookback != 72*time.Hour {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	opts := job.SearchOptions(now)
+	if len(opts.Platforms) != 1 || opts.Platforms[0] != "Sentinel-1" || opts.ProcessingLevel[0] != "SLC" ||
+		len(opts.RelativeOrbits) != 2 || !opts.Start.Equal(now.Add(-72*time.Hour)) {
+		t.Fatalf("unexpected search options %+v", opts)
+	}
+
+	for _, bad := range []string{
+		"destination: d\nquery:\n  platform: [Sentinel-1]\n", // typo
+		"query:\n  platforms: [Sentinel-1]\n",                // no destination
+		"destination: d\nfilters:\n  minCoverage: 0.5\n",     // no AOI
+		"destination: d\n",                           // whole archive
+		"destination: d\nquery:\n  maxResults: -1\n", // negative limit
+	} {
+		if _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestRunnerSkipsExistingFiles(t *testing.T) {
+	var server *httptest.Server
+	var downloads []string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".zip") {
+			downloads = append(downloads, r.URL.Path)
+			w.Write([]byte("data"))
+			return
+		}
+		var features []string
+		for _, name := range []string{"old", "new"} {
+			features = append(features, fmt.Sprintf(
+				`{"properties":{"sceneName":%q,"fileName":"%s.zip","url":"%s/%s.zip"}}`, name, name, server.URL, name))
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "job.yaml")
+	if err := os.WriteFile(path, []byte("destination: data\nconcurrency: 1\nquery:\n  platforms: [Sentinel-1]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	job, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if job.Destination != filepath.Join(dir, "data") {
+		t.Fatalf("expected destination relative to the job file, got %q", job.Destination)
+	}
+	os.MkdirAll(job.Destination, 0755)
+	os.WriteFile(filepath.Join(job.Destination, "old.zip"), []byte("data"), 0644)
+
+	runner := &Runner{Client: asf.NewClient(asf.WithBaseURL(server.URL))}
+	report, err := runner.Run(context.Background(), job)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if report.Filtered != 1 || len(downloads) != 1 || downloads[0] != "/new.zip" {
+		t.Fatalf("expected only new.zip to be downloaded, got %v (report %+v)", downloads, report)
+	}
+}
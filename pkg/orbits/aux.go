@@ -0,0 +1,109 @@
+package orbits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// AuxType selects a Sentinel-1 auxiliary product.
+type AuxType string
+
+const (
+	// Calibration is the AUX_CAL calibration auxiliary product.
+	Calibration AuxType = "AUX_CAL"
+	// Instrument is the AUX_INS instrument auxiliary product.
+	Instrument AuxType = "AUX_INS"
+)
+
+// ErrNoAuxFile reports that no auxiliary product of the requested type was
+// valid at the acquisition time.
+var ErrNoAuxFile = errors.New("orbits: no auxiliary file is valid at the acquisition time")
+
+var auxNamePattern = regexp.MustCompile(`(S1[A-D])_(AUX_(?:CAL|INS))_V(\d{8}T\d{6})_G(\d{8}T\d{6})\.SAFE(?:\.zip|\.TGZ)?`)
+
+// AuxFile describes an auxiliary product on the server.
+type AuxFile struct {
+	Name string
+	URL  string
+	// ValidFrom is the start of validity; a file applies until a newer one
+	// becomes valid.
+	ValidFrom time.Time
+	Generated time.Time
+}
+
+// FetchAuxFile downloads the auxiliary product of auxType that applied to
+// platform (such as "S1A") at the acquisition time into dir and returns its
+// path. An existing file is reused. The options configure the client as for
+// FetchOrbitFile.
+func FetchAuxFile(ctx context.Context, platform string, acquired time.Time, auxType AuxType, dir string, opts ...asf.Option) (string, error) {
+	client := newClient(opts)
+	aux, err := findAux(ctx, client, platform, acquired, auxType)
+	if err != nil {
+		return "", err
+	}
+	return download(ctx, client, aux.Name, aux.URL, dir)
+}
+
+// AuxURL resolves the auxiliary product of auxType that applied to platform at
+// the acquisition time without downloading it.
+func AuxURL(ctx context.Context, platform string, acquired time.Time, auxType AuxType, opts ...asf.Option) (string, error) {
+	aux, err := findAux(ctx, newClient(opts), platform, acquired, auxType)
+	if err != nil {
+		return "", err
+	}
+	return aux.URL, nil
+}
+
+// findAux picks the file with the latest validity start not after acquired,
+// preferring the most recently generated among equals.
+func findAux(ctx context.Context, client *asf.Client, platform string, acquired time.Time, auxType AuxType) (AuxFile, error) {
+	files, err := listAux(ctx, client, auxType)
+	if err != nil {
+		return AuxFile{}, err
+	}
+	var best AuxFile
+	for _, f := range files {
+		if f.Name[:3] != platform || f.ValidFrom.After(acquired) {
+			continue
+		}
+		if best.Name == "" || f.ValidFrom.After(best.ValidFrom) ||
+			(f.ValidFrom.Equal(best.ValidFrom) && f.Generated.After(best.Generated)) {
+			best = f
+		}
+	}
+	if best.Name == "" {
+		return AuxFile{}, fmt.Errorf("%w: %s %s %s", ErrNoAuxFile, auxType, platform, acquired.UTC().Format(time.RFC3339))
+	}
+	return best, nil
+}
+
+// listAux reads the server's directory listing for auxType.
+func listAux(ctx context.Context, client *asf.Client, auxType AuxType) ([]AuxFile, error) {
+	body, base, err := readListing(ctx, client, string(auxType))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []AuxFile
+	for _, m := range auxNamePattern.FindAllStringSubmatch(body, -1) {
+		name := m[0]
+		if seen[name] || m[2] != string(auxType) {
+			continue
+		}
+		seen[name] = true
+		validFrom, err1 := time.Parse(timeLayout, m[3])
+		generated, err2 := time.Parse(timeLayout, m[4])
+		if err := errors.Join(err1, err2); err != nil {
+			continue
+		}
+		ref, _ := base.Parse(name)
+		files = append(files, AuxFile{Name: name, URL: ref.String(), ValidFrom: validFrom, Generated: generated})
+	}
+	return files, nil
+}
@@ -0,0 +1,49 @@
+package orbits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestFetchAuxFile(t *testing.T) {
+	names := []string{
+		"S1A_AUX_CAL_V20190228T092500_G20210104T141310.SAFE.zip",
+		"S1A_AUX_CAL_V20190228T092500_G20220104T141310.SAFE.zip",
+		"S1A_AUX_CAL_V20240601T000000_G20240520T000000.SAFE.zip",
+		"S1B_AUX_CAL_V20200101T000000_G20200101T000000.SAFE.zip",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/aux_cal/" {
+			for _, name := range names {
+				fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", name, name)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write([]byte("PK"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	acquired := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	path, err := FetchAuxFile(context.Background(), "S1A", acquired, Calibration, dir, asf.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("FetchAuxFile returned error: %v", err)
+	}
+	if want := filepath.Join(dir, names[1]); path != want {
+		t.Fatalf("FetchAuxFile = %q, want %q", path, want)
+	}
+
+	_, err = AuxURL(context.Background(), "S1A", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), Calibration, asf.WithBaseURL(server.URL))
+	if !errors.Is(err, ErrNoAuxFile) {
+		t.Fatalf("expected ErrNoAuxFile, got %v", err)
+	}
+}
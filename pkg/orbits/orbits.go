@@ -0,0 +1,204 @@
+// Package orbits resolves and downloads the Sentinel-1 precise (POEORB) and
+// restituted (RESORB) orbit files matching a scene, as InSAR processing
+// needs alongside the SLC, and the AUX_CAL and AUX_INS auxiliary products
+// that processors such as ISCE and SNAP read.
+package orbits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// DefaultBaseURL is ASF's Sentinel-1 orbit file server.
+const DefaultBaseURL = "https://s1qc.asf.alaska.edu"
+
+// OrbitType selects the kind of orbit file.
+type OrbitType string
+
+const (
+	// Precise orbit ephemerides, available about three weeks after
+	// acquisition.
+	Precise OrbitType = "AUX_POEORB"
+	// Restituted orbits, available within hours but less accurate.
+	Restituted OrbitType = "AUX_RESORB"
+)
+
+// ErrNoOrbit reports that no orbit file of the requested type covers the
+// scene, typically because precise orbits are not yet published.
+var ErrNoOrbit = errors.New("orbits: no orbit file covers the scene")
+
+// margin is how far an orbit file must extend beyond the scene on each side,
+// so that interpolation near the scene edges is well supported.
+const margin = time.Minute
+
+// timeLayout is the compact timestamp layout used in scene and orbit names.
+const timeLayout = "20060102T150405"
+
+var (
+	sceneNamePattern = regexp.MustCompile(`^(S1[A-D])_.*?_(\d{8}T\d{6})_(\d{8}T\d{6})_`)
+	orbitNamePattern = regexp.MustCompile(`(S1[A-D])_OPER_(AUX_(?:POE|RES)ORB)_OPOD_(\d{8}T\d{6})_V(\d{8}T\d{6})_(\d{8}T\d{6})\.EOF`)
+)
+
+// OrbitFile describes an orbit file on the server.
+type OrbitFile struct {
+	Name string
+	URL  string
+	// Produced is the file's production time; newer files supersede older
+	// ones with the same validity.
+	Produced   time.Time
+	ValidStart time.Time
+	ValidStop  time.Time
+}
+
+// FetchOrbitFile downloads the orbit file of orbitType matching a Sentinel-1
+// scene into dir and returns its path. An existing file is reused. The
+// options configure the client used for listing and downloading, such as
+// asf.WithAuthToken; the orbit server requires Earthdata credentials.
+func FetchOrbitFile(ctx context.Context, sceneName string, orbitType OrbitType, dir string, opts ...asf.Option) (string, error) {
+	client := newClient(opts)
+	orbit, err := findOrbit(ctx, client, sceneName, orbitType)
+	if err != nil {
+		return "", err
+	}
+	return download(ctx, client, orbit.Name, orbit.URL, dir)
+}
+
+// download saves the file at rawURL into dir as name, reusing an existing
+// copy, and returns its path.
+func download(ctx context.Context, client *asf.Client, name, rawURL, dir string) (string, error) {
+	path := filepath.Join(dir, name)
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		return path, nil
+	}
+	if err := client.DownloadURLs(ctx, dir, rawURL); err != nil {
+		return "", fmt.Errorf("orbits: download %s: %w", name, err)
+	}
+	return path, nil
+}
+
+// OrbitURL resolves the orbit file of orbitType matching a Sentinel-1 scene
+// without downloading it.
+func OrbitURL(ctx context.Context, sceneName string, orbitType OrbitType, opts ...asf.Option) (string, error) {
+	orbit, err := findOrbit(ctx, newClient(opts), sceneName, orbitType)
+	if err != nil {
+		return "", err
+	}
+	return orbit.URL, nil
+}
+
+// newClient creates a client for the orbit server; opts may override its
+// base URL.
+func newClient(opts []asf.Option) *asf.Client {
+	return asf.NewClient(append([]asf.Option{asf.WithBaseURL(DefaultBaseURL)}, opts...)...)
+}
+
+// findOrbit lists the orbit files of orbitType and picks the newest one
+// covering the scene.
+func findOrbit(ctx context.Context, client *asf.Client, sceneName string, orbitType OrbitType) (OrbitFile, error) {
+	platform, start, stop, err := parseSceneName(sceneName)
+	if err != nil {
+		return OrbitFile{}, err
+	}
+	files, err := listOrbits(ctx, client, orbitType)
+	if err != nil {
+		return OrbitFile{}, err
+	}
+
+	var best OrbitFile
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name, platform+"_") {
+			continue
+		}
+		if f.ValidStart.After(start.Add(-margin)) || f.ValidStop.Before(stop.Add(margin)) {
+			continue
+		}
+		if best.Name == "" || f.Produced.After(best.Produced) {
+			best = f
+		}
+	}
+	if best.Name == "" {
+		return OrbitFile{}, fmt.Errorf("%w: %s %s", ErrNoOrbit, orbitType, sceneName)
+	}
+	return best, nil
+}
+
+// listOrbits reads the server's directory listing for orbitType.
+func listOrbits(ctx context.Context, client *asf.Client, orbitType OrbitType) ([]OrbitFile, error) {
+	body, base, err := readListing(ctx, client, string(orbitType))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []OrbitFile
+	for _, m := range orbitNamePattern.FindAllStringSubmatch(body, -1) {
+		name := m[0]
+		if seen[name] || m[2] != string(orbitType) {
+			continue
+		}
+		seen[name] = true
+		produced, err1 := time.Parse(timeLayout, m[3])
+		validStart, err2 := time.Parse(timeLayout, m[4])
+		validStop, err3 := time.Parse(timeLayout, m[5])
+		if err := errors.Join(err1, err2, err3); err != nil {
+			continue
+		}
+		ref, _ := base.Parse(name)
+		files = append(files, OrbitFile{
+			Name:       name,
+			URL:        ref.String(),
+			Produced:   produced,
+			ValidStart: validStart,
+			ValidStop:  validStop,
+		})
+	}
+	return files, nil
+}
+
+// readListing fetches the server's directory listing for a file type such as
+// AUX_POEORB, returning its body and the URL file names are relative to.
+func readListing(ctx context.Context, client *asf.Client, fileType string) (string, *url.URL, error) {
+	resp, err := client.Raw(ctx, strings.ToLower(fileType)+"/", nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("orbits: list %s: %w", fileType, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("orbits: list %s: %w", fileType, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("orbits: list %s: unexpected status %d", fileType, resp.StatusCode)
+	}
+	return string(body), resp.Request.URL, nil
+}
+
+// parseSceneName extracts the platform and acquisition window from a
+// Sentinel-1 scene name such as
+// S1A_IW_SLC__1SDV_20240501T100000_20240501T100030_053000_066C8A_1A2B.
+func parseSceneName(sceneName string) (string, time.Time, time.Time, error) {
+	m := sceneNamePattern.FindStringSubmatch(sceneName)
+	if m == nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("orbits: %q is not a Sentinel-1 scene name", sceneName)
+	}
+	start, err := time.Parse(timeLayout, m[2])
+	if err != nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("orbits: parse start time of %q: %w", sceneName, err)
+	}
+	stop, err := time.Parse(timeLayout, m[3])
+	if err != nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("orbits: parse stop time of %q: %w", sceneName, err)
+	}
+	return m[1], start, stop, nil
+}
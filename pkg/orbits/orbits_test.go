@@ -0,0 +1,71 @@
+package orbits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+const scene = "S1A_IW_SLC__1SDV_20240501T100000_20240501T100030_053000_066C8A_1A2B"
+
+func orbitServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	names := []string{
+		// Older production of the matching validity window.
+		"S1A_OPER_AUX_POEORB_OPOD_20240520T070000_V20240430T225942_20240502T005942.EOF",
+		"S1A_OPER_AUX_POEORB_OPOD_20240521T070000_V20240430T225942_20240502T005942.EOF",
+		// Wrong platform and non-covering windows.
+		"S1B_OPER_AUX_POEORB_OPOD_20240521T070000_V20240430T225942_20240502T005942.EOF",
+		"S1A_OPER_AUX_POEORB_OPOD_20240521T070000_V20240501T100000_20240502T005942.EOF",
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/aux_poeorb/":
+			for _, name := range names {
+				fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", name, name)
+			}
+		case "/aux_resorb/":
+			w.Write([]byte("<html></html>"))
+		default:
+			fmt.Fprintf(w, "<?xml version=\"1.0\"?><Earth_Explorer_File>%s</Earth_Explorer_File>", filepath.Base(r.URL.Path))
+		}
+	}))
+}
+
+func TestFetchOrbitFile(t *testing.T) {
+	server := orbitServer(t)
+	defer server.Close()
+
+	dir := t.TempDir()
+	path, err := FetchOrbitFile(context.Background(), scene, Precise, dir, asf.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("FetchOrbitFile returned error: %v", err)
+	}
+	want := "S1A_OPER_AUX_POEORB_OPOD_20240521T070000_V20240430T225942_20240502T005942.EOF"
+	if path != filepath.Join(dir, want) {
+		t.Fatalf("FetchOrbitFile = %q, want %q", path, want)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected orbit file on disk: %v", err)
+	}
+}
+
+func TestOrbitURLNoMatch(t *testing.T) {
+	server := orbitServer(t)
+	defer server.Close()
+
+	_, err := OrbitURL(context.Background(), scene, Restituted, asf.WithBaseURL(server.URL))
+	if !errors.Is(err, ErrNoOrbit) {
+		t.Fatalf("expected ErrNoOrbit, got %v", err)
+	}
+	if _, err := OrbitURL(context.Background(), "not-a-scene", Precise, asf.WithBaseURL(server.URL)); err == nil {
+		t.Fatalf("expected an error for an invalid scene name")
+	}
+}
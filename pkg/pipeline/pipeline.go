@@ -0,0 +1,105 @@
+// Package pipeline runs the standard harvest workflow, search, filter,
+// download, verify and report, as one configurable object, so services do
+// not have to assemble channels and worker pools around the client
+// themselves.
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// Pipeline streams the results of a search through an optional filter into
+// the client's bounded download queue (see asf.Client.DownloadSeq). Search
+// pages are fetched only as the queue drains, so memory use stays flat
+// however many products match. Download concurrency, queue size and batch
+// mode follow the client's options, such as asf.WithDownloadConcurrency and
+// asf.WithBatchMode, and downloads are verified as configured on the
+// client, for example with asf.WithChecksumVerification.
+type Pipeline struct {
+	Client *asf.Client
+	Search asf.SearchOptions
+	// Filter, if set, keeps only the products for which it returns true.
+	Filter func(asf.Product) bool
+	// Dir is the directory products are downloaded into.
+	Dir string
+	// Workers, if positive, limits how many products are queued or
+	// downloading at once, below the client's download concurrency.
+	Workers int
+	// OnResult, if set, is called as each download finishes, from the
+	// worker that ran it.
+	OnResult func(asf.DownloadResult)
+}
+
+// Report summarizes a pipeline run.
+type Report struct {
+	// Matched is the number of products the search returned.
+	Matched int
+	// Filtered is the number dropped by the filter.
+	Filtered int
+	// Results holds one entry per product queued for download, in
+	// completion order.
+	Results []asf.DownloadResult
+}
+
+// Failed returns the results of the downloads that failed.
+func (r *Report) Failed() []asf.DownloadResult {
+	var failed []asf.DownloadResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Run executes the pipeline until the search is exhausted and every queued
+// product is downloaded. It returns the report together with the search
+// error, if any, and a *asf.BatchError listing the failed downloads.
+func (p *Pipeline) Run(ctx context.Context) (*Report, error) {
+	report := &Report{}
+	var slots chan struct{}
+	if p.Workers > 0 {
+		slots = make(chan struct{}, p.Workers)
+	}
+	products := func(yield func(asf.Product, error) bool) {
+		for product, err := range p.Client.SearchIter(ctx, p.Search) {
+			if err != nil {
+				yield(asf.Product{}, err)
+				return
+			}
+			report.Matched++
+			if p.Filter != nil && !p.Filter(product) {
+				report.Filtered++
+				continue
+			}
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					yield(asf.Product{}, context.Cause(ctx))
+					return
+				}
+			}
+			if !yield(product, nil) {
+				return
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	err := p.Client.DownloadSeq(ctx, p.Dir, products, func(result asf.DownloadResult) {
+		if p.OnResult != nil {
+			p.OnResult(result)
+		}
+		mu.Lock()
+		report.Results = append(report.Results, result)
+		mu.Unlock()
+		if slots != nil {
+			<-slots
+		}
+	})
+	return report, err
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestPipelineRun(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".zip") && r.URL.Path != "/files/bad.zip":
+			w.Write([]byte("data"))
+		case strings.HasSuffix(r.URL.Path, ".zip"):
+			http.NotFound(w, r)
+		default:
+			var features []string
+			for _, name := range []string{"a", "b", "skip", "bad"} {
+				features = append(features, fmt.Sprintf(
+					`{"properties":{"sceneName":%q,"fileName":"%s.zip","url":"%s/files/%s.zip"}}`, name, name, server.URL, name))
+			}
+			fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	var reported atomic.Int32
+	p := &Pipeline{
+		Client:   asf.NewClient(asf.WithBaseURL(server.URL)),
+		Filter:   func(p asf.Product) bool { return p.Properties.SceneName != "skip" },
+		Dir:      dir,
+		Workers:  2,
+		OnResult: func(asf.DownloadResult) { reported.Add(1) },
+	}
+	report, err := p.Run(context.Background())
+
+	var batchErr *asf.BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || batchErr.Errors[0].Product.Properties.SceneName != "bad" {
+		t.Fatalf("expected only bad.zip to fail, got %v", err)
+	}
+	if report.Matched != 4 || report.Filtered != 1 || len(report.Results) != 3 || reported.Load() != 3 {
+		t.Fatalf("unexpected report %+v (%d reported)", report, reported.Load())
+	}
+	for _, name := range []string{"a.zip", "b.zip"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("expected %s to be downloaded: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "skip.zip")); err == nil {
+		t.Fatalf("expected the filtered product not to be downloaded")
+	}
+}
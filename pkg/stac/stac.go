@@ -0,0 +1,280 @@
+// Package stac converts ASF search results into SpatioTemporal Asset Catalog
+// (STAC) Items and Collections, so they can feed STAC catalogs and tooling.
+// Items carry the SAR and Satellite extension fields that ASF metadata
+// supports.
+package stac
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// Version is the STAC specification version of the documents produced.
+const Version = "1.0.0"
+
+// Extension schema URIs used by Items.
+const (
+	SARExtension       = "https://stac-extensions.github.io/sar/v1.0.0/schema.json"
+	SatelliteExtension = "https://stac-extensions.github.io/sat/v1.0.0/schema.json"
+)
+
+// Item is a STAC Item describing one product.
+type Item struct {
+	Type         string           `json:"type"`
+	Version      string           `json:"stac_version"`
+	Extensions   []string         `json:"stac_extensions"`
+	ID           string           `json:"id"`
+	Geometry     json.RawMessage  `json:"geometry"`
+	BBox         []float64        `json:"bbox,omitempty"`
+	Properties   map[string]any   `json:"properties"`
+	Links        []Link           `json:"links"`
+	Assets       map[string]Asset `json:"assets"`
+	CollectionID string           `json:"collection,omitempty"`
+}
+
+// Asset is a file belonging to an Item.
+type Asset struct {
+	Href  string   `json:"href"`
+	Title string   `json:"title,omitempty"`
+	Type  string   `json:"type,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Link relates a STAC document to another resource.
+type Link struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+	Type string `json:"type,omitempty"`
+}
+
+// ItemCollection is a GeoJSON FeatureCollection of Items, the form STAC APIs
+// use for search results.
+type ItemCollection struct {
+	Type     string `json:"type"`
+	Features []Item `json:"features"`
+}
+
+// Collection is a STAC Collection summarizing a set of Items.
+type Collection struct {
+	Type        string `json:"type"`
+	Version     string `json:"stac_version"`
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	License     string `json:"license"`
+	Extent      Extent `json:"extent"`
+	Links       []Link `json:"links"`
+}
+
+// Extent is the spatial and temporal extent of a Collection.
+type Extent struct {
+	Spatial  SpatialExtent  `json:"spatial"`
+	Temporal TemporalExtent `json:"temporal"`
+}
+
+// SpatialExtent holds bounding boxes; the first covers all Items.
+type SpatialExtent struct {
+	BBox [][]float64 `json:"bbox"`
+}
+
+// TemporalExtent holds time intervals; nil bounds are open.
+type TemporalExtent struct {
+	Interval [][]*time.Time `json:"interval"`
+}
+
+// NewItem converts a product into a STAC Item. The Item ID is the product's
+// FileID, falling back to its scene name, and the collection is left empty.
+func NewItem(p asf.Product) (Item, error) {
+	props := p.Properties
+	id := props.FileID
+	if id == "" {
+		id = props.SceneName
+	}
+	if id == "" {
+		return Item{}, fmt.Errorf("stac: product has neither FileID nor SceneName")
+	}
+
+	item := Item{
+		Type:       "Feature",
+		Version:    Version,
+		Extensions: []string{SARExtension, SatelliteExtension},
+		ID:         id,
+		Geometry:   json.RawMessage("null"),
+		Properties: itemProperties(props),
+		Links:      []Link{},
+		Assets:     map[string]Asset{},
+	}
+	geometry, err := p.ParseGeometry()
+	if err != nil {
+		return Item{}, fmt.Errorf("stac: %s: %w", id, err)
+	}
+	if !geometry.IsEmpty() {
+		bounds := geometry.Bounds()
+		item.Geometry, item.BBox = p.Geometry, bounds[:]
+	}
+	if props.URL != "" {
+		item.Assets["data"] = Asset{Href: props.URL, Title: props.FileName, Type: mediaType(props.URL), Roles: []string{"data"}}
+	}
+	if props.Browse != "" {
+		item.Assets["thumbnail"] = Asset{Href: props.Browse, Type: mediaType(props.Browse), Roles: []string{"thumbnail"}}
+	}
+	return item, nil
+}
+
+// NewItems converts products into Items, all belonging to collectionID when
+// it is not empty.
+func NewItems(products []asf.Product, collectionID string) ([]Item, error) {
+	items := make([]Item, 0, len(products))
+	for _, p := range products {
+		item, err := NewItem(p)
+		if err != nil {
+			return nil, err
+		}
+		item.CollectionID = collectionID
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// NewItemCollection wraps Items in an ItemCollection.
+func NewItemCollection(items []Item) ItemCollection {
+	if items == nil {
+		items = []Item{}
+	}
+	return ItemCollection{Type: "FeatureCollection", Features: items}
+}
+
+// NewCollection returns a Collection whose extent covers items.
+func NewCollection(id, description string, items []Item) Collection {
+	collection := Collection{
+		Type:        "Collection",
+		Version:     Version,
+		ID:          id,
+		Description: description,
+		License:     "proprietary",
+		Extent: Extent{
+			Spatial:  SpatialExtent{BBox: [][]float64{{-180, -90, 180, 90}}},
+			Temporal: TemporalExtent{Interval: [][]*time.Time{{nil, nil}}},
+		},
+		Links: []Link{},
+	}
+
+	var bbox []float64
+	var start, end *time.Time
+	for _, item := range items {
+		if item.BBox != nil {
+			bbox = unionBBox(bbox, item.BBox)
+		}
+		if t, ok := itemTime(item, "start_datetime"); ok && (start == nil || t.Before(*start)) {
+			start = &t
+		}
+		if t, ok := itemTime(item, "end_datetime"); ok && (end == nil || t.After(*end)) {
+			end = &t
+		}
+	}
+	if bbox != nil {
+		collection.Extent.Spatial.BBox = [][]float64{bbox}
+	}
+	collection.Extent.Temporal.Interval = [][]*time.Time{{start, end}}
+	return collection
+}
+
+// itemProperties maps product metadata to Item properties.
+func itemProperties(props asf.Properties) map[string]any {
+	properties := map[string]any{"datetime": nil}
+	if !props.StartTime.IsZero() {
+		properties["datetime"] = props.StartTime.UTC()
+		properties["start_datetime"] = props.StartTime.UTC()
+	}
+	if !props.StopTime.IsZero() {
+		properties["end_datetime"] = props.StopTime.UTC()
+	}
+	setIfNonEmpty(properties, "platform", strings.ToLower(props.Platform))
+	if props.Sensor != "" {
+		properties["instruments"] = []string{strings.ToLower(props.Sensor)}
+	}
+
+	setIfNonEmpty(properties, "sar:instrument_mode", props.BeamModeType)
+	setIfNonEmpty(properties, "sar:frequency_band", frequencyBand(props.Platform))
+	setIfNonEmpty(properties, "sar:product_type", props.ProcessingLevel)
+	if pols := polarizations(props.Polarization); len(pols) > 0 {
+		properties["sar:polarizations"] = pols
+	}
+
+	setIfNonEmpty(properties, "sat:orbit_state", strings.ToLower(props.FlightDirection))
+	if props.Orbit > 0 {
+		properties["sat:absolute_orbit"] = props.Orbit
+	}
+	if props.PathNumber > 0 {
+		properties["sat:relative_orbit"] = props.PathNumber
+	}
+	return properties
+}
+
+func setIfNonEmpty(properties map[string]any, key, value string) {
+	if value != "" {
+		properties[key] = value
+	}
+}
+
+// frequencyBand returns the radar band of a platform, or "" if unknown.
+func frequencyBand(platform string) string {
+	switch p := strings.ToUpper(platform); {
+	case strings.HasPrefix(p, "SENTINEL-1"), strings.HasPrefix(p, "RADARSAT"), p == "ERS-1", p == "ERS-2":
+		return "C"
+	case p == "UAVSAR", strings.HasPrefix(p, "ALOS"), p == "JERS-1", p == "SEASAT", p == "NISAR":
+		return "L"
+	default:
+		return ""
+	}
+}
+
+// polarizations splits ASF polarization strings such as "VV+VH" and
+// "HH+HV" into individual channels. Quad-pol products list all four.
+func polarizations(s string) []string {
+	if strings.EqualFold(s, string(asf.PolarizationQP)) {
+		return []string{"HH", "HV", "VH", "VV"}
+	}
+	var pols []string
+	for pol := range strings.SplitSeq(s, "+") {
+		if pol = strings.ToUpper(strings.TrimSpace(pol)); pol != "" {
+			pols = append(pols, pol)
+		}
+	}
+	return pols
+}
+
+// mediaType guesses an asset's media type from its URL.
+func mediaType(href string) string {
+	switch lower := strings.ToLower(href); {
+	case strings.HasSuffix(lower, ".zip"):
+		return "application/zip"
+	case strings.HasSuffix(lower, ".h5"):
+		return "application/x-hdf5"
+	case strings.HasSuffix(lower, ".tif"), strings.HasSuffix(lower, ".tiff"):
+		return "image/tiff; application=geotiff"
+	case strings.HasSuffix(lower, ".png"):
+		return "image/png"
+	case strings.HasSuffix(lower, ".jpg"), strings.HasSuffix(lower, ".jpeg"):
+		return "image/jpeg"
+	case strings.HasSuffix(lower, ".xml"):
+		return "application/xml"
+	default:
+		return ""
+	}
+}
+
+func unionBBox(a, b []float64) []float64 {
+	if a == nil {
+		return append([]float64(nil), b...)
+	}
+	return []float64{min(a[0], b[0]), min(a[1], b[1]), max(a[2], b[2]), max(a[3], b[3])}
+}
+
+func itemTime(item Item, key string) (time.Time, bool) {
+	t, ok := item.Properties[key].(time.Time)
+	return t, ok
+}
@@ -0,0 +1,93 @@
+package stac
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func loadProducts(t *testing.T) []asf.Product {
+	t.Helper()
+	file, err := os.Open("../asf/asf_response.json")
+	if err != nil {
+		t.Fatalf("failed to open asf_response.json: %v", err)
+	}
+	defer file.Close()
+	products, err := asf.ReadProducts(file)
+	if err != nil {
+		t.Fatalf("ReadProducts returned error: %v", err)
+	}
+	return products
+}
+
+func TestNewItem(t *testing.T) {
+	products := loadProducts(t)
+	item, err := NewItem(products[0])
+	if err != nil {
+		t.Fatalf("NewItem returned error: %v", err)
+	}
+
+	if item.ID != "S1C_IW_SLC__1SDV_20251028T021014_20251028T021042_004756_00963D_8B2E-SLC" {
+		t.Fatalf("unexpected item ID: %s", item.ID)
+	}
+	if want := []float64{-127.428642, 49.01503, -123.430382, 51.085098}; !reflect.DeepEqual(item.BBox, want) {
+		t.Fatalf("unexpected bbox: %v, want %v", item.BBox, want)
+	}
+	props := item.Properties
+	if props["sar:instrument_mode"] != "IW" || props["sar:frequency_band"] != "C" || props["sat:orbit_state"] != "ascending" {
+		t.Fatalf("unexpected SAR/sat properties: %v", props)
+	}
+	if pols, _ := props["sar:polarizations"].([]string); !reflect.DeepEqual(pols, []string{"VV", "VH"}) {
+		t.Fatalf("unexpected polarizations: %v", props["sar:polarizations"])
+	}
+	if item.Assets["data"].Href != products[0].Properties.URL || item.Assets["data"].Type != "application/zip" {
+		t.Fatalf("unexpected data asset: %+v", item.Assets["data"])
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("marshal item: %v", err)
+	}
+	var decoded map[string]any
+	json.Unmarshal(data, &decoded)
+	if decoded["stac_version"] != Version || decoded["type"] != "Feature" {
+		t.Fatalf("unexpected item JSON: %s", data)
+	}
+}
+
+func TestNewItemWithoutGeometry(t *testing.T) {
+	item, err := NewItem(asf.Product{Properties: asf.Properties{SceneName: "LITE"}})
+	if err != nil {
+		t.Fatalf("NewItem returned error: %v", err)
+	}
+	if string(item.Geometry) != "null" || item.BBox != nil || item.Properties["datetime"] != nil {
+		t.Fatalf("unexpected item for bare product: %+v", item)
+	}
+	if _, err := NewItem(asf.Product{}); err == nil {
+		t.Fatalf("expected error for product without ID")
+	}
+}
+
+func TestNewCollection(t *testing.T) {
+	items, err := NewItems(loadProducts(t), "sentinel-1-slc")
+	if err != nil {
+		t.Fatalf("NewItems returned error: %v", err)
+	}
+	for _, item := range items {
+		if item.CollectionID != "sentinel-1-slc" {
+			t.Fatalf("expected collection on item, got %q", item.CollectionID)
+		}
+	}
+
+	collection := NewCollection("sentinel-1-slc", "Sentinel-1 SLC scenes", items)
+	if len(collection.Extent.Spatial.BBox) != 1 || len(collection.Extent.Spatial.BBox[0]) != 4 {
+		t.Fatalf("unexpected spatial extent: %v", collection.Extent.Spatial)
+	}
+	interval := collection.Extent.Temporal.Interval[0]
+	if interval[0] == nil || interval[1] == nil || !interval[0].Before(*interval[1]) {
+		t.Fatalf("unexpected temporal extent: %v", interval)
+	}
+}
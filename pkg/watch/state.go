@@ -0,0 +1,133 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asfsync"
+)
+
+// State is the compact persisted state of a watched query.
+type State struct {
+	// QueryHash identifies the query the state belongs to; state saved for a
+	// different query is discarded.
+	QueryHash string `json:"queryHash"`
+	// Seen lists the file IDs already acknowledged, sorted. IDs of products
+	// processed before LastProcessingDate are dropped, since the search no
+	// longer returns them.
+	Seen []string `json:"seen"`
+	// LastProcessingDate bounds the next poll's search; every product
+	// processed before it has been acknowledged.
+	LastProcessingDate time.Time `json:"lastProcessingDate"`
+}
+
+// hasSeen reports whether id has already been acknowledged.
+func (s *State) hasSeen(id string) bool {
+	_, found := slices.BinarySearch(s.Seen, id)
+	return found
+}
+
+// markSeen records id as acknowledged, keeping Seen sorted.
+func (s *State) markSeen(id string) {
+	if i, found := slices.BinarySearch(s.Seen, id); !found {
+		s.Seen = slices.Insert(s.Seen, i, id)
+	}
+}
+
+// Store persists watch state between runs, allowing stateless deployments to
+// restore it from durable storage.
+type Store interface {
+	// Load returns the stored state, or nil and no error when none exists.
+	Load(ctx context.Context) (*State, error)
+	Save(ctx context.Context, state *State) error
+}
+
+// FileStore keeps state in a JSON file on the local file system.
+type FileStore struct {
+	Path string
+}
+
+// Load reads the state file, returning nil if it does not exist yet.
+func (s FileStore) Load(ctx context.Context) (*State, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watch: read state: %w", err)
+	}
+	return decodeState(data)
+}
+
+// Save writes the state file atomically.
+func (s FileStore) Save(ctx context.Context, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("watch: encode state: %w", err)
+	}
+	if err := asfsync.WriteFileAtomic(s.Path, data); err != nil {
+		return fmt.Errorf("watch: save state: %w", err)
+	}
+	return nil
+}
+
+// Blob is the minimal object storage interface needed to keep watch state in
+// S3, GCS or similar services. Get must return an error wrapping
+// fs.ErrNotExist when the object does not exist.
+type Blob interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Put(ctx context.Context, key string, body io.Reader) error
+}
+
+// BlobStore keeps state as a JSON object under Key in a Blob.
+type BlobStore struct {
+	Blob Blob
+	Key  string
+}
+
+// Load fetches the state object, returning nil if it does not exist yet.
+func (s BlobStore) Load(ctx context.Context) (*State, error) {
+	body, err := s.Blob.Get(ctx, s.Key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watch: get state: %w", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("watch: read state: %w", err)
+	}
+	return decodeState(data)
+}
+
+// Save uploads the state object.
+func (s BlobStore) Save(ctx context.Context, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("watch: encode state: %w", err)
+	}
+	if err := s.Blob.Put(ctx, s.Key, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("watch: put state: %w", err)
+	}
+	return nil
+}
+
+func decodeState(data []byte) (*State, error) {
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("watch: decode state: %w", err)
+	}
+	slices.Sort(state.Seen)
+	return &state, nil
+}
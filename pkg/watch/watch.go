@@ -0,0 +1,129 @@
+// Package watch polls a saved ASF search and reports only products that have
+// not been seen before, persisting its state between runs.
+package watch
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+	"github.com/robert-malhotra/go-asf/pkg/asfsync"
+)
+
+// Watcher reports new products for a saved search.
+type Watcher struct {
+	Client  *asf.Client
+	Options asf.SearchOptions
+	Store   Store
+
+	mu sync.Mutex
+	// state is the state loaded by the last poll.
+	state *State
+	// pending maps the products reported by the last poll and not yet
+	// acknowledged to their processing dates.
+	pending map[string]time.Time
+	// returned maps every product returned by the last poll to its
+	// processing date.
+	returned map[string]time.Time
+	// newest is the newest processing date returned by the last poll.
+	newest time.Time
+}
+
+// Poll runs the search once and returns the products not acknowledged by
+// earlier polls. State saved for a different query is ignored, so editing the
+// search starts from scratch.
+//
+// Poll does not save anything: pass the products that were handled to Ack,
+// and the rest are reported again by the next poll. The search is built on
+// asfsync, bounded by ProcessedAfter at the state's processing date, so
+// Options.MaxResults must be zero.
+func (w *Watcher) Poll(ctx context.Context) ([]asf.Product, error) {
+	hash, err := QueryHash(w.Options)
+	if err != nil {
+		return nil, err
+	}
+	state, err := w.Store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || state.QueryHash != hash {
+		state = &State{QueryHash: hash}
+	}
+
+	cursor := &asfsync.Cursor{QueryHash: hash, LastProcessingDate: state.LastProcessingDate}
+	products, _, err := asfsync.Since(ctx, w.Client, w.Options, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]time.Time)
+	returned := make(map[string]time.Time)
+	var newest time.Time
+	var fresh []asf.Product
+	for _, product := range products {
+		pd := product.Properties.ProcessingDate
+		if pd.After(newest) {
+			newest = pd
+		}
+		id := product.ID()
+		if _, dup := returned[id]; id == "" || dup {
+			continue
+		}
+		returned[id] = pd
+		if state.hasSeen(id) {
+			continue
+		}
+		pending[id] = pd
+		fresh = append(fresh, product)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state, w.pending, w.returned, w.newest = state, pending, returned, newest
+	return fresh, nil
+}
+
+// Ack records products returned by the last Poll as handled and saves the
+// state. Call it after every poll, with no products if none were handled, so
+// the processing-date bound can advance; the bound stops at the oldest
+// product still unacknowledged, so that product is found again.
+func (w *Watcher) Ack(ctx context.Context, products ...asf.Product) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.state == nil {
+		return errors.New("watch: Ack called before Poll")
+	}
+	for _, product := range products {
+		if id := product.ID(); id != "" {
+			w.state.markSeen(id)
+			delete(w.pending, id)
+		}
+	}
+
+	bound := w.newest
+	for _, pd := range w.pending {
+		if pd.Before(bound) {
+			bound = pd
+		}
+	}
+	if bound.After(w.state.LastProcessingDate) {
+		w.state.LastProcessingDate = bound
+	}
+	// Products processed before the bound are never returned again, and
+	// products missing from the last poll were processed before its bound.
+	w.state.Seen = slices.DeleteFunc(w.state.Seen, func(id string) bool {
+		pd, ok := w.returned[id]
+		return !ok || pd.Before(w.state.LastProcessingDate)
+	})
+	return w.Store.Save(ctx, w.state)
+}
+
+// QueryHash returns a stable identifier for the query described by opts.
+// Settings that do not change which products match, such as timeouts, page
+// size and wire format, are excluded.
+func QueryHash(opts asf.SearchOptions) (string, error) {
+	return asfsync.QueryHash(opts)
+}
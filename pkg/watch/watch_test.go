@@ -0,0 +1,215 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// memoryBlob is an in-memory Blob for tests.
+type memoryBlob struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (b *memoryBlob) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	data, ok := b.objects[key]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (b *memoryBlob) Put(ctx context.Context, key string, body io.Reader) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.objects == nil {
+		b.objects = make(map[string][]byte)
+	}
+	b.objects[key] = data
+	return nil
+}
+
+// sceneServer serves the given scene IDs as search results.
+func sceneServer(scenes *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var features []string
+		for i, id := range *scenes {
+			features = append(features, fmt.Sprintf(
+				`{"properties":{"fileID":%q,"sceneName":%q,"processingDate":"2024-01-0%dT00:00:00Z"}}`, id, id, i+1))
+		}
+		fmt.Fprintf(w, `{"features":[%s]}`, strings.Join(features, ","))
+	}))
+}
+
+func TestWatcherPollReportsOnlyNewProducts(t *testing.T) {
+	stores := map[string]Store{
+		"File": FileStore{Path: filepath.Join(t.TempDir(), "state.json")},
+		"Blob": BlobStore{Blob: &memoryBlob{}, Key: "watch/state.json"},
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			scenes := []string{"A", "B"}
+			server := sceneServer(&scenes)
+			defer server.Close()
+
+			w := &Watcher{
+				Client:  asf.NewClient(asf.WithBaseURL(server.URL)),
+				Options: asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1}},
+				Store:   store,
+			}
+			fresh, err := w.Poll(context.Background())
+			if err != nil {
+				t.Fatalf("first poll: %v", err)
+			}
+			if len(fresh) != 2 {
+				t.Fatalf("expected 2 new products on first poll, got %d", len(fresh))
+			}
+			if err := w.Ack(context.Background(), fresh...); err != nil {
+				t.Fatalf("first ack: %v", err)
+			}
+
+			scenes = append(scenes, "C")
+			// A new Watcher simulates a restarted, stateless container.
+			w = &Watcher{Client: w.Client, Options: w.Options, Store: store}
+			fresh, err = w.Poll(context.Background())
+			if err != nil {
+				t.Fatalf("second poll: %v", err)
+			}
+			if len(fresh) != 1 || fresh[0].Properties.FileID != "C" {
+				t.Fatalf("expected only C on second poll, got %+v", fresh)
+			}
+			if err := w.Ack(context.Background(), fresh...); err != nil {
+				t.Fatalf("second ack: %v", err)
+			}
+
+			state, err := store.Load(context.Background())
+			if err != nil {
+				t.Fatalf("load state: %v", err)
+			}
+			// A and B were processed before the bound, which already
+			// excludes them, so only C is remembered.
+			if strings.Join(state.Seen, ",") != "C" {
+				t.Fatalf("unexpected seen set: %v", state.Seen)
+			}
+			if !state.LastProcessingDate.Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)) {
+				t.Fatalf("unexpected last processing date: %s", state.LastProcessingDate)
+			}
+		})
+	}
+}
+
+func TestWatcherPollBoundsSearchByProcessingDate(t *testing.T) {
+	var bounds []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bounds = append(bounds, r.URL.Query().Get("processingDate"))
+		fmt.Fprint(w, `{"features":[{"properties":{"fileID":"A","processingDate":"2024-01-02T00:00:00Z"}}]}`)
+	}))
+	defer server.Close()
+
+	w := &Watcher{
+		Client:  asf.NewClient(asf.WithBaseURL(server.URL)),
+		Options: asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1}},
+		Store:   FileStore{Path: filepath.Join(t.TempDir(), "state.json")},
+	}
+	for i := 0; i < 2; i++ {
+		fresh, err := w.Poll(context.Background())
+		if err != nil {
+			t.Fatalf("poll %d: %v", i, err)
+		}
+		if err := w.Ack(context.Background(), fresh...); err != nil {
+			t.Fatalf("ack %d: %v", i, err)
+		}
+	}
+	if len(bounds) != 2 || bounds[0] != "" || bounds[1] != "2024-01-02T00:00:00Z" {
+		t.Fatalf("unexpected processingDate bounds %v", bounds)
+	}
+}
+
+func TestWatcherReportsUnacknowledgedProductsAgain(t *testing.T) {
+	scenes := []string{"A", "B", "C"}
+	server := sceneServer(&scenes)
+	defer server.Close()
+
+	store := FileStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	w := &Watcher{
+		Client:  asf.NewClient(asf.WithBaseURL(server.URL)),
+		Options: asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1}},
+		Store:   store,
+	}
+	fresh, err := w.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+	// B failed to process, so only A and C are acknowledged.
+	if err := w.Ack(context.Background(), fresh[0], fresh[2]); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	state, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if !state.LastProcessingDate.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("bound moved past the unacknowledged product: %s", state.LastProcessingDate)
+	}
+
+	w = &Watcher{Client: w.Client, Options: w.Options, Store: store}
+	fresh, err = w.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if len(fresh) != 1 || fresh[0].Properties.FileID != "B" {
+		t.Fatalf("expected B to be reported again, got %+v", fresh)
+	}
+}
+
+func TestWatcherResetsStateForChangedQuery(t *testing.T) {
+	scenes := []string{"A"}
+	server := sceneServer(&scenes)
+	defer server.Close()
+
+	store := FileStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	client := asf.NewClient(asf.WithBaseURL(server.URL))
+	first := &Watcher{Client: client, Options: asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1}}, Store: store}
+	fresh, err := first.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+	if err := first.Ack(context.Background(), fresh...); err != nil {
+		t.Fatalf("first ack: %v", err)
+	}
+
+	changed := &Watcher{Client: client, Options: asf.SearchOptions{Platforms: []asf.Platform{asf.PlatformSentinel1A}}, Store: store}
+	fresh, err = changed.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("poll with changed query: %v", err)
+	}
+	if len(fresh) != 1 {
+		t.Fatalf("expected state to reset for a different query, got %d new products", len(fresh))
+	}
+}
+
+func TestQueryHashIgnoresTransportSettings(t *testing.T) {
+	a, _ := QueryHash(asf.SearchOptions{MaxResults: 5})
+	b, _ := QueryHash(asf.SearchOptions{MaxResults: 5, PageSize: 100, Timeout: time.Minute, Output: asf.OutputJSONLite})
+	if a != b {
+		t.Fatalf("expected transport settings not to affect the query hash")
+	}
+}
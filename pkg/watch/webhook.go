@@ -0,0 +1,127 @@
+package watch
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of a webhook body, as
+// "sha256=" followed by the hex digest, when the Webhook has a Secret.
+const SignatureHeader = "X-ASF-Signature"
+
+// Webhook posts new products to an HTTP endpoint, such as a Slack or
+// operations integration.
+type Webhook struct {
+	URL string
+	// Secret, if set, signs each body with HMAC-SHA256 so the receiver can
+	// check it came from this watcher; see SignatureHeader.
+	Secret string
+	// HTTPClient sends the requests; nil uses a client that gives up on an
+	// attempt after 30 seconds, so a hung endpoint cannot stall the watcher.
+	HTTPClient *http.Client
+	// Retries is the number of additional attempts after a failed delivery.
+	// Zero uses 3; a negative value disables retries.
+	Retries int
+	// Backoff caps the delay before the first retry, which is jittered and
+	// doubled for each one after it as described for asf.RetryPolicy. Zero
+	// uses one second.
+	Backoff time.Duration
+}
+
+// webhookClient sends webhook requests when the Webhook has no HTTPClient.
+var webhookClient = &http.Client{Timeout: 30 * time.Second}
+
+// WebhookPayload is the JSON body posted by a Webhook.
+type WebhookPayload struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Count int       `json:"count"`
+	// Products are flattened as by asf.Products.ToRecords.
+	Products []map[string]any `json:"products"`
+}
+
+// Notify posts the products to the webhook, retrying network errors, 429 and
+// 5xx responses and honoring Retry-After. Nothing is sent for an empty list.
+func (h *Webhook) Notify(ctx context.Context, products []asf.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(WebhookPayload{
+		Event:    "new_products",
+		Time:     time.Now().UTC(),
+		Count:    len(products),
+		Products: asf.Products(products).ToRecords(),
+	})
+	if err != nil {
+		return fmt.Errorf("watch: encode webhook payload: %w", err)
+	}
+
+	retries := h.Retries
+	if retries == 0 {
+		retries = 3
+	}
+	policy := asf.RetryPolicy{MaxAttempts: retries + 1, BaseDelay: cmp.Or(h.Backoff, time.Second)}
+	for attempt := 1; ; attempt++ {
+		resp, retry, err := h.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= policy.MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(policy.Delay(attempt, resp)):
+		}
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying, along with the response, if any, whose body has been drained.
+func (h *Webhook) post(ctx context.Context, body []byte) (*http.Response, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, fmt.Errorf("watch: create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(h.Secret, body))
+	}
+
+	client := h.HTTPClient
+	if client == nil {
+		client = webhookClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("watch: post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return resp, retry, fmt.Errorf("watch: webhook returned status %d", resp.StatusCode)
+}
+
+// Sign returns the SignatureHeader value for body under secret. Receivers
+// should compare it with hmac.Equal.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,61 @@
+package watch
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	var attempts atomic.Int32
+	var payload WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if got := r.Header.Get(SignatureHeader); !hmac.Equal([]byte(got), []byte(Sign("s3cret", body))) {
+			t.Errorf("unexpected signature %q", got)
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	hook := &Webhook{URL: server.URL, Secret: "s3cret", Backoff: time.Millisecond}
+	products := []asf.Product{{Properties: asf.Properties{SceneName: "S1A_SCENE", FileID: "S1A_SCENE-SLC"}}}
+	if err := hook.Notify(context.Background(), products); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Fatalf("expected one retry, got %d attempts", attempts.Load())
+	}
+	if payload.Event != "new_products" || payload.Count != 1 || payload.Products[0]["sceneName"] != "S1A_SCENE" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestWebhookNotifyDoesNotRetryClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.Error(w, "gone", http.StatusGone)
+	}))
+	defer server.Close()
+
+	hook := &Webhook{URL: server.URL, Backoff: time.Millisecond}
+	err := hook.Notify(context.Background(), []asf.Product{{Properties: asf.Properties{SceneName: "S1A_SCENE"}}})
+	if err == nil || attempts.Load() != 1 {
+		t.Fatalf("expected a single failed attempt, got %d attempts and %v", attempts.Load(), err)
+	}
+}
@@ -0,0 +1,97 @@
+package wkt
+
+import (
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// splitAntimeridian splits a polygon crossing the antimeridian into the
+// parts east and west of it, each with longitudes within [-180, 180].
+// Polygons that do not cross it are returned unchanged.
+func splitAntimeridian(polygon asf.Polygon) []asf.Polygon {
+	unwrapped := make(asf.Polygon, len(polygon))
+	crosses := false
+	for i, ring := range polygon {
+		unwrapped[i] = unwrapRing(ring)
+		for _, p := range unwrapped[i] {
+			if p[0] < -180 || p[0] > 180 {
+				crosses = true
+			}
+		}
+	}
+	if !crosses {
+		return []asf.Polygon{polygon}
+	}
+
+	// Clip against each 360-degree window the polygon reaches into, then
+	// shift the pieces back into range.
+	var parts []asf.Polygon
+	for _, offset := range []float64{-360, 0, 360} {
+		lo, hi := -180+offset, 180+offset
+		var part asf.Polygon
+		for i, ring := range unwrapped {
+			clipped := dedupe(clipLongitude(clipLongitude(ring, lo, true), hi, false))
+			if len(clipped) < 3 || signedArea(closeRing(clipped)) == 0 {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+			shifted := make(asf.Ring, len(clipped))
+			for j, p := range clipped {
+				shifted[j] = asf.Position{p[0] - offset, p[1]}
+			}
+			part = append(part, closeRing(shifted))
+		}
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// unwrapRing makes longitudes continuous by undoing jumps of more than 180
+// degrees between consecutive positions, so a ring crossing the antimeridian
+// extends beyond ±180 instead of wrapping around the globe.
+func unwrapRing(ring asf.Ring) asf.Ring {
+	unwrapped := make(asf.Ring, len(ring))
+	var shift float64
+	for i, p := range ring {
+		if i > 0 {
+			switch delta := p[0] - ring[i-1][0]; {
+			case delta > 180:
+				shift -= 360
+			case delta < -180:
+				shift += 360
+			}
+		}
+		unwrapped[i] = asf.Position{p[0] + shift, p[1]}
+	}
+	return unwrapped
+}
+
+// clipLongitude keeps the part of a ring east of limit (keepEast) or west of
+// it, using Sutherland-Hodgman clipping against a meridian. The result is
+// open.
+func clipLongitude(ring asf.Ring, limit float64, keepEast bool) asf.Ring {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	inside := func(p asf.Position) bool {
+		if keepEast {
+			return p[0] >= limit
+		}
+		return p[0] <= limit
+	}
+	var out asf.Ring
+	for i := range ring {
+		p, q := ring[i], ring[(i+1)%len(ring)]
+		if inside(p) {
+			out = append(out, p)
+		}
+		if inside(p) != inside(q) {
+			t := (limit - p[0]) / (q[0] - p[0])
+			out = append(out, asf.Position{limit, p[1] + t*(q[1]-p[1])})
+		}
+	}
+	return out
+}
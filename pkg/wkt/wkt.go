@@ -0,0 +1,213 @@
+// Package wkt validates and repairs the WKT polygons used as search areas,
+// catching geometry problems locally instead of as opaque 400 responses
+// from the search API.
+package wkt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+// ErrInvalid is wrapped by every validation failure.
+var ErrInvalid = errors.New("wkt: invalid geometry")
+
+// Validate reports whether s is a WKT POLYGON or MULTIPOLYGON the search API
+// accepts: every ring closed with at least three distinct positions,
+// coordinates within longitude [-180, 180] and latitude [-90, 90], non-zero
+// area and no self-intersections. Failures wrap ErrInvalid; Repair fixes
+// most of them.
+func Validate(s string) error {
+	geometry, err := asf.ParseWKT(s)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	if geometry.IsEmpty() {
+		return fmt.Errorf("%w: empty geometry", ErrInvalid)
+	}
+	for i, polygon := range geometry.Polygons {
+		for j, ring := range polygon {
+			if err := validateRing(ring); err != nil {
+				return fmt.Errorf("%w: polygon %d ring %d: %w", ErrInvalid, i+1, j+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateRing(ring asf.Ring) error {
+	if len(ring) < 4 {
+		return fmt.Errorf("ring has %d positions, need at least 4", len(ring))
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return errors.New("ring is not closed")
+	}
+	for _, p := range ring {
+		if p[0] < -180 || p[0] > 180 || p[1] < -90 || p[1] > 90 {
+			return fmt.Errorf("position (%g %g) out of range", p[0], p[1])
+		}
+	}
+	if signedArea(ring) == 0 {
+		return errors.New("ring has zero area")
+	}
+	if i, j, ok := selfIntersection(ring); ok {
+		return fmt.Errorf("edges %d and %d intersect", i+1, j+1)
+	}
+	return nil
+}
+
+// Repair returns a corrected version of s: rings are closed and stripped of
+// repeated positions, exteriors are wound counter-clockwise and holes
+// clockwise as the search API expects, and polygons crossing the
+// antimeridian, written with longitudes beyond ±180 or with a jump of more
+// than 180 degrees between positions, are split into a MULTIPOLYGON on
+// either side of it. Self-intersections are not repaired; check the result
+// with Validate.
+func Repair(s string) (string, error) {
+	geometry, err := asf.ParseWKT(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	var repaired asf.Geometry
+	for _, polygon := range geometry.Polygons {
+		var rings asf.Polygon
+		for i, ring := range polygon {
+			ring = closeRing(dedupe(ring))
+			if len(ring) < 4 {
+				if i == 0 {
+					return "", fmt.Errorf("%w: ring has fewer than 3 distinct positions", ErrInvalid)
+				}
+				continue
+			}
+			rings = append(rings, orient(unwrapRing(ring), i == 0))
+		}
+		repaired.Polygons = append(repaired.Polygons, splitAntimeridian(rings)...)
+	}
+	repaired.Type = "Polygon"
+	if len(repaired.Polygons) > 1 {
+		repaired.Type = "MultiPolygon"
+	}
+	return repaired.WKT(), nil
+}
+
+// RepairRemote asks ASF's WKT utility endpoint to repair s and returns the
+// wrapped geometry it produces, along with its description of each repair.
+// Use it when the API's own interpretation of a polygon matters more than a
+// local repair.
+func RepairRemote(ctx context.Context, client *asf.Client, s string) (string, []string, error) {
+	resp, err := client.Raw(ctx, "services/utils/wkt", url.Values{"wkt": {s}})
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", nil, fmt.Errorf("wkt: unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var payload struct {
+		WKT struct {
+			Wrapped string `json:"wrapped"`
+		} `json:"wkt"`
+		Repairs []struct {
+			Type   string `json:"type"`
+			Report string `json:"report"`
+		} `json:"repairs"`
+		Error struct {
+			Type   string `json:"type"`
+			Report string `json:"report"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", nil, fmt.Errorf("wkt: decode repair response: %w", err)
+	}
+	if payload.Error.Report != "" {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalid, payload.Error.Report)
+	}
+	if payload.WKT.Wrapped == "" {
+		return "", nil, errors.New("wkt: repair response has no geometry")
+	}
+	var repairs []string
+	for _, repair := range payload.Repairs {
+		repairs = append(repairs, repair.Report)
+	}
+	return payload.WKT.Wrapped, repairs, nil
+}
+
+// dedupe drops consecutive repeated positions.
+func dedupe(ring asf.Ring) asf.Ring {
+	return slices.Compact(slices.Clone(ring))
+}
+
+// closeRing appends the first position if the ring is open.
+func closeRing(ring asf.Ring) asf.Ring {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+	return ring
+}
+
+// orient winds exteriors counter-clockwise and holes clockwise.
+func orient(ring asf.Ring, exterior bool) asf.Ring {
+	if ccw := signedArea(ring) > 0; ccw != exterior {
+		ring = slices.Clone(ring)
+		slices.Reverse(ring)
+	}
+	return ring
+}
+
+// signedArea returns the shoelace area of a ring, positive when it is wound
+// counter-clockwise.
+func signedArea(ring asf.Ring) float64 {
+	var area float64
+	for i := range ring {
+		p, q := ring[i], ring[(i+1)%len(ring)]
+		area += p[0]*q[1] - q[0]*p[1]
+	}
+	return area / 2
+}
+
+// selfIntersection returns the first pair of non-adjacent edges of a closed
+// ring that touch or cross.
+func selfIntersection(ring asf.Ring) (int, int, bool) {
+	edges := len(ring) - 1
+	for i := 0; i < edges; i++ {
+		for j := i + 2; j < edges; j++ {
+			if i == 0 && j == edges-1 {
+				continue // the closing edge shares the first position
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return i, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func segmentsIntersect(a, b, c, d asf.Position) bool {
+	d1, d2 := orientation(c, d, a), orientation(c, d, b)
+	d3, d4 := orientation(a, b, c), orientation(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(c, d, a)) || (d2 == 0 && onSegment(c, d, b)) ||
+		(d3 == 0 && onSegment(a, b, c)) || (d4 == 0 && onSegment(a, b, d))
+}
+
+func orientation(a, b, c asf.Position) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// onSegment reports whether p, known to be collinear with a and b, lies
+// between them.
+func onSegment(a, b, p asf.Position) bool {
+	return min(a[0], b[0]) <= p[0] && p[0] <= max(a[0], b[0]) &&
+		min(a[1], b[1]) <= p[1] && p[1] <= max(a[1], b[1])
+}
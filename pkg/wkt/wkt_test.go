@@ -0,0 +1,87 @@
+package wkt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/robert-malhotra/go-asf/pkg/asf"
+)
+
+func TestValidate(t *testing.T) {
+	if err := Validate("POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))"); err != nil {
+		t.Fatalf("expected valid polygon, got %v", err)
+	}
+	for name, s := range map[string]string{
+		"syntax":      "POLYGON ((0 0, 1 0",
+		"open":        "POLYGON ((0 0, 1 0, 1 1, 0 1))",
+		"short":       "POLYGON ((0 0, 1 0, 0 0))",
+		"range":       "POLYGON ((170 0, 190 0, 190 10, 170 10, 170 0))",
+		"flat":        "POLYGON ((0 0, 1 0, 2 0, 0 0))",
+		"bowtie":      "POLYGON ((0 0, 1 1, 1 0, 0 1, 0 0))",
+		"empty":       "POLYGON EMPTY",
+		"unsupported": "POINT (1 1)",
+	} {
+		if err := Validate(s); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: expected ErrInvalid, got %v", name, err)
+		}
+	}
+}
+
+func TestRepair(t *testing.T) {
+	for name, tc := range map[string]struct{ in, want string }{
+		"close and wind": {
+			"POLYGON ((0 0, 0 1, 1 1, 1 1, 1 0))",
+			"POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))",
+		},
+		"hole winding": {
+			"POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 3 1, 3 3, 1 3, 1 1))",
+			"POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 1 3, 3 3, 3 1, 1 1))",
+		},
+		"antimeridian jump": {
+			"POLYGON ((170 0, -170 0, -170 10, 170 10, 170 0))",
+			"MULTIPOLYGON (((170 0, 180 0, 180 10, 170 10, 170 0)), ((-180 0, -170 0, -170 10, -180 10, -180 0)))",
+		},
+		"beyond 180": {
+			"POLYGON ((170 0, 190 0, 190 10, 170 10, 170 0))",
+			"MULTIPOLYGON (((170 0, 180 0, 180 10, 170 10, 170 0)), ((-180 0, -170 0, -170 10, -180 10, -180 0)))",
+		},
+	} {
+		got, err := Repair(tc.in)
+		if err != nil {
+			t.Errorf("%s: Repair returned error: %v", name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s:\n got %s\nwant %s", name, got, tc.want)
+		}
+		if err := Validate(got); err != nil {
+			t.Errorf("%s: repaired WKT is invalid: %v", name, err)
+		}
+	}
+
+	if _, err := Repair("POLYGON ((0 0, 0 0, 0 0))"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for degenerate ring, got %v", err)
+	}
+}
+
+func TestRepairRemote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/utils/wkt" || r.URL.Query().Get("wkt") == "" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Write([]byte(`{"wkt":{"wrapped":"POLYGON ((0 0, 1 0, 1 1, 0 0))","unwrapped":"POLYGON ((0 0, 1 0, 1 1, 0 0))"},
+			"repairs":[{"type":"REVERSE","report":"Reversed polygon winding order"}]}`))
+	}))
+	defer server.Close()
+
+	repaired, repairs, err := RepairRemote(context.Background(), asf.NewClient(asf.WithBaseURL(server.URL)), "POLYGON ((0 0, 1 1, 1 0, 0 0))")
+	if err != nil {
+		t.Fatalf("RepairRemote returned error: %v", err)
+	}
+	if repaired != "POLYGON ((0 0, 1 0, 1 1, 0 0))" || len(repairs) != 1 || repairs[0] != "Reversed polygon winding order" {
+		t.Fatalf("unexpected repair: %s %v", repaired, repairs)
+	}
+}